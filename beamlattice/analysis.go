@@ -0,0 +1,101 @@
+package beamlattice
+
+import (
+	"math"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// Stats contains geometric measures of a group of beams.
+//
+// Volume is an approximation of the material volume that adds up
+// the truncated cones of the beams and their caps.
+// Sphere and hemisphere caps contribute with a hemisphere and butt caps do not
+// contribute. Overlaps at the beam joints are not subtracted.
+type Stats struct {
+	Beams     int
+	Length    float64 // Total strut length.
+	MinLength float64 // Length of the shortest beam.
+	MaxLength float64 // Length of the longest beam.
+	Volume    float64
+}
+
+func (s *Stats) add(length, volume float64) {
+	if s.Beams == 0 || length < s.MinLength {
+		s.MinLength = length
+	}
+	if length > s.MaxLength {
+		s.MaxLength = length
+	}
+	s.Beams++
+	s.Length += length
+	s.Volume += volume
+}
+
+// An Analysis contains geometric measures of a beam lattice.
+//
+// The lattice Volume also includes the balls.
+// Valence contains the number of beams connected to each vertex
+// and BeamSets the measures of each beam set, in the same order as in the lattice.
+type Analysis struct {
+	Stats
+	Valence  []int
+	BeamSets []Stats
+}
+
+// Analyze measures the beam lattice bl, whose mesh vertices are vertices.
+// Beams and references that are out of bounds are ignored.
+func Analyze(vertices []go3mf.Point3D, bl *BeamLattice) *Analysis {
+	a := &Analysis{
+		Valence:  make([]int, len(vertices)),
+		BeamSets: make([]Stats, len(bl.BeamSets)),
+	}
+	lengths := make([]float64, len(bl.Beams))
+	volumes := make([]float64, len(bl.Beams))
+	valid := make([]bool, len(bl.Beams))
+	for i, b := range bl.Beams {
+		if int(b.Indices[0]) >= len(vertices) || int(b.Indices[1]) >= len(vertices) {
+			continue
+		}
+		valid[i] = true
+		lengths[i] = beamLength(vertices, b)
+		volumes[i] = beamVolume(bl, b, lengths[i])
+		a.Stats.add(lengths[i], volumes[i])
+		a.Valence[b.Indices[0]]++
+		a.Valence[b.Indices[1]]++
+	}
+	for _, b := range balls(bl) {
+		if int(b.Index) < len(vertices) {
+			a.Volume += 4 * math.Pi * math.Pow(float64(b.Radius), 3) / 3
+		}
+	}
+	for i, set := range bl.BeamSets {
+		for _, ref := range set.Refs {
+			if int(ref) < len(valid) && valid[ref] {
+				a.BeamSets[i].add(lengths[ref], volumes[ref])
+			}
+		}
+	}
+	return a
+}
+
+func beamLength(vertices []go3mf.Point3D, b Beam) float64 {
+	return float64(vertices[b.Indices[1]].Sub(vertices[b.Indices[0]]).Len())
+}
+
+func beamVolume(bl *BeamLattice, b Beam, length float64) float64 {
+	r0, r1 := float64(b.Radius[0]), float64(b.Radius[1])
+	if r0 == 0 {
+		r0 = float64(bl.Radius)
+	}
+	if r1 == 0 {
+		r1 = r0
+	}
+	volume := math.Pi * length * (r0*r0 + r0*r1 + r1*r1) / 3
+	for i, r := range [2]float64{r0, r1} {
+		if b.CapMode[i] != CapModeButt {
+			volume += 2 * math.Pi * r * r * r / 3
+		}
+	}
+	return volume
+}
@@ -0,0 +1,39 @@
+package beamlattice
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+func TestAnalyze(t *testing.T) {
+	vertices := []go3mf.Point3D{{0, 0, 0}, {0, 0, 3}, {4, 0, 3}}
+	bl := &BeamLattice{Radius: 1, BallMode: BallModeMixed, BallRadius: 2, Beams: []Beam{
+		{Indices: [2]uint32{0, 1}, CapMode: [2]CapMode{CapModeButt, CapModeButt}},
+		{Indices: [2]uint32{1, 2}, Radius: [2]float32{2, 1}, CapMode: [2]CapMode{CapModeSphere, CapModeHemisphere}},
+		{Indices: [2]uint32{0, 2}, Radius: [2]float32{1, 1}, CapMode: [2]CapMode{CapModeButt, CapModeButt}},
+		{Indices: [2]uint32{0, 10}},
+	}, BeamSets: []BeamSet{
+		{Identifier: "a", Refs: []uint32{0, 2, 3, 100}},
+		{Identifier: "b", Refs: []uint32{1}},
+	}, Balls: []Ball{{Index: 1}, {Index: 10}}}
+	v0 := math.Pi * 3
+	v1 := math.Pi*4*(4+2+1)/3 + 2*math.Pi*8/3 + 2*math.Pi/3
+	v2 := math.Pi * 5
+	ball := 4 * math.Pi * 8 / 3
+	want := &Analysis{
+		Stats:   Stats{Beams: 3, Length: 12, MinLength: 3, MaxLength: 5, Volume: v0 + v1 + v2 + ball},
+		Valence: []int{2, 2, 2},
+		BeamSets: []Stats{
+			{Beams: 2, Length: 8, MinLength: 3, MaxLength: 5, Volume: v0 + v2},
+			{Beams: 1, Length: 4, MinLength: 4, MaxLength: 4, Volume: v1},
+		},
+	}
+	got := Analyze(vertices, bl)
+	deep.FloatPrecision = 6
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Analyze() = %v", diff)
+	}
+}
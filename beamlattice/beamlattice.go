@@ -3,6 +3,9 @@ package beamlattice
 // Namespace is the canonical name of this extension.
 const Namespace = "http://schemas.microsoft.com/3dmanufacturing/beamlattice/2017/02"
 
+// NamespaceBalls is the canonical name of the balls addendum of this extension.
+const NamespaceBalls = "http://schemas.microsoft.com/3dmanufacturing/beamlattice/balls/2020/07"
+
 type Spec struct {
 	LocalName string
 }
@@ -73,6 +76,33 @@ func (b CapMode) String() string {
 	}[b]
 }
 
+// BallMode defines the modes in which balls are placed at the beam joints.
+type BallMode uint8
+
+// Supported ball modes.
+const (
+	BallModeNone BallMode = iota
+	BallModeAll
+	BallModeMixed
+)
+
+func newBallMode(s string) (b BallMode, ok bool) {
+	b, ok = map[string]BallMode{
+		"none":  BallModeNone,
+		"all":   BallModeAll,
+		"mixed": BallModeMixed,
+	}[s]
+	return
+}
+
+func (b BallMode) String() string {
+	return map[BallMode]string{
+		BallModeNone:  "none",
+		BallModeAll:   "all",
+		BallModeMixed: "mixed",
+	}[b]
+}
+
 // BeamLattice defines the Model Mesh BeamLattice Attributes class and is part of the BeamLattice extension to 3MF.
 type BeamLattice struct {
 	ClipMode             ClipMode
@@ -82,11 +112,15 @@ type BeamLattice struct {
 	BeamSets             []BeamSet
 	MinLength, Radius    float32
 	CapMode              CapMode
+	BallMode             BallMode
+	BallRadius           float32
+	Balls                []Ball
 }
 
 // BeamSet defines a set of beams.
 type BeamSet struct {
 	Refs       []uint32
+	BallRefs   []uint32
 	Name       string
 	Identifier string
 }
@@ -98,6 +132,12 @@ type Beam struct {
 	CapMode [2]CapMode // Capping mode.
 }
 
+// Ball defines a spherical node placed at a beam vertex.
+type Ball struct {
+	Index  uint32  // Index of the vertex where the ball is centered.
+	Radius float32 // Radius of the ball.
+}
+
 const (
 	attrBeamLattice        = "beamlattice"
 	attrRadius             = "radius"
@@ -123,4 +163,11 @@ const (
 	attrIdentifier         = "identifier"
 	attrRef                = "ref"
 	attrIndex              = "index"
+	attrBallMode           = "ballmode"
+	attrBallRadius         = "ballradius"
+	attrBalls              = "balls"
+	attrBall               = "ball"
+	attrVIndex             = "vindex"
+	attrR                  = "r"
+	attrBallRef            = "ballref"
 )
@@ -13,6 +13,7 @@ var (
 	jsBeamLattice    = go3mf.RegisterClass("BeamLattice", "X", jsNS)
 	jsBeam           = go3mf.RegisterClass("Beam", "X", jsNS)
 	jsBeamSet        = go3mf.RegisterClass("BeamSet", "X", jsNS)
+	jsBall           = go3mf.RegisterClass("Ball", "X", jsNS)
 )
 
 // JSValue returns a JavaScript value associated with the object.
@@ -42,6 +43,19 @@ func (bs BeamSet) JSValue() js.Value {
 		sr.SetIndex(i, b)
 	}
 	v.Set(attrRef, sr)
+	sbr := arrayConstructor.New(len(bs.BallRefs))
+	for i, b := range bs.BallRefs {
+		sbr.SetIndex(i, b)
+	}
+	v.Set("ballRef", sbr)
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+func (b Ball) JSValue() js.Value {
+	v := jsBall.New()
+	v.Set(attrVIndex, b.Index)
+	v.Set(attrR, b.Radius)
 	return v
 }
 
@@ -74,6 +88,14 @@ func (bl *BeamLattice) JSValue() js.Value {
 		sbs.SetIndex(i, bs)
 	}
 	v.Set(attrBeamSets, sbs)
+
+	v.Set("ballMode", bl.BallMode.String())
+	v.Set("ballRadius", bl.BallRadius)
+	sbl := arrayConstructor.New(len(bl.Balls))
+	for i, b := range bl.Balls {
+		sbl.SetIndex(i, b)
+	}
+	v.Set(attrBalls, sbl)
 	return v
 }
 
@@ -94,3 +94,45 @@ func TestClipMode_String(t *testing.T) {
 		})
 	}
 }
+
+func Test_newBallMode(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantB  BallMode
+		wantOk bool
+	}{
+		{"none", BallModeNone, true},
+		{"all", BallModeAll, true},
+		{"mixed", BallModeMixed, true},
+		{"empty", BallModeNone, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotB, gotOk := newBallMode(tt.name)
+			if !reflect.DeepEqual(gotB, tt.wantB) {
+				t.Errorf("newBallMode() gotB = %v, want %v", gotB, tt.wantB)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("newBallMode() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestBallMode_String(t *testing.T) {
+	tests := []struct {
+		name string
+		b    BallMode
+	}{
+		{"none", BallModeNone},
+		{"all", BallModeAll},
+		{"mixed", BallModeMixed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.String(); got != tt.name {
+				t.Errorf("BallMode.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
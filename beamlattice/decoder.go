@@ -29,6 +29,10 @@ func (d *beamLatticeDecoder) Start(attrs []xml.Attr) {
 	beamLattice := new(BeamLattice)
 	d.mesh.Any = append(d.mesh.Any, beamLattice)
 	for _, a := range attrs {
+		if a.Name.Space == NamespaceBalls {
+			d.ballAttr(beamLattice, a)
+			continue
+		}
 		if a.Name.Space != "" {
 			continue
 		}
@@ -73,6 +77,23 @@ func (d *beamLatticeDecoder) Start(attrs []xml.Attr) {
 	}
 }
 
+func (d *beamLatticeDecoder) ballAttr(beamLattice *BeamLattice, a xml.Attr) {
+	switch a.Name.Local {
+	case attrBallMode:
+		var ok bool
+		beamLattice.BallMode, ok = newBallMode(a.Value)
+		if !ok {
+			d.Scanner.InvalidAttr(a.Name.Local, false)
+		}
+	case attrBallRadius:
+		val, err := strconv.ParseFloat(a.Value, 32)
+		if err != nil {
+			d.Scanner.InvalidAttr(a.Name.Local, false)
+		}
+		beamLattice.BallRadius = float32(val)
+	}
+}
+
 func (d *beamLatticeDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
 	if name.Space == Namespace {
 		if name.Local == attrBeams {
@@ -80,6 +101,8 @@ func (d *beamLatticeDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
 		} else if name.Local == attrBeamSets {
 			child = &beamSetsDecoder{mesh: d.mesh}
 		}
+	} else if name.Space == NamespaceBalls && name.Local == attrBalls {
+		child = &ballsDecoder{mesh: d.mesh}
 	}
 	return
 }
@@ -171,6 +194,57 @@ func (d *beamDecoder) Start(attrs []xml.Attr) {
 	beamLattice.Beams = append(beamLattice.Beams, beam)
 }
 
+type ballsDecoder struct {
+	baseDecoder
+	mesh        *go3mf.Mesh
+	ballDecoder ballDecoder
+}
+
+func (d *ballsDecoder) Start(_ []xml.Attr) {
+	d.ballDecoder.mesh = d.mesh
+}
+
+func (d *ballsDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
+	if name.Space == NamespaceBalls && name.Local == attrBall {
+		child = &d.ballDecoder
+	}
+	return
+}
+
+type ballDecoder struct {
+	baseDecoder
+	mesh *go3mf.Mesh
+}
+
+func (d *ballDecoder) Start(attrs []xml.Attr) {
+	var ball Ball
+	var beamLattice *BeamLattice
+	d.mesh.Any.Get(&beamLattice)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrVIndex:
+			val, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			ball.Index = uint32(val)
+		case attrR:
+			val, err := strconv.ParseFloat(a.Value, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, false)
+			}
+			ball.Radius = float32(val)
+		}
+	}
+	if ball.Radius == 0 {
+		ball.Radius = beamLattice.BallRadius
+	}
+	beamLattice.Balls = append(beamLattice.Balls, ball)
+}
+
 type beamSetsDecoder struct {
 	baseDecoder
 	mesh *go3mf.Mesh
@@ -188,6 +262,7 @@ type beamSetDecoder struct {
 	mesh           *go3mf.Mesh
 	beamSet        BeamSet
 	beamRefDecoder beamRefDecoder
+	ballRefDecoder ballRefDecoder
 }
 
 func (d *beamSetDecoder) End() {
@@ -198,6 +273,7 @@ func (d *beamSetDecoder) End() {
 
 func (d *beamSetDecoder) Start(attrs []xml.Attr) {
 	d.beamRefDecoder.beamSet = &d.beamSet
+	d.ballRefDecoder.beamSet = &d.beamSet
 	for _, a := range attrs {
 		if a.Name.Space != "" {
 			continue
@@ -214,6 +290,8 @@ func (d *beamSetDecoder) Start(attrs []xml.Attr) {
 func (d *beamSetDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
 	if name.Space == Namespace && name.Local == attrRef {
 		child = &d.beamRefDecoder
+	} else if name.Space == NamespaceBalls && name.Local == attrBallRef {
+		child = &d.ballRefDecoder
 	}
 	return
 }
@@ -235,6 +313,23 @@ func (d *beamRefDecoder) Start(attrs []xml.Attr) {
 	}
 }
 
+type ballRefDecoder struct {
+	baseDecoder
+	beamSet *BeamSet
+}
+
+func (d *ballRefDecoder) Start(attrs []xml.Attr) {
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrIndex {
+			val, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			d.beamSet.BallRefs = append(d.beamSet.BallRefs, uint32(val))
+		}
+	}
+}
+
 type baseDecoder struct {
 	Scanner *go3mf.Scanner
 }
@@ -29,7 +29,10 @@ func TestDecode(t *testing.T) {
 		{55, 45, 55},
 		{55, 45, 45},
 	}...)
-	beamLattice.BeamSets = append(beamLattice.BeamSets, BeamSet{Name: "test", Identifier: "set_id", Refs: []uint32{1}})
+	beamLattice.BallMode = BallModeMixed
+	beamLattice.BallRadius = 2
+	beamLattice.Balls = append(beamLattice.Balls, []Ball{{Index: 0, Radius: 2}, {Index: 5, Radius: 2.5}}...)
+	beamLattice.BeamSets = append(beamLattice.BeamSets, BeamSet{Name: "test", Identifier: "set_id", Refs: []uint32{1}, BallRefs: []uint32{1}})
 	beamLattice.Beams = append(beamLattice.Beams, []Beam{
 		{Indices: [2]uint32{0, 1}, Radius: [2]float32{1.5, 1.6}, CapMode: [2]CapMode{CapModeSphere, CapModeButt}},
 		{Indices: [2]uint32{2, 0}, Radius: [2]float32{3, 1.5}, CapMode: [2]CapMode{CapModeSphere, CapModeHemisphere}},
@@ -66,7 +69,7 @@ func TestDecode(t *testing.T) {
 						<vertex x="55.00000" y="45.00000" z="45.00000"/>
 					</vertices>
 					<b:other/>
-					<b:beamlattice radius="1" minlength="0.0001" cap="hemisphere" clippingmode="inside" clippingmesh="8" representationmesh="8">
+					<b:beamlattice xmlns:balls="http://schemas.microsoft.com/3dmanufacturing/beamlattice/balls/2020/07" radius="1" minlength="0.0001" cap="hemisphere" clippingmode="inside" clippingmesh="8" representationmesh="8" balls:ballmode="mixed" balls:ballradius="2">
 						<b:beams>
 							<b:beam v1="0" v2="1" r1="1.50000" r2="1.60000" cap1="sphere" cap2="butt"/>
 							<b:beam v1="2" v2="0" r1="3.00000" r2="1.50000" cap1="sphere"/>
@@ -81,9 +84,14 @@ func TestDecode(t *testing.T) {
 							<b:beam v1="7" v2="3" r1="2.00000" r2="3.00000"/>
 							<b:beam v1="0" v2="5" r1="1.50000" r2="2.00000" cap2="butt"/>
 						</b:beams>
+						<balls:balls>
+							<balls:ball vindex="0"/>
+							<balls:ball vindex="5" r="2.5"/>
+						</balls:balls>
 						<b:beamsets>
 							<b:beamset name="test" identifier="set_id">
 								<b:ref index="1"/>
+								<balls:ballref index="1"/>
 							</b:beamset>
 						</b:beamsets>
 					</b:beamlattice>
@@ -113,7 +121,11 @@ func TestDecode_warns(t *testing.T) {
 	want := &errors.List{Errors: []error{
 		&errors.ParseFieldError{Required: false, ResourceID: 15, Name: "cap", Context: "model@resources@object@mesh@beamlattice"},
 		&errors.ParseFieldError{Required: false, ResourceID: 15, Name: "clippingmode", Context: "model@resources@object@mesh@beamlattice"},
+		&errors.ParseFieldError{Required: false, ResourceID: 15, Name: "ballmode", Context: "model@resources@object@mesh@beamlattice"},
+		&errors.ParseFieldError{Required: true, ResourceID: 15, Name: "vindex", Context: "model@resources@object@mesh@beamlattice@balls@ball"},
+		&errors.ParseFieldError{Required: false, ResourceID: 15, Name: "r", Context: "model@resources@object@mesh@beamlattice@balls@ball"},
 		&errors.ParseFieldError{Required: true, ResourceID: 15, Name: "index", Context: "model@resources@object@mesh@beamlattice@beamsets@beamset@ref"},
+		&errors.ParseFieldError{Required: true, ResourceID: 15, Name: "index", Context: "model@resources@object@mesh@beamlattice@beamsets@beamset@ballref"},
 	}}
 	got := new(go3mf.Model)
 	got.Path = "/3D/3dmodel.model"
@@ -133,7 +145,7 @@ func TestDecode_warns(t *testing.T) {
 						<vertex x="55.00000" y="45.00000" z="45.00000"/>
 					</vertices>
 					<b:beamlattice />
-					<b:beamlattice qm:mq="other" radius="1" minlength="0.0001" cap="invalid" clippingmode="invalid2" clippingmesh="8" representationmesh="8">
+					<b:beamlattice xmlns:balls="http://schemas.microsoft.com/3dmanufacturing/beamlattice/balls/2020/07" qm:mq="other" radius="1" minlength="0.0001" cap="invalid" clippingmode="invalid2" clippingmesh="8" representationmesh="8" balls:ballmode="invalid3">
 						<b:beams>
 							<b:beam qm:mq="other" v1="0" v2="1" r1="1.50000" r2="1.60000" cap1="sphere" cap2="butt"/>
 							<b:beam v1="2" v2="0" r1="3.00000" r2="1.50000" cap1="sphere"/>
@@ -149,11 +161,15 @@ func TestDecode_warns(t *testing.T) {
 							<b:beam v1="7" v2="3" r1="2.00000" r2="3.00000"/>
 							<b:beam v1="0" v2="5" r1="1.50000" r2="2.00000" cap2="butt"/>
 						</b:beams>
+						<balls:balls>
+							<balls:ball vindex="a" r="b"/>
+						</balls:balls>
 						<b:beamsets>
 							<b:beamset qm:mq="other" name="test" identifier="set_id">
 								<b:ref index="1"/>
 								<b:ref />
 								<b:ref index="a"/>
+								<balls:ballref index="a"/>
 							</b:beamset>
 						</b:beamsets>
 					</b:beamlattice>
@@ -31,9 +31,24 @@ func (m *BeamLattice) Marshal3MF(x *go3mf.XMLEncoder) error {
 	if m.CapMode != CapModeSphere {
 		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrCap}, Value: m.CapMode.String()})
 	}
+	if m.hasBalls() {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Space: "xmlns", Local: "balls"}, Value: NamespaceBalls})
+		if m.BallMode != BallModeNone {
+			xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Space: NamespaceBalls, Local: attrBallMode}, Value: m.BallMode.String()})
+		}
+		if m.BallRadius != 0 {
+			xs.Attr = append(xs.Attr, xml.Attr{
+				Name:  xml.Name{Space: NamespaceBalls, Local: attrBallRadius},
+				Value: strconv.FormatFloat(float64(m.BallRadius), 'f', x.FloatPresicion(), 32),
+			})
+		}
+	}
 	x.EncodeToken(xs)
 
 	marshalBeams(x, m)
+	if len(m.Balls) > 0 {
+		marshalBalls(x, m)
+	}
 	marshalBeamsets(x, m)
 
 	x.EncodeToken(xs.End())
@@ -58,6 +73,11 @@ func marshalBeamsets(x *go3mf.XMLEncoder, m *BeamLattice) {
 				{Name: xml.Name{Local: attrIndex}, Value: strconv.FormatUint(uint64(ref), 10)},
 			}})
 		}
+		for _, ref := range bs.BallRefs {
+			x.EncodeToken(xml.StartElement{Name: xml.Name{Space: NamespaceBalls, Local: attrBallRef}, Attr: []xml.Attr{
+				{Name: xml.Name{Local: attrIndex}, Value: strconv.FormatUint(uint64(ref), 10)},
+			}})
+		}
 		x.SetAutoClose(false)
 		x.EncodeToken(xbs.End())
 	}
@@ -96,3 +116,37 @@ func marshalBeams(x *go3mf.XMLEncoder, m *BeamLattice) {
 	x.SetAutoClose(false)
 	x.EncodeToken(xb.End())
 }
+
+func marshalBalls(x *go3mf.XMLEncoder, m *BeamLattice) {
+	xb := xml.StartElement{Name: xml.Name{Space: NamespaceBalls, Local: attrBalls}}
+	x.EncodeToken(xb)
+	x.SetAutoClose(true)
+	for _, b := range m.Balls {
+		xball := xml.StartElement{Name: xml.Name{Space: NamespaceBalls, Local: attrBall}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrVIndex}, Value: strconv.FormatUint(uint64(b.Index), 10)},
+		}}
+		// The radius is always written, as the decoder resolves the missing ones
+		// to the lattice ball radius, which can be changed after decoding.
+		if b.Radius > 0 {
+			xball.Attr = append(xball.Attr, xml.Attr{
+				Name:  xml.Name{Local: attrR},
+				Value: strconv.FormatFloat(float64(b.Radius), 'f', x.FloatPresicion(), 32),
+			})
+		}
+		x.EncodeToken(xball)
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xb.End())
+}
+
+func (m *BeamLattice) hasBalls() bool {
+	if m.BallMode != BallModeNone || len(m.Balls) > 0 {
+		return true
+	}
+	for _, bs := range m.BeamSets {
+		if len(bs.BallRefs) > 0 {
+			return true
+		}
+	}
+	return false
+}
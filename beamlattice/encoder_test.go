@@ -1,6 +1,7 @@
 package beamlattice
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/go-test/deep"
@@ -28,7 +29,10 @@ func TestMarshalModel(t *testing.T) {
 		{55, 45, 55},
 		{55, 45, 45},
 	}...)
-	beamLattice.BeamSets = append(beamLattice.BeamSets, BeamSet{Name: "test", Identifier: "set_id", Refs: []uint32{1}})
+	beamLattice.BallMode = BallModeMixed
+	beamLattice.BallRadius = 2
+	beamLattice.Balls = append(beamLattice.Balls, []Ball{{Index: 0, Radius: 2}, {Index: 5, Radius: 2.5}}...)
+	beamLattice.BeamSets = append(beamLattice.BeamSets, BeamSet{Name: "test", Identifier: "set_id", Refs: []uint32{1}, BallRefs: []uint32{1}})
 	beamLattice.Beams = append(beamLattice.Beams, []Beam{
 		{Indices: [2]uint32{0, 1}, Radius: [2]float32{1.5, 1.6}, CapMode: [2]CapMode{CapModeSphere, CapModeButt}},
 		{Indices: [2]uint32{2, 0}, Radius: [2]float32{3, 1.5}, CapMode: [2]CapMode{CapModeSphere, CapModeHemisphere}},
@@ -66,4 +70,14 @@ func TestMarshalModel(t *testing.T) {
 			t.Errorf("beamlattice.MarshalModel() = %v, s = %s", diff, string(b))
 		}
 	})
+	t.Run("ballRadius", func(t *testing.T) {
+		b, err := go3mf.MarshalModel(m)
+		if err != nil {
+			t.Errorf("beamlattice.MarshalModel() error = %v", err)
+			return
+		}
+		if !strings.Contains(string(b), `<balls:ball vindex="0" r="2.0000"/>`) {
+			t.Errorf("beamlattice.MarshalModel() ball radius not written, s = %s", string(b))
+		}
+	})
 }
@@ -0,0 +1,199 @@
+package beamlattice
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// A UnitCell defines the nodes and the struts of a lattice cell.
+// Nodes are expressed in normalized cell coordinates, in the range [0, 1],
+// and each edge references two nodes.
+type UnitCell struct {
+	Name  string
+	Nodes []go3mf.Point3D
+	Edges [][2]uint32
+}
+
+var cubeCorners = []go3mf.Point3D{
+	{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {0, 1, 0},
+	{0, 0, 1}, {1, 0, 1}, {1, 1, 1}, {0, 1, 1},
+}
+
+// Nodes 8 to 13 are the face centers: -x, +x, -y, +y, -z, +z.
+var faceCenters = []go3mf.Point3D{
+	{0, 0.5, 0.5}, {1, 0.5, 0.5}, {0.5, 0, 0.5}, {0.5, 1, 0.5}, {0.5, 0.5, 0}, {0.5, 0.5, 1},
+}
+
+// Predefined unit cells.
+var (
+	// CellCubic has struts along the edges of the cube.
+	CellCubic = UnitCell{
+		Name:  "cubic",
+		Nodes: cubeCorners,
+		Edges: [][2]uint32{
+			{0, 1}, {1, 2}, {2, 3}, {3, 0},
+			{4, 5}, {5, 6}, {6, 7}, {7, 4},
+			{0, 4}, {1, 5}, {2, 6}, {3, 7},
+		},
+	}
+	// CellBCC is the body centered cubic cell,
+	// with struts from the center of the cube to its corners.
+	CellBCC = UnitCell{
+		Name:  "bcc",
+		Nodes: append(cubeCorners[:8:8], go3mf.Point3D{0.5, 0.5, 0.5}),
+		Edges: [][2]uint32{{8, 0}, {8, 1}, {8, 2}, {8, 3}, {8, 4}, {8, 5}, {8, 6}, {8, 7}},
+	}
+	// CellFCC is the face centered cubic cell,
+	// with struts along the diagonals of the cube faces.
+	CellFCC = UnitCell{
+		Name:  "fcc",
+		Nodes: append(cubeCorners[:8:8], faceCenters...),
+		Edges: faceDiagonals,
+	}
+	// CellOctet is the octet truss cell, which extends CellFCC
+	// with the octahedron that connects the face centers.
+	CellOctet = UnitCell{
+		Name:  "octet",
+		Nodes: append(cubeCorners[:8:8], faceCenters...),
+		Edges: append(faceDiagonals[:24:24],
+			[2]uint32{8, 10}, [2]uint32{8, 11}, [2]uint32{8, 12}, [2]uint32{8, 13},
+			[2]uint32{9, 10}, [2]uint32{9, 11}, [2]uint32{9, 12}, [2]uint32{9, 13},
+			[2]uint32{10, 12}, [2]uint32{10, 13}, [2]uint32{11, 12}, [2]uint32{11, 13},
+		),
+	}
+)
+
+var faceDiagonals = [][2]uint32{
+	{8, 0}, {8, 3}, {8, 4}, {8, 7},
+	{9, 1}, {9, 2}, {9, 5}, {9, 6},
+	{10, 0}, {10, 1}, {10, 4}, {10, 5},
+	{11, 2}, {11, 3}, {11, 6}, {11, 7},
+	{12, 0}, {12, 1}, {12, 2}, {12, 3},
+	{13, 4}, {13, 5}, {13, 6}, {13, 7},
+}
+
+// A Generator fills a region with a beam lattice made of
+// copies of Cell, whose side length is CellSize.
+//
+// All the beams have the same Radius and the lattice MinLength
+// is set to MinLength.
+// The beams are grouped in one BeamSet for each layer of cells along the Z axis.
+type Generator struct {
+	Cell      UnitCell
+	CellSize  float32
+	Radius    float32
+	MinLength float32
+}
+
+// NewGenerator returns a new Generator with a default MinLength.
+func NewGenerator(cell UnitCell, cellSize, radius float32) *Generator {
+	return &Generator{
+		Cell:      cell,
+		CellSize:  cellSize,
+		Radius:    radius,
+		MinLength: 0.0001,
+	}
+}
+
+// Box returns a mesh containing a beam lattice that fills the box
+// defined by its min and max corners.
+// The cell size is reduced, if necessary, so an integer number
+// of cells fits exactly in the box, therefore no clipping is needed.
+func (g *Generator) Box(min, max go3mf.Point3D) *go3mf.Mesh {
+	size := max.Sub(min)
+	var count [3]int
+	var cellSize go3mf.Point3D
+	for i := range count {
+		count[i] = g.cellCount(size[i])
+		cellSize[i] = size[i] / float32(count[i])
+	}
+	return g.generate(min, cellSize, count, &BeamLattice{ClipMode: ClipNone})
+}
+
+// Clipped returns a mesh containing a beam lattice that covers the bounding box
+// of the boundary object mesh and that is clipped by it.
+// It returns false if boundary does not contain mesh vertices.
+func (g *Generator) Clipped(boundary *go3mf.Object) (*go3mf.Mesh, bool) {
+	if boundary.Mesh == nil || len(boundary.Mesh.Vertices) == 0 {
+		return nil, false
+	}
+	min, max := boundary.Mesh.Vertices[0], boundary.Mesh.Vertices[0]
+	for _, v := range boundary.Mesh.Vertices[1:] {
+		for i := range v {
+			min[i] = float32(math.Min(float64(min[i]), float64(v[i])))
+			max[i] = float32(math.Max(float64(max[i]), float64(v[i])))
+		}
+	}
+	var count [3]int
+	for i := range count {
+		count[i] = g.cellCount(max[i] - min[i])
+	}
+	cellSize := go3mf.Point3D{g.CellSize, g.CellSize, g.CellSize}
+	return g.generate(min, cellSize, count, &BeamLattice{ClipMode: ClipInside, ClippingMeshID: boundary.ID}), true
+}
+
+func (g *Generator) cellCount(length float32) int {
+	if g.CellSize <= 0 {
+		return 1
+	}
+	n := int(math.Ceil(float64(length / g.CellSize)))
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+func (g *Generator) generate(origin, cellSize go3mf.Point3D, count [3]int, bl *BeamLattice) *go3mf.Mesh {
+	bl.Radius = g.Radius
+	bl.MinLength = g.MinLength
+	mesh := &go3mf.Mesh{Any: go3mf.Marshalers{bl}}
+	mb := go3mf.NewMeshBuilder(mesh)
+	beams := make(map[[2]uint32]struct{})
+	nodes := make([]uint32, len(g.Cell.Nodes))
+	for k := 0; k < count[2]; k++ {
+		set := BeamSet{
+			Name:       fmt.Sprintf("%s layer %d", g.Cell.Name, k),
+			Identifier: fmt.Sprintf("%s-%d", g.Cell.Name, k),
+		}
+		for j := 0; j < count[1]; j++ {
+			for i := 0; i < count[0]; i++ {
+				cell := go3mf.Point3D{float32(i), float32(j), float32(k)}
+				for n, node := range g.Cell.Nodes {
+					var p go3mf.Point3D
+					for c := range p {
+						p[c] = origin[c] + (cell[c]+node[c])*cellSize[c]
+					}
+					nodes[n] = mb.AddVertex(p)
+				}
+				for _, e := range g.Cell.Edges {
+					if int(e[0]) >= len(nodes) || int(e[1]) >= len(nodes) {
+						continue
+					}
+					v1, v2 := nodes[e[0]], nodes[e[1]]
+					if v1 == v2 {
+						continue
+					}
+					key := [2]uint32{v1, v2}
+					if v1 > v2 {
+						key = [2]uint32{v2, v1}
+					}
+					if _, ok := beams[key]; ok {
+						continue
+					}
+					beams[key] = struct{}{}
+					set.Refs = append(set.Refs, uint32(len(bl.Beams)))
+					bl.Beams = append(bl.Beams, Beam{
+						Indices: [2]uint32{v1, v2},
+						Radius:  [2]float32{g.Radius, g.Radius},
+					})
+				}
+			}
+		}
+		if len(set.Refs) > 0 {
+			bl.BeamSets = append(bl.BeamSets, set)
+		}
+	}
+	return mesh
+}
@@ -0,0 +1,76 @@
+package beamlattice
+
+import (
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+)
+
+func TestGenerator_Box(t *testing.T) {
+	tests := []struct {
+		name      string
+		cell      UnitCell
+		max       go3mf.Point3D
+		vertices  int
+		beams     int
+		beamSets  []int
+		firstBeam [2]go3mf.Point3D
+	}{
+		{"cubic", CellCubic, go3mf.Point3D{2, 2, 2}, 27, 54, []int{33, 21}, [2]go3mf.Point3D{{0, 0, 0}, {1, 0, 0}}},
+		{"bcc", CellBCC, go3mf.Point3D{2, 1, 1}, 14, 16, []int{16}, [2]go3mf.Point3D{{0.5, 0.5, 0.5}, {0, 0, 0}}},
+		{"fcc", CellFCC, go3mf.Point3D{1, 1, 1}, 14, 24, []int{24}, [2]go3mf.Point3D{{0, 0.5, 0.5}, {0, 0, 0}}},
+		{"octet", CellOctet, go3mf.Point3D{2, 1, 1}, 23, 68, []int{68}, [2]go3mf.Point3D{{0, 0.5, 0.5}, {0, 0, 0}}},
+		{"resized", CellCubic, go3mf.Point3D{1.5, 1.5, 1.5}, 27, 54, []int{33, 21}, [2]go3mf.Point3D{{0, 0, 0}, {0.75, 0, 0}}},
+		{"custom", UnitCell{Name: "custom", Nodes: []go3mf.Point3D{{0, 0, 0}, {1, 1, 1}, {0, 0, 0}}, Edges: [][2]uint32{{0, 1}, {0, 2}, {0, 5}}},
+			go3mf.Point3D{1, 1, 1}, 2, 1, []int{1}, [2]go3mf.Point3D{{0, 0, 0}, {1, 1, 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mesh := NewGenerator(tt.cell, 1, 0.1).Box(go3mf.Point3D{}, tt.max)
+			var bl *BeamLattice
+			if !mesh.Any.Get(&bl) {
+				t.Fatal("Generator.Box() mesh without beam lattice")
+			}
+			if len(mesh.Vertices) != tt.vertices {
+				t.Errorf("Generator.Box() vertices = %d, want %d", len(mesh.Vertices), tt.vertices)
+			}
+			if len(bl.Beams) != tt.beams {
+				t.Errorf("Generator.Box() beams = %d, want %d", len(bl.Beams), tt.beams)
+			}
+			if len(bl.BeamSets) != len(tt.beamSets) {
+				t.Fatalf("Generator.Box() beam sets = %d, want %d", len(bl.BeamSets), len(tt.beamSets))
+			}
+			for i, set := range bl.BeamSets {
+				if len(set.Refs) != tt.beamSets[i] {
+					t.Errorf("Generator.Box() beam set %s refs = %d, want %d", set.Identifier, len(set.Refs), tt.beamSets[i])
+				}
+			}
+			b := bl.Beams[0]
+			if got := [2]go3mf.Point3D{mesh.Vertices[b.Indices[0]], mesh.Vertices[b.Indices[1]]}; got != tt.firstBeam {
+				t.Errorf("Generator.Box() first beam = %v, want %v", got, tt.firstBeam)
+			}
+			if bl.ClipMode != ClipNone || bl.Radius != 0.1 || b.Radius != [2]float32{0.1, 0.1} {
+				t.Errorf("Generator.Box() lattice = %v", bl)
+			}
+		})
+	}
+}
+
+func TestGenerator_Clipped(t *testing.T) {
+	boundary := &go3mf.Object{ID: 5, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{0, 0, 0}, {2.5, 0, 0}, {0, 1, 0}, {0, 0, 1}}}}
+	mesh, ok := NewGenerator(CellCubic, 1, 0.1).Clipped(boundary)
+	if !ok {
+		t.Fatal("Generator.Clipped() = false, want true")
+	}
+	var bl *BeamLattice
+	mesh.Any.Get(&bl)
+	if bl.ClipMode != ClipInside || bl.ClippingMeshID != 5 {
+		t.Errorf("Generator.Clipped() clip = %v, %d, want %v, %d", bl.ClipMode, bl.ClippingMeshID, ClipInside, 5)
+	}
+	if len(mesh.Vertices) != 16 || len(bl.Beams) != 28 {
+		t.Errorf("Generator.Clipped() vertices = %d, beams = %d, want 16, 28", len(mesh.Vertices), len(bl.Beams))
+	}
+	if _, ok := NewGenerator(CellCubic, 1, 0.1).Clipped(&go3mf.Object{ID: 1}); ok {
+		t.Error("Generator.Clipped() = true, want false")
+	}
+}
@@ -0,0 +1,251 @@
+package beamlattice
+
+import (
+	"math"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// DefaultSegments is the number of sides used to approximate
+// the circular section of a beam when Tessellator.Segments is not valid.
+const DefaultSegments = 16
+
+// A Tessellator converts beam lattices into triangle meshes
+// that can be consumed by tools that do not support the beam lattice extension.
+//
+// Each beam is approximated by a cylinder, or a truncated cone if both radius differ,
+// whose ends are closed according to its CapMode:
+// butt caps are flat disks, hemisphere caps are domes attached to the beam
+// and sphere caps are closed spheres that overlap the beam end.
+// Balls are emitted as closed spheres.
+//
+// Segments defines the angular resolution and it is
+// replaced by DefaultSegments if it is lower than 3.
+//
+// The beam lattice clipping, defined by ClipMode and ClippingMeshID,
+// is not applied, so the tessellated beams may exceed the clipping mesh.
+type Tessellator struct {
+	Segments int
+}
+
+// NewTessellator returns a new Tessellator with DefaultSegments.
+func NewTessellator() *Tessellator {
+	return &Tessellator{Segments: DefaultSegments}
+}
+
+// Tessellate adds to mb the triangles that approximate the beams and balls of bl.
+// vertices are the vertices of the mesh that contains bl.
+// Beams that reference out of bounds or coincident vertices
+// and beams whose radius is not positive are skipped.
+//
+// Every beam, sphere cap and ball is emitted as an independent closed shell,
+// so mb.CalculateConnectivity is disabled while tessellating to avoid
+// merging vertices of overlapping shells.
+func (t *Tessellator) Tessellate(mb *go3mf.MeshBuilder, vertices []go3mf.Point3D, bl *BeamLattice) {
+	defer func(connectivity bool) { mb.CalculateConnectivity = connectivity }(mb.CalculateConnectivity)
+	mb.CalculateConnectivity = false
+	tb := tessellation{mb: mb, segments: t.segments()}
+	for _, b := range bl.Beams {
+		if int(b.Indices[0]) >= len(vertices) || int(b.Indices[1]) >= len(vertices) {
+			continue
+		}
+		r0, r1 := b.Radius[0], b.Radius[1]
+		if r0 == 0 {
+			r0 = bl.Radius
+		}
+		if r1 == 0 {
+			r1 = r0
+		}
+		if r0 <= 0 || r1 <= 0 {
+			continue
+		}
+		tb.beam(vertices[b.Indices[0]], vertices[b.Indices[1]], r0, r1, b.CapMode)
+	}
+	for _, b := range balls(bl) {
+		if int(b.Index) < len(vertices) && b.Radius > 0 {
+			tb.sphere(vertices[b.Index], b.Radius, go3mf.Point3D{0, 0, 1})
+		}
+	}
+}
+
+// AddRepresentationMesh tessellates the beam lattice of obj into a new mesh object
+// and references it from the beam lattice RepresentationMeshID.
+// The new object is inserted in rs before obj, so it is defined
+// before being referenced, and it is returned.
+// It returns false if obj does not contain a beam lattice.
+func (t *Tessellator) AddRepresentationMesh(rs *go3mf.Resources, obj *go3mf.Object) (*go3mf.Object, bool) {
+	var bl *BeamLattice
+	if obj.Mesh == nil || !obj.Mesh.Any.Get(&bl) {
+		return nil, false
+	}
+	repr := &go3mf.Object{ID: rs.UnusedID(), Type: go3mf.ObjectTypeModel, Mesh: new(go3mf.Mesh)}
+	t.Tessellate(go3mf.NewMeshBuilder(repr.Mesh), obj.Mesh.Vertices, bl)
+	bl.RepresentationMeshID = repr.ID
+	for i, o := range rs.Objects {
+		if o == obj {
+			rs.Objects = append(rs.Objects[:i], append([]*go3mf.Object{repr}, rs.Objects[i:]...)...)
+			return repr, true
+		}
+	}
+	rs.Objects = append(rs.Objects, repr)
+	return repr, true
+}
+
+func (t *Tessellator) segments() int {
+	if t.Segments < 3 {
+		return DefaultSegments
+	}
+	return t.Segments
+}
+
+// balls returns the balls that have to be placed at the beam vertices,
+// sorted by first appearance.
+func balls(bl *BeamLattice) []Ball {
+	if bl.BallMode == BallModeNone {
+		return nil
+	}
+	var balls []Ball
+	index := make(map[uint32]int)
+	add := func(b Ball) {
+		if b.Radius == 0 {
+			b.Radius = bl.BallRadius
+		}
+		if i, ok := index[b.Index]; ok {
+			balls[i] = b
+			return
+		}
+		index[b.Index] = len(balls)
+		balls = append(balls, b)
+	}
+	if bl.BallMode == BallModeAll {
+		for _, b := range bl.Beams {
+			add(Ball{Index: b.Indices[0]})
+			add(Ball{Index: b.Indices[1]})
+		}
+	}
+	for _, b := range bl.Balls {
+		add(b)
+	}
+	return balls
+}
+
+type tessellation struct {
+	mb       *go3mf.MeshBuilder
+	segments int
+}
+
+func (t *tessellation) beam(p0, p1 go3mf.Point3D, r0, r1 float32, caps [2]CapMode) {
+	axis := p1.Sub(p0)
+	if axis.Len() == 0 {
+		return
+	}
+	axis = axis.Normalize()
+	u, v := orthonormal(axis)
+	ring0 := t.ring(p0, u, v, r0)
+	ring1 := t.ring(p1, u, v, r1)
+	t.strip(ring0, ring1, false)
+	t.cap(p0, ring0, u, v, scale(axis, -1), r0, caps[0], true)
+	t.cap(p1, ring1, u, v, axis, r1, caps[1], false)
+}
+
+// cap closes ring, centered at c, according to mode.
+// dir points outwards the beam and flip must be true
+// if ring is ordered clockwise when looking from dir.
+func (t *tessellation) cap(c go3mf.Point3D, ring []uint32, u, v, dir go3mf.Point3D, r float32, mode CapMode, flip bool) {
+	switch mode {
+	case CapModeHemisphere:
+		t.dome(c, ring, u, v, dir, r, flip)
+	case CapModeSphere:
+		t.disk(c, ring, flip)
+		t.sphere(c, r, dir)
+	default:
+		t.disk(c, ring, flip)
+	}
+}
+
+func (t *tessellation) sphere(c go3mf.Point3D, r float32, axis go3mf.Point3D) {
+	u, v := orthonormal(axis)
+	equator := t.ring(c, u, v, r)
+	t.dome(c, equator, u, v, axis, r, false)
+	t.dome(c, equator, u, v, scale(axis, -1), r, true)
+}
+
+// dome closes ring with a hemisphere of radius r centered at c and oriented towards dir.
+func (t *tessellation) dome(c go3mf.Point3D, ring []uint32, u, v, dir go3mf.Point3D, r float32, flip bool) {
+	steps := t.segments / 4
+	if steps < 1 {
+		steps = 1
+	}
+	lower := ring
+	for j := 1; j < steps; j++ {
+		phi := float64(j) * math.Pi / 2 / float64(steps)
+		center := c.Add(scale(dir, r*float32(math.Sin(phi))))
+		upper := t.ring(center, u, v, r*float32(math.Cos(phi)))
+		t.strip(lower, upper, flip)
+		lower = upper
+	}
+	t.fan(c.Add(scale(dir, r)), lower, flip)
+}
+
+func (t *tessellation) disk(c go3mf.Point3D, ring []uint32, flip bool) {
+	t.fan(c, ring, flip)
+}
+
+// fan connects every edge of ring with the apex.
+func (t *tessellation) fan(apex go3mf.Point3D, ring []uint32, flip bool) {
+	a := t.mb.AddVertex(apex)
+	for k := range ring {
+		k1 := (k + 1) % len(ring)
+		if flip {
+			t.triangle(a, ring[k1], ring[k])
+		} else {
+			t.triangle(a, ring[k], ring[k1])
+		}
+	}
+}
+
+// strip connects two rings with the same number of vertices.
+func (t *tessellation) strip(lower, upper []uint32, flip bool) {
+	for k := range lower {
+		k1 := (k + 1) % len(lower)
+		if flip {
+			t.triangle(lower[k], upper[k1], lower[k1])
+			t.triangle(lower[k], upper[k], upper[k1])
+		} else {
+			t.triangle(lower[k], lower[k1], upper[k1])
+			t.triangle(lower[k], upper[k1], upper[k])
+		}
+	}
+}
+
+func (t *tessellation) ring(c, u, v go3mf.Point3D, r float32) []uint32 {
+	ring := make([]uint32, t.segments)
+	for k := range ring {
+		theta := 2 * math.Pi * float64(k) / float64(t.segments)
+		cos, sin := float32(math.Cos(theta)), float32(math.Sin(theta))
+		ring[k] = t.mb.AddVertex(c.Add(scale(u, r*cos)).Add(scale(v, r*sin)))
+	}
+	return ring
+}
+
+func (t *tessellation) triangle(v1, v2, v3 uint32) {
+	if v1 == v2 || v1 == v3 || v2 == v3 {
+		return
+	}
+	t.mb.Mesh.Triangles = append(t.mb.Mesh.Triangles, go3mf.NewTriangle(v1, v2, v3))
+}
+
+// orthonormal returns two unit vectors that form
+// a right-handed orthonormal basis together with axis.
+func orthonormal(axis go3mf.Point3D) (go3mf.Point3D, go3mf.Point3D) {
+	ref := go3mf.Point3D{1, 0, 0}
+	if math.Abs(float64(axis.X())) > 0.9 {
+		ref = go3mf.Point3D{0, 1, 0}
+	}
+	u := axis.Cross(ref).Normalize()
+	return u, axis.Cross(u)
+}
+
+func scale(p go3mf.Point3D, s float32) go3mf.Point3D {
+	return go3mf.Point3D{p.X() * s, p.Y() * s, p.Z() * s}
+}
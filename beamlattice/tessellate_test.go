@@ -0,0 +1,112 @@
+package beamlattice
+
+import (
+	"math"
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+)
+
+func closedVolume(t *testing.T, m *go3mf.Mesh) float64 {
+	t.Helper()
+	edges := make(map[[2]uint32]int)
+	var volume float64
+	for _, tr := range m.Triangles {
+		i1, i2, i3 := tr.Indices()
+		edges[[2]uint32{i1, i2}]++
+		edges[[2]uint32{i2, i3}]++
+		edges[[2]uint32{i3, i1}]++
+		v1, v2, v3 := m.Vertices[i1], m.Vertices[i2], m.Vertices[i3]
+		c := v2.Cross(v3)
+		volume += float64(v1.X()*c.X()+v1.Y()*c.Y()+v1.Z()*c.Z()) / 6
+	}
+	for e, n := range edges {
+		if n != 1 || edges[[2]uint32{e[1], e[0]}] != 1 {
+			t.Fatalf("mesh is not closed and consistently oriented at edge %v", e)
+		}
+	}
+	return volume
+}
+
+func TestTessellator_Tessellate(t *testing.T) {
+	vertices := []go3mf.Point3D{{0, 0, 0}, {0, 0, 10}, {10, 0, 10}}
+	const segments = 32
+	polygon := segments / (2 * math.Pi) * math.Sin(2*math.Pi/segments)
+	cylinder := math.Pi * 10 * polygon
+	sphere := 4.0 / 3 * math.Pi
+	tests := []struct {
+		name string
+		bl   *BeamLattice
+		want float64
+	}{
+		{"butt", &BeamLattice{Radius: 1, Beams: []Beam{
+			{Indices: [2]uint32{0, 1}, CapMode: [2]CapMode{CapModeButt, CapModeButt}},
+		}}, cylinder},
+		{"hemisphere", &BeamLattice{Radius: 1, Beams: []Beam{
+			{Indices: [2]uint32{0, 1}, CapMode: [2]CapMode{CapModeHemisphere, CapModeHemisphere}},
+		}}, cylinder + sphere},
+		{"sphere", &BeamLattice{Radius: 1, Beams: []Beam{
+			{Indices: [2]uint32{0, 1}, CapMode: [2]CapMode{CapModeSphere, CapModeButt}},
+		}}, cylinder + sphere},
+		{"cone", &BeamLattice{Radius: 1, Beams: []Beam{
+			{Indices: [2]uint32{1, 2}, Radius: [2]float32{2, 1}, CapMode: [2]CapMode{CapModeButt, CapModeButt}},
+		}}, math.Pi * 10 * (4 + 2 + 1) / 3 * polygon},
+		{"balls", &BeamLattice{Radius: 1, BallMode: BallModeMixed, BallRadius: 1, Beams: []Beam{
+			{Indices: [2]uint32{0, 1}, CapMode: [2]CapMode{CapModeButt, CapModeButt}},
+		}, Balls: []Ball{{Index: 1}, {Index: 10}}}, cylinder + sphere},
+		{"out of bounds", &BeamLattice{Radius: 1, Beams: []Beam{
+			{Indices: [2]uint32{0, 5}}, {Indices: [2]uint32{1, 1}},
+		}}, 0},
+		{"zero radius", &BeamLattice{Beams: []Beam{
+			{Indices: [2]uint32{0, 1}}, {Indices: [2]uint32{1, 2}, Radius: [2]float32{1, -1}},
+		}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(go3mf.Mesh)
+			(&Tessellator{Segments: segments}).Tessellate(go3mf.NewMeshBuilder(m), vertices, tt.bl)
+			got := closedVolume(t, m)
+			if math.Abs(got-tt.want) > 0.05*math.Max(tt.want, 1) {
+				t.Errorf("Tessellator.Tessellate() volume = %v, want %v", got, tt.want)
+			}
+			if tt.want == 0 && len(m.Triangles) != 0 {
+				t.Errorf("Tessellator.Tessellate() triangles = %d, want 0", len(m.Triangles))
+			}
+		})
+	}
+}
+
+func TestTessellator_AddRepresentationMesh(t *testing.T) {
+	bl := &BeamLattice{Radius: 1, MinLength: 1, ClipMode: ClipInside, ClippingMeshID: 1, Beams: []Beam{
+		{Indices: [2]uint32{0, 1}, CapMode: [2]CapMode{CapModeHemisphere, CapModeButt}},
+	}}
+	clip := &go3mf.Object{ID: 1, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {}, {}}, Triangles: []go3mf.Triangle{
+		go3mf.NewTriangle(0, 1, 2), go3mf.NewTriangle(0, 3, 1), go3mf.NewTriangle(0, 2, 3), go3mf.NewTriangle(1, 3, 2),
+	}}}
+	obj := &go3mf.Object{ID: 2, Mesh: &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {0, 0, 10}, {0, 10, 10}},
+		Triangles: []go3mf.Triangle{
+			go3mf.NewTriangle(0, 1, 2), go3mf.NewTriangle(0, 2, 1), go3mf.NewTriangle(0, 1, 2), go3mf.NewTriangle(0, 2, 1),
+		},
+		Any: go3mf.Marshalers{bl},
+	}}
+	m := &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{clip, obj}}}
+	m.WithSpec(&Spec{})
+	repr, ok := NewTessellator().AddRepresentationMesh(&m.Resources, obj)
+	if !ok {
+		t.Fatal("Tessellator.AddRepresentationMesh() = false, want true")
+	}
+	if repr.ID != 3 || bl.RepresentationMeshID != 3 {
+		t.Errorf("Tessellator.AddRepresentationMesh() id = %d, RepresentationMeshID = %d, want 3", repr.ID, bl.RepresentationMeshID)
+	}
+	if len(m.Resources.Objects) != 3 || m.Resources.Objects[1] != repr {
+		t.Errorf("Tessellator.AddRepresentationMesh() representation mesh MUST be defined before the lattice object")
+	}
+	closedVolume(t, repr.Mesh)
+	if err := m.Validate(); err != nil {
+		t.Errorf("Tessellator.AddRepresentationMesh() Validate() = %v", err)
+	}
+	if _, ok := NewTessellator().AddRepresentationMesh(&m.Resources, clip); ok {
+		t.Error("Tessellator.AddRepresentationMesh() = true, want false")
+	}
+}
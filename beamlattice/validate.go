@@ -33,6 +33,8 @@ func (e *Spec) ValidateObject(m *go3mf.Model, path string, obj *go3mf.Object) er
 	}
 	if bl.Radius == 0 {
 		errs = errors.Append(errs, errors.NewMissingFieldError(attrRadius))
+	} else if bl.Radius < 0 {
+		errs = errors.Append(errs, errors.ErrLatticeRadius)
 	}
 	if bl.ClipMode == ClipNone && bl.ClippingMeshID == 0 {
 		errs = errors.Append(errs, errors.ErrLatticeClippedNoMesh)
@@ -51,12 +53,36 @@ func (e *Spec) ValidateObject(m *go3mf.Model, path string, obj *go3mf.Object) er
 			l := len(obj.Mesh.Vertices)
 			if int(b.Indices[0]) >= l || int(b.Indices[1]) >= l {
 				errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, b, i))
+			} else if beamLength(obj.Mesh.Vertices, b) < float64(bl.MinLength) {
+				errs = errors.Append(errs, errors.WrapIndex(errors.ErrLatticeBeamTooShort, b, i))
 			}
 		}
+		if b.Radius[0] < 0 || b.Radius[1] < 0 {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrLatticeRadius, b, i))
+		}
 		if b.Radius[0] != 0 && b.Radius[0] != bl.Radius && b.Radius[0] != b.Radius[1] {
 			errs = errors.Append(errs, errors.WrapIndex(errors.ErrLatticeBeamR2, b, i))
 		}
 	}
+	if bl.BallMode != BallModeNone && bl.BallRadius == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrBallRadius))
+	} else if bl.BallRadius < 0 {
+		errs = errors.Append(errs, errors.ErrLatticeRadius)
+	}
+	balls := make(map[uint32]struct{}, len(bl.Balls))
+	for i, b := range bl.Balls {
+		if int(b.Index) >= len(obj.Mesh.Vertices) {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, b, i))
+		} else if _, ok := balls[b.Index]; ok {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrLatticeDuplicatedBall, b, i))
+		}
+		// Zero radii default to the ball radius, which is already reported
+		// when invalid unless it is not set and not required by the ball mode.
+		if b.Radius < 0 || (b.Radius == 0 && bl.BallRadius == 0 && bl.BallMode == BallModeNone) {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrLatticeRadius, b, i))
+		}
+		balls[b.Index] = struct{}{}
+	}
 	for i, set := range bl.BeamSets {
 		for _, ref := range set.Refs {
 			if int(ref) >= len(set.Refs) {
@@ -64,6 +90,12 @@ func (e *Spec) ValidateObject(m *go3mf.Model, path string, obj *go3mf.Object) er
 				break
 			}
 		}
+		for _, ref := range set.BallRefs {
+			if int(ref) >= len(bl.Balls) {
+				errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, set, i))
+				break
+			}
+		}
 	}
 	if errs != nil {
 		errs = errors.Wrap(errors.Wrap(errs, bl), obj.Mesh)
@@ -77,7 +77,7 @@ func TestValidate(t *testing.T) {
 			fmt.Errorf("Resources@Object#0@Mesh@BeamLattice@Beam#2: %v", errors.ErrIndexOutOfBounds),
 		}},
 		{"incorrect beamseat", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
-			{ID: 2, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {}}, Any: go3mf.Marshalers{&BeamLattice{
+			{ID: 2, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {0, 0, 2}}, Any: go3mf.Marshalers{&BeamLattice{
 				MinLength: 1, Radius: 1, ClipMode: ClipInside, Beams: []Beam{
 					{Indices: [2]uint32{1, 2}},
 				}, BeamSets: []BeamSet{{Refs: []uint32{0, 2, 3}}},
@@ -85,6 +85,38 @@ func TestValidate(t *testing.T) {
 		}}}, []error{
 			fmt.Errorf("Resources@Object#0@Mesh@BeamLattice@BeamSet#0: %v", errors.ErrIndexOutOfBounds),
 		}},
+		{"incorrect geometry", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 2, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {0, 0, 0.5}, {0, 0, 2}}, Any: go3mf.Marshalers{&BeamLattice{
+				MinLength: 1, Radius: -1, ClipMode: ClipInside, BallRadius: -1, Beams: []Beam{
+					{Indices: [2]uint32{0, 1}}, {Indices: [2]uint32{0, 2}, Radius: [2]float32{-1, -1}}, {Indices: [2]uint32{1, 2}},
+				}, Balls: []Ball{{Index: 0, Radius: -2}},
+			}}}},
+		}}}, []error{
+			fmt.Errorf("Resources@Object#0@Mesh@BeamLattice: %v", errors.ErrLatticeRadius),
+			fmt.Errorf("Resources@Object#0@Mesh@BeamLattice@Beam#0: %v", errors.ErrLatticeBeamTooShort),
+			fmt.Errorf("Resources@Object#0@Mesh@BeamLattice@Beam#1: %v", errors.ErrLatticeRadius),
+			fmt.Errorf("Resources@Object#0@Mesh@BeamLattice: %v", errors.ErrLatticeRadius),
+			fmt.Errorf("Resources@Object#0@Mesh@BeamLattice@Ball#0: %v", errors.ErrLatticeRadius),
+		}},
+		{"zero ball radius", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 2, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {}}, Any: go3mf.Marshalers{&BeamLattice{
+				MinLength: 1, Radius: 1, ClipMode: ClipInside, Balls: []Ball{{Index: 0}, {Index: 1, Radius: 1}},
+			}}}},
+		}}}, []error{
+			fmt.Errorf("Resources@Object#0@Mesh@BeamLattice@Ball#0: %v", errors.ErrLatticeRadius),
+		}},
+		{"incorrect balls", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 2, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {}}, Any: go3mf.Marshalers{&BeamLattice{
+				MinLength: 1, Radius: 1, ClipMode: ClipInside, BallMode: BallModeAll, Balls: []Ball{
+					{Index: 1}, {Index: 3}, {Index: 1},
+				}, BeamSets: []BeamSet{{BallRefs: []uint32{0, 3}}},
+			}}}},
+		}}}, []error{
+			fmt.Errorf("Resources@Object#0@Mesh@BeamLattice: %v", &errors.MissingFieldError{Name: attrBallRadius}),
+			fmt.Errorf("Resources@Object#0@Mesh@BeamLattice@Ball#1: %v", errors.ErrIndexOutOfBounds),
+			fmt.Errorf("Resources@Object#0@Mesh@BeamLattice@Ball#2: %v", errors.ErrLatticeDuplicatedBall),
+			fmt.Errorf("Resources@Object#0@Mesh@BeamLattice@BeamSet#0: %v", errors.ErrIndexOutOfBounds),
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,81 @@
+package beamlattice
+
+import (
+	"math"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// A Wireframe converts the edges of a mesh into a beam lattice.
+//
+// All the beams have the same Radius and CapMode and the lattice
+// MinLength is set to MinLength.
+// If FeatureAngle, in radians, is greater than zero only the edges
+// whose dihedral angle is greater or equal than FeatureAngle are converted,
+// where the dihedral angle is the angle between the normals of the two triangles
+// that share the edge. Boundary and non-manifold edges are always converted.
+type Wireframe struct {
+	Radius       float32
+	CapMode      CapMode
+	MinLength    float32
+	FeatureAngle float64
+}
+
+// NewWireframe returns a new Wireframe that converts all the edges
+// into beams with sphere caps and a default MinLength.
+func NewWireframe(radius float32) *Wireframe {
+	return &Wireframe{
+		Radius:    radius,
+		CapMode:   CapModeSphere,
+		MinLength: 0.0001,
+	}
+}
+
+// Lattice returns a beam lattice with a beam along each unique edge of the obj mesh.
+// The beams reference the vertices of obj.Mesh, so the lattice can be added
+// to that mesh or to any other mesh with the same vertices.
+// It returns false if obj does not contain a mesh.
+func (w *Wireframe) Lattice(obj *go3mf.Object) (*BeamLattice, bool) {
+	if obj.Mesh == nil {
+		return nil, false
+	}
+	bl := &BeamLattice{Radius: w.Radius, MinLength: w.MinLength, CapMode: w.CapMode}
+	var normals []go3mf.Point3D
+	if w.FeatureAngle > 0 {
+		normals = triangleNormals(obj.Mesh)
+	}
+	for _, e := range obj.Mesh.Edges() {
+		if int(e.Indices[1]) >= len(obj.Mesh.Vertices) || e.Indices[0] == e.Indices[1] {
+			continue
+		}
+		if w.FeatureAngle > 0 && len(e.Triangles) == 2 {
+			n1, n2 := normals[e.Triangles[0]], normals[e.Triangles[1]]
+			cos := float64(n1.X()*n2.X() + n1.Y()*n2.Y() + n1.Z()*n2.Z())
+			if math.Acos(math.Max(-1, math.Min(1, cos))) < w.FeatureAngle {
+				continue
+			}
+		}
+		bl.Beams = append(bl.Beams, Beam{
+			Indices: e.Indices,
+			Radius:  [2]float32{w.Radius, w.Radius},
+			CapMode: [2]CapMode{w.CapMode, w.CapMode},
+		})
+	}
+	return bl, true
+}
+
+func triangleNormals(m *go3mf.Mesh) []go3mf.Point3D {
+	normals := make([]go3mf.Point3D, len(m.Triangles))
+	for i, t := range m.Triangles {
+		i1, i2, i3 := t.Indices()
+		if int(i1) >= len(m.Vertices) || int(i2) >= len(m.Vertices) || int(i3) >= len(m.Vertices) {
+			continue
+		}
+		v1 := m.Vertices[i1]
+		n := m.Vertices[i2].Sub(v1).Cross(m.Vertices[i3].Sub(v1))
+		if n.Len() > 0 {
+			normals[i] = n.Normalize()
+		}
+	}
+	return normals
+}
@@ -0,0 +1,60 @@
+package beamlattice
+
+import (
+	"math"
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+)
+
+func TestWireframe_Lattice(t *testing.T) {
+	cube := &go3mf.Object{ID: 1, Mesh: &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {0, 1, 0}, {0, 0, 1}, {1, 0, 1}, {1, 1, 1}, {0, 1, 1}},
+		Triangles: []go3mf.Triangle{
+			go3mf.NewTriangle(0, 2, 1), go3mf.NewTriangle(0, 3, 2),
+			go3mf.NewTriangle(4, 5, 6), go3mf.NewTriangle(4, 6, 7),
+			go3mf.NewTriangle(0, 1, 5), go3mf.NewTriangle(0, 5, 4),
+			go3mf.NewTriangle(3, 7, 6), go3mf.NewTriangle(3, 6, 2),
+			go3mf.NewTriangle(0, 4, 7), go3mf.NewTriangle(0, 7, 3),
+			go3mf.NewTriangle(1, 2, 6), go3mf.NewTriangle(1, 6, 5),
+		},
+	}}
+	open := &go3mf.Object{ID: 2, Mesh: &go3mf.Mesh{
+		Vertices:  []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {0, 1, 0}},
+		Triangles: []go3mf.Triangle{go3mf.NewTriangle(0, 1, 2), go3mf.NewTriangle(0, 2, 3)},
+	}}
+	tests := []struct {
+		name  string
+		w     *Wireframe
+		obj   *go3mf.Object
+		beams int
+		ok    bool
+	}{
+		{"no mesh", NewWireframe(0.1), &go3mf.Object{ID: 3}, 0, false},
+		{"all", NewWireframe(0.1), cube, 18, true},
+		{"features", &Wireframe{Radius: 0.1, CapMode: CapModeButt, FeatureAngle: math.Pi / 6}, cube, 12, true},
+		{"boundary", &Wireframe{Radius: 0.1, FeatureAngle: math.Pi / 6}, open, 4, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.w.Lattice(tt.obj)
+			if ok != tt.ok {
+				t.Fatalf("Wireframe.Lattice() ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if len(got.Beams) != tt.beams {
+				t.Errorf("Wireframe.Lattice() beams = %d, want %d", len(got.Beams), tt.beams)
+			}
+			for _, b := range got.Beams {
+				if b.Radius != [2]float32{tt.w.Radius, tt.w.Radius} || b.CapMode != [2]CapMode{tt.w.CapMode, tt.w.CapMode} {
+					t.Errorf("Wireframe.Lattice() beam = %v", b)
+				}
+			}
+			if got.Radius != tt.w.Radius || got.CapMode != tt.w.CapMode || got.MinLength != tt.w.MinLength {
+				t.Errorf("Wireframe.Lattice() lattice = %v", got)
+			}
+		})
+	}
+}
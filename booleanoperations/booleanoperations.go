@@ -0,0 +1,103 @@
+package booleanoperations
+
+import "github.com/qmuntal/go3mf"
+
+// Namespace is the canonical name of this extension.
+const Namespace = "http://schemas.3mf.io/3dmanufacturing/booleanoperations/2023/07"
+
+type Spec struct {
+	LocalName  string
+	IsRequired bool
+}
+
+func (e Spec) Namespace() string   { return Namespace }
+func (e Spec) Required() bool      { return e.IsRequired }
+func (e *Spec) SetRequired(r bool) { e.IsRequired = r }
+func (e *Spec) SetLocal(l string)  { e.LocalName = l }
+
+func (e Spec) Local() string {
+	if e.LocalName != "" {
+		return e.LocalName
+	}
+	return "bo"
+}
+
+// Operation defines the boolean operation applied to the operands.
+type Operation uint8
+
+// Supported operations.
+const (
+	OperationUnion Operation = iota
+	OperationDifference
+	OperationIntersection
+)
+
+func newOperation(s string) (o Operation, ok bool) {
+	o, ok = map[string]Operation{
+		"union":        OperationUnion,
+		"difference":   OperationDifference,
+		"intersection": OperationIntersection,
+	}[s]
+	return
+}
+
+func (o Operation) String() string {
+	return map[Operation]string{
+		OperationUnion:        "union",
+		OperationDifference:   "difference",
+		OperationIntersection: "intersection",
+	}[o]
+}
+
+// BooleanShape defines an object shape as the result of applying
+// a boolean operation between a base object and a list of operands.
+// It is stored in the Any field of the containing object.
+type BooleanShape struct {
+	ObjectID  uint32
+	Operation Operation
+	Transform go3mf.Matrix
+	Path      string // Production path of the base object, if any.
+	Operands  []Operand
+}
+
+// ObjectPath returns the path of the base object, or the default path if not set.
+func (b *BooleanShape) ObjectPath(defaultPath string) string {
+	if b.Path != "" {
+		return b.Path
+	}
+	return defaultPath
+}
+
+// HasTransform returns true if the transform is different than the identity.
+func (b *BooleanShape) HasTransform() bool {
+	return b.Transform != go3mf.Matrix{} && b.Transform != go3mf.Identity()
+}
+
+// Operand defines an object that is combined with the base object.
+type Operand struct {
+	ObjectID  uint32
+	Transform go3mf.Matrix
+	Path      string // Production path of the operand object, if any.
+}
+
+// ObjectPath returns the path of the operand object, or the default path if not set.
+func (o *Operand) ObjectPath(defaultPath string) string {
+	if o.Path != "" {
+		return o.Path
+	}
+	return defaultPath
+}
+
+// HasTransform returns true if the transform is different than the identity.
+func (o *Operand) HasTransform() bool {
+	return o.Transform != go3mf.Matrix{} && o.Transform != go3mf.Identity()
+}
+
+const (
+	attrBooleanShape = "booleanshape"
+	attrBoolean      = "boolean"
+	attrObjectID     = "objectid"
+	attrOperation    = "operation"
+	attrTransform    = "transform"
+	attrPath         = "path"
+)
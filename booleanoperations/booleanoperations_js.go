@@ -0,0 +1,47 @@
+package booleanoperations
+
+import (
+	"syscall/js"
+
+	"github.com/qmuntal/go3mf"
+)
+
+var (
+	jsNS             = "BOOLEANOPERATIONS"
+	arrayConstructor = js.Global().Get("Array")
+	jsSpec           = go3mf.RegisterClass(jsNS, "X")
+	jsBooleanShape   = go3mf.RegisterClass("BooleanShape", "X", jsNS)
+	jsOperand        = go3mf.RegisterClass("Operand", "X", jsNS)
+)
+
+// JSValue returns a JavaScript value associated with the object.
+func (o Operand) JSValue() js.Value {
+	v := jsOperand.New()
+	v.Set("objectId", o.ObjectID)
+	v.Set(attrTransform, o.Transform)
+	setString(v, attrPath, o.Path)
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+func (b *BooleanShape) JSValue() js.Value {
+	v := jsBooleanShape.New()
+	v.Set("objectId", b.ObjectID)
+	v.Set(attrOperation, b.Operation.String())
+	v.Set(attrTransform, b.Transform)
+	setString(v, attrPath, b.Path)
+	arr := arrayConstructor.New(len(b.Operands))
+	for i, o := range b.Operands {
+		arr.SetIndex(i, o)
+	}
+	v.Set("operands", arr)
+	return v
+}
+
+func setString(v js.Value, name, value string) {
+	if value == "" {
+		v.Set(name, js.Undefined())
+	} else {
+		v.Set(name, value)
+	}
+}
@@ -0,0 +1,86 @@
+package booleanoperations
+
+import (
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+)
+
+var _ go3mf.SpecDecoder = new(Spec)
+var _ go3mf.SpecValidator = new(Spec)
+var _ go3mf.Marshaler = new(BooleanShape)
+
+func TestOperation_String(t *testing.T) {
+	tests := []struct {
+		name string
+		o    Operation
+	}{
+		{"union", OperationUnion},
+		{"difference", OperationDifference},
+		{"intersection", OperationIntersection},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.String(); got != tt.name {
+				t.Errorf("Operation.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func Test_newOperation(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   Operation
+		wantOk bool
+	}{
+		{"union", OperationUnion, true},
+		{"difference", OperationDifference, true},
+		{"intersection", OperationIntersection, true},
+		{"empty", OperationUnion, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := newOperation(tt.name)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("newOperation() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestBooleanShape_ObjectPath(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *BooleanShape
+		want string
+	}{
+		{"default", &BooleanShape{}, "/3D/3dmodel.model"},
+		{"path", &BooleanShape{Path: "/3D/other.model"}, "/3D/other.model"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.ObjectPath("/3D/3dmodel.model"); got != tt.want {
+				t.Errorf("BooleanShape.ObjectPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperand_ObjectPath(t *testing.T) {
+	tests := []struct {
+		name string
+		o    *Operand
+		want string
+	}{
+		{"default", &Operand{}, "/3D/3dmodel.model"},
+		{"path", &Operand{Path: "/3D/other.model"}, "/3D/other.model"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.ObjectPath("/3D/3dmodel.model"); got != tt.want {
+				t.Errorf("Operand.ObjectPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package booleanoperations
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/production"
+)
+
+func (e Spec) OnDecoded(_ *go3mf.Model) error {
+	return nil
+}
+
+func (e Spec) NewNodeDecoder(parentNode interface{}, nodeName string) (child go3mf.NodeDecoder) {
+	if nodeName == attrBooleanShape {
+		if obj, ok := parentNode.(*go3mf.Object); ok {
+			child = &booleanShapeDecoder{object: obj}
+		}
+	}
+	return
+}
+
+func (e Spec) DecodeAttribute(_ *go3mf.Scanner, _ interface{}, _ xml.Attr) {}
+
+type booleanShapeDecoder struct {
+	baseDecoder
+	object         *go3mf.Object
+	shape          *BooleanShape
+	operandDecoder operandDecoder
+}
+
+func (d *booleanShapeDecoder) Start(attrs []xml.Attr) {
+	d.shape = new(BooleanShape)
+	d.object.Any = append(d.object.Any, d.shape)
+	d.operandDecoder.shape = d.shape
+	for _, a := range attrs {
+		if a.Name.Space == production.Namespace && a.Name.Local == attrPath {
+			d.shape.Path = a.Value
+			continue
+		}
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrObjectID:
+			val, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			d.shape.ObjectID = uint32(val)
+		case attrOperation:
+			var ok bool
+			d.shape.Operation, ok = newOperation(a.Value)
+			if !ok {
+				d.Scanner.InvalidAttr(a.Name.Local, false)
+			}
+		case attrTransform:
+			var ok bool
+			d.shape.Transform, ok = go3mf.ParseMatrix(a.Value)
+			if !ok {
+				d.Scanner.InvalidAttr(a.Name.Local, false)
+			}
+		}
+	}
+}
+
+func (d *booleanShapeDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
+	if name.Space == Namespace && name.Local == attrBoolean {
+		child = &d.operandDecoder
+	}
+	return
+}
+
+type operandDecoder struct {
+	baseDecoder
+	shape *BooleanShape
+}
+
+func (d *operandDecoder) Start(attrs []xml.Attr) {
+	var operand Operand
+	for _, a := range attrs {
+		if a.Name.Space == production.Namespace && a.Name.Local == attrPath {
+			operand.Path = a.Value
+			continue
+		}
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrObjectID:
+			val, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			operand.ObjectID = uint32(val)
+		case attrTransform:
+			var ok bool
+			operand.Transform, ok = go3mf.ParseMatrix(a.Value)
+			if !ok {
+				d.Scanner.InvalidAttr(a.Name.Local, false)
+			}
+		}
+	}
+	d.shape.Operands = append(d.shape.Operands, operand)
+}
+
+type baseDecoder struct {
+	Scanner *go3mf.Scanner
+}
+
+func (d *baseDecoder) Start([]xml.Attr)                 {}
+func (d *baseDecoder) Text([]byte)                      {}
+func (d *baseDecoder) Child(xml.Name) go3mf.NodeDecoder { return nil }
+func (d *baseDecoder) End()                             {}
+func (d *baseDecoder) SetScanner(s *go3mf.Scanner)      { d.Scanner = s }
@@ -0,0 +1,77 @@
+package booleanoperations
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func TestDecode(t *testing.T) {
+	shape := &BooleanShape{ObjectID: 1, Operation: OperationDifference, Transform: go3mf.Matrix{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 10, 0, 0, 1}, Operands: []Operand{
+		{ObjectID: 2},
+		{ObjectID: 3, Transform: go3mf.Matrix{2, 0, 0, 0, 0, 2, 0, 0, 0, 0, 2, 0, 0, 0, 0, 1}, Path: "/3D/other.model"},
+	}}
+	want := &go3mf.Model{Path: "/3D/3dmodel.model"}
+	want.Resources.Objects = append(want.Resources.Objects, &go3mf.Object{ID: 4, Any: go3mf.Marshalers{shape}})
+	got := new(go3mf.Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := `
+	<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:bo="http://schemas.3mf.io/3dmanufacturing/booleanoperations/2023/07">
+		<resources>
+			<object id="4">
+				<bo:booleanshape objectid="1" operation="difference" transform="1 0 0 0 1 0 0 0 1 10 0 0">
+					<bo:boolean objectid="2" />
+					<bo:boolean xmlns:p="http://schemas.microsoft.com/3dmanufacturing/production/2015/06" objectid="3" transform="2 0 0 0 2 0 0 0 2 0 0 0" p:path="/3D/other.model" />
+				</bo:booleanshape>
+			</object>
+		</resources>
+		<build>
+		</build>
+	</model>`
+	t.Run("base", func(t *testing.T) {
+		got.WithSpec(&Spec{LocalName: "bo"})
+		want.WithSpec(&Spec{LocalName: "bo"})
+		if err := go3mf.UnmarshalModel([]byte(rootFile), got); err != nil {
+			t.Errorf("DecodeRawModel() unexpected error = %v", err)
+			return
+		}
+		if diff := deep.Equal(got, want); diff != nil {
+			t.Errorf("DecodeRawModel() = %v", diff)
+			return
+		}
+	})
+}
+
+func TestDecode_warns(t *testing.T) {
+	want := &errors.List{Errors: []error{
+		&errors.ParseFieldError{Required: true, ResourceID: 4, Name: "objectid", Context: "model@resources@object@booleanshape"},
+		&errors.ParseFieldError{Required: false, ResourceID: 4, Name: "operation", Context: "model@resources@object@booleanshape"},
+		&errors.ParseFieldError{Required: false, ResourceID: 4, Name: "transform", Context: "model@resources@object@booleanshape"},
+		&errors.ParseFieldError{Required: true, ResourceID: 4, Name: "objectid", Context: "model@resources@object@booleanshape@boolean"},
+		&errors.ParseFieldError{Required: false, ResourceID: 4, Name: "transform", Context: "model@resources@object@booleanshape@boolean"},
+	}}
+	got := new(go3mf.Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := `
+	<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:bo="http://schemas.3mf.io/3dmanufacturing/booleanoperations/2023/07">
+		<resources>
+			<object id="4">
+				<bo:booleanshape qm:mq="other" objectid="a" operation="xor" transform="1 0 0">
+					<bo:boolean objectid="b" transform="0 0" />
+				</bo:booleanshape>
+			</object>
+		</resources>
+		<build>
+		</build>
+	</model>`
+	t.Run("base", func(t *testing.T) {
+		got.WithSpec(&Spec{LocalName: "bo"})
+		err := go3mf.UnmarshalModel([]byte(rootFile), got)
+		if diff := deep.Equal(err, want); diff != nil {
+			t.Errorf("UnmarshalModel_warn() = %v", diff)
+			return
+		}
+	})
+}
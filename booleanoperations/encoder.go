@@ -0,0 +1,42 @@
+package booleanoperations
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/production"
+)
+
+// Marshal3MF encodes the resource.
+func (b *BooleanShape) Marshal3MF(x *go3mf.XMLEncoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrBooleanShape}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrObjectID}, Value: strconv.FormatUint(uint64(b.ObjectID), 10)},
+	}}
+	if b.Operation != OperationUnion {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrOperation}, Value: b.Operation.String()})
+	}
+	if b.HasTransform() {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTransform}, Value: b.Transform.String()})
+	}
+	if b.Path != "" {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Space: production.Namespace, Local: attrPath}, Value: b.Path})
+	}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	for _, o := range b.Operands {
+		xo := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrBoolean}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrObjectID}, Value: strconv.FormatUint(uint64(o.ObjectID), 10)},
+		}}
+		if o.HasTransform() {
+			xo.Attr = append(xo.Attr, xml.Attr{Name: xml.Name{Local: attrTransform}, Value: o.Transform.String()})
+		}
+		if o.Path != "" {
+			xo.Attr = append(xo.Attr, xml.Attr{Name: xml.Name{Space: production.Namespace, Local: attrPath}, Value: o.Path})
+		}
+		x.EncodeToken(xo)
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
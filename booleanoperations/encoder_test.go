@@ -0,0 +1,41 @@
+package booleanoperations
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/production"
+)
+
+func TestMarshalModel(t *testing.T) {
+	shape := &BooleanShape{ObjectID: 1, Operation: OperationIntersection, Transform: go3mf.Matrix{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 10, 0, 0, 1}, Operands: []Operand{
+		{ObjectID: 2},
+		{ObjectID: 3, Transform: go3mf.Matrix{2, 0, 0, 0, 0, 2, 0, 0, 0, 0, 2, 0, 0, 0, 0, 1}, Path: "/3D/other.model"},
+	}}
+	m := &go3mf.Model{Path: "/3D/3dmodel.model"}
+	m.Resources.Objects = append(m.Resources.Objects,
+		&go3mf.Object{ID: 4, Any: go3mf.Marshalers{shape}},
+		&go3mf.Object{ID: 5, Any: go3mf.Marshalers{&BooleanShape{ObjectID: 4, Path: "/3D/other.model"}}},
+	)
+
+	t.Run("base", func(t *testing.T) {
+		m.WithSpec(&Spec{LocalName: "bo"})
+		m.WithSpec(&production.Spec{LocalName: "p"})
+		b, err := go3mf.MarshalModel(m)
+		if err != nil {
+			t.Errorf("booleanoperations.MarshalModel() error = %v", err)
+			return
+		}
+		newModel := new(go3mf.Model)
+		newModel.WithSpec(&Spec{LocalName: "bo"})
+		newModel.Path = m.Path
+		if err := go3mf.UnmarshalModel(b, newModel); err != nil {
+			t.Errorf("booleanoperations.MarshalModel() error decoding = %v, s = %s", err, string(b))
+			return
+		}
+		if diff := deep.Equal(m.Resources, newModel.Resources); diff != nil {
+			t.Errorf("booleanoperations.MarshalModel() = %v, s = %s", diff, string(b))
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package booleanoperations
+
+import (
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+	"github.com/qmuntal/go3mf/production"
+)
+
+func (e *Spec) ValidateModel(_ *go3mf.Model) error {
+	return nil
+}
+
+func (e *Spec) ValidateAsset(_ *go3mf.Model, _ string, _ go3mf.Asset) error {
+	return nil
+}
+
+func (e *Spec) ValidateObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
+	var bs *BooleanShape
+	if !obj.Any.Get(&bs) {
+		return nil
+	}
+
+	var errs error
+	usesPath := bs.Path != ""
+	if bs.ObjectID == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrObjectID))
+	} else if base, ok := m.FindObject(bs.ObjectPath(path), bs.ObjectID); !ok {
+		errs = errors.Append(errs, errors.ErrMissingResource)
+	} else if e.isRecursive(m, path, obj, bs) {
+		errs = errors.Append(errs, errors.ErrRecursion)
+	} else if base.Type != go3mf.ObjectTypeModel || len(base.Components) > 0 || (base.Mesh == nil && len(base.Any) == 0) {
+		errs = errors.Append(errs, errors.ErrBooleanBase)
+	}
+	for i, o := range bs.Operands {
+		usesPath = usesPath || o.Path != ""
+		if o.ObjectID == 0 {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrObjectID), o, i))
+		} else if ref, ok := m.FindObject(o.ObjectPath(path), o.ObjectID); !ok {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrMissingResource, o, i))
+		} else if ref == obj {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrRecursion, o, i))
+		} else if ref.Type != go3mf.ObjectTypeModel || ref.Mesh == nil {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrBooleanOperand, o, i))
+		}
+	}
+	if usesPath {
+		if spec, ok := m.Specs[production.Namespace]; !ok || !spec.Required() {
+			errs = errors.Append(errs, errors.ErrProdExtRequired)
+		}
+	}
+	if errs != nil {
+		errs = errors.Wrap(errs, bs)
+	}
+	if obj.Mesh != nil || len(obj.Components) > 0 {
+		errs = errors.Append(errs, errors.ErrBooleanObject)
+	}
+	return errs
+}
+
+// isRecursive follows the chain of base objects starting at bs
+// and reports whether it leads back to obj.
+func (e *Spec) isRecursive(m *go3mf.Model, path string, obj *go3mf.Object, bs *BooleanShape) bool {
+	visited := make(map[*go3mf.Object]struct{})
+	for {
+		path = bs.ObjectPath(path)
+		base, ok := m.FindObject(path, bs.ObjectID)
+		if !ok {
+			return false
+		}
+		if base == obj {
+			return true
+		}
+		if _, ok := visited[base]; ok {
+			return false
+		}
+		visited[base] = struct{}{}
+		if !base.Any.Get(&bs) {
+			return false
+		}
+	}
+}
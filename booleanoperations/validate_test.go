@@ -0,0 +1,80 @@
+package booleanoperations
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func TestValidate(t *testing.T) {
+	tetrahedron := func() *go3mf.Mesh {
+		return &go3mf.Mesh{Vertices: []go3mf.Point3D{{}, {}, {}, {}}, Triangles: []go3mf.Triangle{
+			go3mf.NewTriangle(0, 1, 2), go3mf.NewTriangle(0, 3, 1), go3mf.NewTriangle(0, 2, 3), go3mf.NewTriangle(1, 3, 2),
+		}}
+	}
+	tests := []struct {
+		name  string
+		model *go3mf.Model
+		want  []error
+	}{
+		{"child", &go3mf.Model{Childs: map[string]*go3mf.ChildModel{
+			"/other.model": {Resources: go3mf.Resources{Objects: []*go3mf.Object{
+				{ID: 1, Any: go3mf.Marshalers{&BooleanShape{}}},
+			}}},
+		}}, []error{
+			fmt.Errorf("/other.model@Resources@Object#0@BooleanShape: %v", &errors.MissingFieldError{Name: attrObjectID}),
+		}},
+		{"base", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Mesh: tetrahedron()},
+			{ID: 2, Components: []*go3mf.Component{{ObjectID: 1}}},
+			{ID: 3, Any: go3mf.Marshalers{&BooleanShape{ObjectID: 1}}},
+			{ID: 4, Any: go3mf.Marshalers{&BooleanShape{ObjectID: 100}}},
+			{ID: 5, Any: go3mf.Marshalers{&BooleanShape{ObjectID: 2}}},
+			{ID: 6, Any: go3mf.Marshalers{&BooleanShape{ObjectID: 6}}},
+			{ID: 7, Any: go3mf.Marshalers{&BooleanShape{ObjectID: 8}}},
+			{ID: 8, Any: go3mf.Marshalers{&BooleanShape{ObjectID: 7}}},
+			{ID: 9, Any: go3mf.Marshalers{&BooleanShape{ObjectID: 3}}},
+			{ID: 10, Type: go3mf.ObjectTypeSupport, Mesh: tetrahedron()},
+			{ID: 11, Any: go3mf.Marshalers{&BooleanShape{ObjectID: 10}}},
+		}}}, []error{
+			fmt.Errorf("Resources@Object#3@BooleanShape: %v", errors.ErrMissingResource),
+			fmt.Errorf("Resources@Object#4@BooleanShape: %v", errors.ErrBooleanBase),
+			fmt.Errorf("Resources@Object#5@BooleanShape: %v", errors.ErrRecursion),
+			fmt.Errorf("Resources@Object#6@BooleanShape: %v", errors.ErrRecursion),
+			fmt.Errorf("Resources@Object#7@BooleanShape: %v", errors.ErrRecursion),
+			fmt.Errorf("Resources@Object#10@BooleanShape: %v", errors.ErrBooleanBase),
+		}},
+		{"operands", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Mesh: tetrahedron()},
+			{ID: 2, Any: go3mf.Marshalers{&BooleanShape{ObjectID: 1}}},
+			{ID: 3, Any: go3mf.Marshalers{&BooleanShape{ObjectID: 1, Operands: []Operand{
+				{ObjectID: 1}, {}, {ObjectID: 100}, {ObjectID: 2}, {ObjectID: 3},
+			}}}},
+		}}}, []error{
+			fmt.Errorf("Resources@Object#2@BooleanShape@Operand#1: %v", &errors.MissingFieldError{Name: attrObjectID}),
+			fmt.Errorf("Resources@Object#2@BooleanShape@Operand#2: %v", errors.ErrMissingResource),
+			fmt.Errorf("Resources@Object#2@BooleanShape@Operand#3: %v", errors.ErrBooleanOperand),
+			fmt.Errorf("Resources@Object#2@BooleanShape@Operand#4: %v", errors.ErrRecursion),
+		}},
+		{"object", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Mesh: tetrahedron()},
+			{ID: 2, Mesh: tetrahedron(), Any: go3mf.Marshalers{&BooleanShape{ObjectID: 1}}},
+			{ID: 3, Any: go3mf.Marshalers{&BooleanShape{ObjectID: 1, Path: "/3D/3dmodel.model"}}},
+		}}}, []error{
+			fmt.Errorf("Resources@Object#1: %v", errors.ErrBooleanObject),
+			fmt.Errorf("Resources@Object#2@BooleanShape: %v", errors.ErrProdExtRequired),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.model.WithSpec(&Spec{})
+			got := tt.model.Validate()
+			if diff := deep.Equal(got.(*errors.List).Errors, tt.want); diff != nil {
+				t.Errorf("Validate() = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package go3mf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+const nsXMLPrefix = "http://www.w3.org/XML/1998/namespace"
+
+// canonicalize returns the inclusive canonical XML form, without comments,
+// of the element whose local name is local and whose Id attribute is id.
+// If id is empty the Id attribute is not checked.
+//
+// Only the direct children of the xmldsig Signature root element are considered,
+// and the document must not contain duplicated Id attributes nor more than one
+// matching element, so the signed content cannot be moved elsewhere in the signature.
+func canonicalize(data []byte, local, id string) ([]byte, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var (
+		scopes   []map[string]string
+		rendered []map[string]string
+		buf      bytes.Buffer
+		found    bool
+	)
+	ids := make(map[string]struct{})
+	for {
+		t, err := d.RawToken()
+		if err == io.EOF {
+			if !found {
+				return nil, errors.New("canonicalized element not found: " + local)
+			}
+			return buf.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			depth := len(scopes)
+			scope := make(map[string]string)
+			if depth > 0 {
+				for k, v := range scopes[depth-1] {
+					scope[k] = v
+				}
+			}
+			for _, a := range t.Attr {
+				if a.Name.Space == attrXmlns {
+					scope[a.Name.Local] = a.Value
+				} else if a.Name.Space == "" && a.Name.Local == attrXmlns {
+					scope[""] = a.Value
+				}
+			}
+			scopes = append(scopes, scope)
+			if v := attrValue(t.Attr, "Id"); v != "" {
+				if _, ok := ids[v]; ok {
+					return nil, errors.New("duplicated Id attribute: " + v)
+				}
+				ids[v] = struct{}{}
+			}
+			space := scope[t.Name.Space]
+			if depth == 0 && (space != nsXMLDSig || t.Name.Local != "Signature") {
+				return nil, errors.New("root element is not an xml signature")
+			}
+			if rendered == nil {
+				if depth != 1 || space != nsXMLDSig || t.Name.Local != local || (id != "" && attrValue(t.Attr, "Id") != id) {
+					continue
+				}
+				if found {
+					return nil, errors.New("duplicated canonicalized element: " + local)
+				}
+				found = true
+				rendered = []map[string]string{{}}
+			}
+			writeCanonicalStart(&buf, t, scope, rendered[len(rendered)-1])
+			rendered = append(rendered, scope)
+		case xml.EndElement:
+			scopes = scopes[:len(scopes)-1]
+			if rendered == nil {
+				continue
+			}
+			buf.WriteString("</" + rawName(t.Name) + ">")
+			rendered = rendered[:len(rendered)-1]
+			if len(rendered) == 1 {
+				rendered = nil
+			}
+		case xml.CharData:
+			if rendered != nil {
+				escapeCanonical(&buf, string(t), false)
+			}
+		}
+	}
+}
+
+func writeCanonicalStart(buf *bytes.Buffer, t xml.StartElement, scope, parent map[string]string) {
+	buf.WriteString("<" + rawName(t.Name))
+	prefixes := make([]string, 0, len(scope))
+	for prefix, uri := range scope {
+		if v, ok := parent[prefix]; (ok && v == uri) || (!ok && prefix == "" && uri == "") {
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		if prefix == "" {
+			buf.WriteString(` xmlns="`)
+		} else {
+			buf.WriteString(" xmlns:" + prefix + `="`)
+		}
+		escapeCanonical(buf, scope[prefix], true)
+		buf.WriteByte('"')
+	}
+	attrs := make([]xml.Attr, 0, len(t.Attr))
+	for _, a := range t.Attr {
+		if a.Name.Space != attrXmlns && !(a.Name.Space == "" && a.Name.Local == attrXmlns) {
+			attrs = append(attrs, a)
+		}
+	}
+	uri := func(a xml.Attr) string {
+		if a.Name.Space == attrXML {
+			return nsXMLPrefix
+		}
+		if a.Name.Space == "" {
+			return ""
+		}
+		return scope[a.Name.Space]
+	}
+	sort.SliceStable(attrs, func(i, j int) bool {
+		ui, uj := uri(attrs[i]), uri(attrs[j])
+		if ui != uj {
+			return ui < uj
+		}
+		return attrs[i].Name.Local < attrs[j].Name.Local
+	})
+	for _, a := range attrs {
+		buf.WriteString(" " + rawName(a.Name) + `="`)
+		escapeCanonical(buf, a.Value, true)
+		buf.WriteByte('"')
+	}
+	buf.WriteByte('>')
+}
+
+func rawName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
+
+func attrValue(attrs []xml.Attr, local string) string {
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func escapeCanonical(buf *bytes.Buffer, s string, attr bool) {
+	for _, r := range s {
+		switch {
+		case r == '&':
+			buf.WriteString("&amp;")
+		case r == '<':
+			buf.WriteString("&lt;")
+		case r == '>' && !attr:
+			buf.WriteString("&gt;")
+		case r == '"' && attr:
+			buf.WriteString("&quot;")
+		case r == '\t' && attr:
+			buf.WriteString("&#x9;")
+		case r == '\n' && attr:
+			buf.WriteString("&#xA;")
+		case r == '\r':
+			buf.WriteString("&#xD;")
+		default:
+			buf.WriteRune(r)
+		}
+	}
+}
+
+type xmlRelationship struct {
+	ID         string `xml:"Id,attr"`
+	Type       string `xml:"Type,attr"`
+	Target     string `xml:"Target,attr"`
+	TargetMode string `xml:"TargetMode,attr"`
+}
+
+type xmlRelationships struct {
+	Relationships []xmlRelationship `xml:"Relationship"`
+}
+
+// newXMLRelationships returns the relationships as written by the package writer.
+func newXMLRelationships(rels []Relationship) []xmlRelationship {
+	xrels := make([]xmlRelationship, len(rels))
+	for i, r := range rels {
+		target := r.Path
+		if !strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "\\") && !strings.HasPrefix(target, ".") {
+			target = "/" + target
+		}
+		xrels[i] = xmlRelationship{ID: r.ID, Type: r.Type, Target: target}
+	}
+	return xrels
+}
+
+// transformRelationships applies the OPC relationship transform,
+// selecting the relationships by id or type, followed by a canonicalization.
+func transformRelationships(rels []xmlRelationship, ids, types []string) []byte {
+	selected := make([]xmlRelationship, 0, len(rels))
+	for _, r := range rels {
+		if containsString(ids, r.ID) || containsString(types, r.Type) {
+			selected = append(selected, r)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].ID < selected[j].ID
+	})
+	var buf bytes.Buffer
+	buf.WriteString(`<Relationships xmlns="` + nsRelationships + `">`)
+	for _, r := range selected {
+		mode := r.TargetMode
+		if mode == "" {
+			mode = "Internal"
+		}
+		buf.WriteString(`<Relationship Id="`)
+		escapeCanonical(&buf, r.ID, true)
+		buf.WriteString(`" Target="`)
+		escapeCanonical(&buf, r.Target, true)
+		buf.WriteString(`" TargetMode="`)
+		escapeCanonical(&buf, mode, true)
+		buf.WriteString(`" Type="`)
+		escapeCanonical(&buf, r.Type, true)
+		buf.WriteString(`"></Relationship>`)
+	}
+	buf.WriteString("</Relationships>")
+	return buf.Bytes()
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package go3mf
 
 import (
 	"encoding/xml"
+	"errors"
 	"image/color"
 	"io"
 	"sort"
@@ -68,10 +69,14 @@ type Metadata struct {
 }
 
 // Attachment defines the Model Attachment.
+//
+// Relationships are the relationships whose source is the attachment part,
+// only filled for the parts preserved by the decoder.
 type Attachment struct {
-	Stream      io.Reader
-	Path        string
-	ContentType string
+	Stream        io.Reader
+	Path          string
+	ContentType   string
+	Relationships []Relationship
 }
 
 // Relationship defines a dependency between
@@ -88,6 +93,7 @@ type Relationship struct {
 type Build struct {
 	Items   []*Item
 	AnyAttr AttrMarshalers
+	Any     Marshalers
 }
 
 // The Resources element acts as the root element of a library of constituent
@@ -96,6 +102,7 @@ type Resources struct {
 	Assets  []Asset
 	Objects []*Object
 	AnyAttr AttrMarshalers
+	Any     Marshalers
 }
 
 // UnusedID returns the lowest unused ID.
@@ -154,6 +161,8 @@ func (rs *Resources) FindAsset(id uint32) (Asset, bool) {
 type ChildModel struct {
 	Resources     Resources
 	Relationships []Relationship
+	PrintTicket   *PrintTicket
+	AnyAttr       AttrMarshalers
 	Any           Marshalers
 }
 
@@ -164,6 +173,7 @@ type ChildModel struct {
 // but they are usefull to reference custom attachments.
 // Childs keys cannot be an empty string.
 // RootRelationships are the OPC root relationships.
+// PrintTicket is the print ticket attached to the root model part.
 type Model struct {
 	Path              string
 	Language          string
@@ -177,6 +187,7 @@ type Model struct {
 	Childs            map[string]*ChildModel // path -> child
 	RootRelationships []Relationship
 	Relationships     []Relationship
+	PrintTicket       *PrintTicket
 	Any               Marshalers
 	AnyAttr           AttrMarshalers
 }
@@ -270,6 +281,69 @@ func (m *Model) WalkObjects(fn func(string, *Object) error) error {
 	return nil
 }
 
+// maxComponentDepth limits the component nesting walked by WalkBuild and WalkComponents
+// to avoid infinite loops on malformed models.
+const maxComponentDepth = 32
+
+// SkipComponents is used as a return value from the functions called by WalkBuild
+// and WalkComponents to indicate that the components of the object in the call are not walked.
+// It is not returned as an error by any function.
+var SkipComponents = errors.New("skip components")
+
+// WalkBuild walks the objects referenced by the build items and their components, calling fn
+// for each object with the model part path where it is defined and its transform relative to the build,
+// and stopping if fn returns an error.
+//
+// Zero transforms are treated as the identity, references to missing objects are ignored
+// and the components nested more than 32 levels are not walked.
+func (m *Model) WalkBuild(fn func(string, *Object, Matrix) error) error {
+	for _, item := range m.Build.Items {
+		if obj, ok := m.FindObject(item.ObjectPath(), item.ObjectID); ok {
+			if err := m.walkComponents(item.ObjectPath(), obj, item.Transform, fn, 0); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WalkComponents walks obj, which is defined in the model part path, and its components,
+// calling fn for each object with the model part path where it is defined and its transform
+// relative to transform, and stopping if fn returns an error.
+//
+// See WalkBuild for more details.
+func (m *Model) WalkComponents(path string, obj *Object, transform Matrix, fn func(string, *Object, Matrix) error) error {
+	return m.walkComponents(path, obj, transform, fn, 0)
+}
+
+func (m *Model) walkComponents(path string, obj *Object, transform Matrix, fn func(string, *Object, Matrix) error, depth int) error {
+	if depth > maxComponentDepth {
+		return nil
+	}
+	if transform == (Matrix{}) {
+		transform = Identity()
+	}
+	if err := fn(path, obj, transform); err != nil {
+		if err == SkipComponents {
+			return nil
+		}
+		return err
+	}
+	for _, c := range obj.Components {
+		cpath := c.ObjectPath(path)
+		if ref, ok := m.FindObject(cpath, c.ObjectID); ok {
+			ct := c.Transform
+			if ct == (Matrix{}) {
+				ct = Identity()
+			}
+			if err := m.walkComponents(cpath, ref, transform.Mul(ct), fn, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Base defines the Model Base Material Resource.
 // A model material resource is an in memory representation of the 3MF
 // material resource object.
@@ -282,6 +356,7 @@ type Base struct {
 type BaseMaterials struct {
 	ID        uint32
 	Materials []Base
+	AnyAttr   AttrMarshalers
 }
 
 // Len returns the materials count.
@@ -301,6 +376,7 @@ type Item struct {
 	PartNumber string
 	Metadata   []Metadata
 	AnyAttr    AttrMarshalers
+	Any        Marshalers
 }
 
 // ObjectPath search an extension attribute with an ObjectPath
@@ -324,18 +400,24 @@ func (b *Item) HasTransform() bool {
 }
 
 // An Object is an in memory representation of the 3MF model object.
+//
+// Objects without Mesh and Components can define
+// their geometry with an extension element in Any.
+// ComponentsAny contains the extension elements of the components element.
 type Object struct {
-	ID         uint32
-	Name       string
-	PartNumber string
-	Thumbnail  string
-	PID        uint32
-	PIndex     uint32
-	Type       ObjectType
-	Metadata   []Metadata
-	Mesh       *Mesh
-	Components []*Component
-	AnyAttr    AttrMarshalers
+	ID            uint32
+	Name          string
+	PartNumber    string
+	Thumbnail     string
+	PID           uint32
+	PIndex        uint32
+	Type          ObjectType
+	Metadata      []Metadata
+	Mesh          *Mesh
+	Components    []*Component
+	ComponentsAny Marshalers
+	AnyAttr       AttrMarshalers
+	Any           Marshalers
 }
 
 // A Component is an in memory representation of the 3MF component.
@@ -415,10 +497,47 @@ func (t Triangle) PIndices() (uint32, uint32, uint32) {
 // orientation (i.e. the face can look up or look down) and have three nodes.
 // The orientation is defined by the order of its nodes.
 type Mesh struct {
-	Vertices  []Point3D
-	Triangles []Triangle
-	AnyAttr   AttrMarshalers
-	Any       Marshalers
+	Vertices     []Point3D
+	Triangles    []Triangle
+	TriangleSets []TriangleSet
+	AnyAttr      AttrMarshalers
+	Any          Marshalers
+}
+
+// A TriangleSet groups a selection of mesh triangles under a name.
+// Indices reference the Triangles of the Mesh.
+type TriangleSet struct {
+	Name       string
+	Identifier string
+	Indices    []uint32
+}
+
+// An Edge is a unique edge of a mesh.
+// Indices are sorted in ascending order and Triangles references
+// the triangles that share the edge, in order of appearance.
+type Edge struct {
+	Indices   [2]uint32
+	Triangles []uint32
+}
+
+// Edges returns the unique edges of the mesh in order of appearance.
+func (m *Mesh) Edges() []Edge {
+	var edges []Edge
+	pairMatching := make(pairMatch)
+	for i, face := range m.Triangles {
+		for j := 0; j < 3; j++ {
+			n1, n2 := face[j].ToUint32(), face[(j+1)%3].ToUint32()
+			edgeIndex, ok := pairMatching.CheckMatch(n1, n2)
+			if !ok {
+				edgeIndex = uint32(len(edges))
+				pairMatching.AddMatch(n1, n2, edgeIndex)
+				e := newPairEntry(n1, n2)
+				edges = append(edges, Edge{Indices: [2]uint32{e.a, e.b}})
+			}
+			edges[edgeIndex].Triangles = append(edges[edgeIndex].Triangles, uint32(i))
+		}
+	}
+	return edges
 }
 
 // MeshBuilder is a helper that creates mesh following a configurable criteria.
@@ -488,6 +607,8 @@ const (
 const (
 	// Namespace is the canonical name of this extension.
 	Namespace = "http://schemas.microsoft.com/3dmanufacturing/core/2015/02"
+	// NamespaceTriangleSets is the canonical name of the core triangle sets.
+	NamespaceTriangleSets = "http://schemas.microsoft.com/3dmanufacturing/trianglesets/2021/07"
 
 	// RelType3DModel is the canonical 3D model relationship type.
 	RelType3DModel = "http://schemas.microsoft.com/3dmanufacturing/2013/01/3dmodel"
@@ -558,4 +679,12 @@ const (
 	attrMetadata      = "metadata"
 	attrMetadataGroup = "metadatagroup"
 	attrPath          = "path"
+	attrTriangleSets  = "trianglesets"
+	attrTriangleSet   = "triangleset"
+	attrIdentifier    = "identifier"
+	attrRef           = "ref"
+	attrRefRange      = "refrange"
+	attrIndex         = "index"
+	attrStartIndex    = "startindex"
+	attrEndIndex      = "endindex"
 )
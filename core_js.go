@@ -23,6 +23,7 @@ var (
 	jsItem                  = RegisterClass("Item")
 	jsBuild                 = RegisterClass("Build")
 	jsMesh                  = RegisterClass("Mesh")
+	jsTriangleSet           = RegisterClass("TriangleSet")
 	jsComponent             = RegisterClass("Component")
 	jsObject                = RegisterClass("Object")
 	jsBase                  = RegisterClass("Base")
@@ -99,6 +100,7 @@ func (att Attachment) JSValue() js.Value {
 	v.Set("data", data)
 	v.Set(attrPath, att.Path)
 	v.Set("contentType", att.ContentType)
+	v.Set("relationships", jsValueRelationships(att.Relationships))
 	return v
 }
 
@@ -135,6 +137,7 @@ func (item *Item) JSValue() js.Value {
 	v.Set(attrTransform, item.Transform)
 	setString(v, "partNumber", item.PartNumber)
 	v.Set(attrMetadata, jsValueMetadatas(item.Metadata))
+	v.Set("any", item.Any)
 	v.Set("anyAttr", item.AnyAttr)
 	return v
 }
@@ -147,6 +150,7 @@ func (b Build) JSValue() js.Value {
 		arr.SetIndex(i, item)
 	}
 	v.Set("items", arr)
+	v.Set("any", b.Any)
 	v.Set("anyAttr", b.AnyAttr)
 	return v
 }
@@ -175,11 +179,31 @@ func (m *Mesh) JSValue() js.Value {
 	triangles := uint8ArrayConstructor.New(ht.Len)
 	js.CopyBytesToJS(triangles, *(*[]byte)(unsafe.Pointer(ht)))
 	v.Set(attrTriangles, triangles)
+	sets := arrayConstructor.New(len(m.TriangleSets))
+	for i, s := range m.TriangleSets {
+		sets.SetIndex(i, s)
+	}
+	v.Set("triangleSets", sets)
 	v.Set("any", m.Any)
 	v.Set("anyAttr", m.AnyAttr)
 	return v
 }
 
+// JSValue returns a JavaScript value associated with the object.
+//
+// Indices are encoded as a Uint32Array.
+func (s TriangleSet) JSValue() js.Value {
+	v := jsTriangleSet.New()
+	v.Set(attrName, s.Name)
+	v.Set(attrIdentifier, s.Identifier)
+	indices := uint32ArrayConstructor.New(len(s.Indices))
+	for i, idx := range s.Indices {
+		indices.SetIndex(i, idx)
+	}
+	v.Set("indices", indices)
+	return v
+}
+
 // JSValue returns a JavaScript value associated with the object.
 func (c *Component) JSValue() js.Value {
 	v := jsComponent.New()
@@ -213,6 +237,7 @@ func (r *Object) JSValue() js.Value {
 		}
 		v.Set(attrComponents, comps)
 	}
+	v.Set("any", r.Any)
 	v.Set("anyAttr", r.AnyAttr)
 	return v
 }
@@ -234,6 +259,7 @@ func (r *BaseMaterials) JSValue() js.Value {
 		bases.SetIndex(i, b)
 	}
 	v.Set("materials", bases)
+	v.Set("anyAttr", r.AnyAttr)
 	return v
 }
 
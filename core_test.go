@@ -1,6 +1,7 @@
 package go3mf
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -263,6 +264,30 @@ func TestMeshBuilder_AddVertex(t *testing.T) {
 	}
 }
 
+func TestMesh_Edges(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *Mesh
+		want []Edge
+	}{
+		{"empty", new(Mesh), nil},
+		{"base", &Mesh{Triangles: []Triangle{NewTriangle(0, 1, 2), NewTriangle(2, 1, 3)}}, []Edge{
+			{Indices: [2]uint32{0, 1}, Triangles: []uint32{0}},
+			{Indices: [2]uint32{1, 2}, Triangles: []uint32{0, 1}},
+			{Indices: [2]uint32{0, 2}, Triangles: []uint32{0}},
+			{Indices: [2]uint32{1, 3}, Triangles: []uint32{1}},
+			{Indices: [2]uint32{2, 3}, Triangles: []uint32{1}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Edges(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Mesh.Edges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_newObjectType(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -427,3 +452,54 @@ func TestModel_WalkObjects(t *testing.T) {
 		})
 	}
 }
+
+func TestModel_WalkBuild(t *testing.T) {
+	leaf := &Object{ID: 1, Mesh: new(Mesh)}
+	parent := &Object{ID: 2, Components: []*Component{
+		{ObjectID: 1, Transform: Matrix{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 5, 1}},
+		{ObjectID: 3},
+	}}
+	loop := &Object{ID: 4, Components: []*Component{{ObjectID: 4}}}
+	m := &Model{
+		Resources: Resources{Objects: []*Object{leaf, parent, loop}},
+		Build: Build{Items: []*Item{
+			{ObjectID: 2, Transform: Matrix{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 1, 0, 0, 1}},
+			{ObjectID: 5},
+			{ObjectID: 4},
+		}},
+	}
+	type visit struct {
+		obj       *Object
+		transform Matrix
+	}
+	var got []visit
+	err := m.WalkBuild(func(path string, obj *Object, transform Matrix) error {
+		if obj == loop {
+			return SkipComponents
+		}
+		got = append(got, visit{obj, transform})
+		return nil
+	})
+	want := []visit{
+		{parent, Matrix{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 1, 0, 0, 1}},
+		{leaf, Matrix{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 1, 0, 5, 1}},
+	}
+	if err != nil {
+		t.Errorf("Model.WalkBuild() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Model.WalkBuild() = %v, want %v", got, want)
+	}
+	var count int
+	m.WalkBuild(func(string, *Object, Matrix) error {
+		count++
+		return nil
+	})
+	if want := 2 + maxComponentDepth + 1; count != want {
+		t.Errorf("Model.WalkBuild() visited %d objects, want %d", count, want)
+	}
+	wantErr := errors.New("")
+	if err := m.WalkBuild(func(string, *Object, Matrix) error { return wantErr }); err != wantErr {
+		t.Errorf("Model.WalkBuild() error = %v, want %v", err, wantErr)
+	}
+}
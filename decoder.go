@@ -34,6 +34,7 @@ func (d *modelDecoder) Child(name xml.Name) (child NodeDecoder) {
 
 func (d *modelDecoder) Start(attrs []xml.Attr) {
 	if !d.Scanner.IsRoot {
+		d.childAttributes(attrs)
 		return
 	}
 	var requiredExts []string
@@ -72,6 +73,10 @@ func (d *modelDecoder) noCoreAttribute(a xml.Attr) {
 			d.model.Language = a.Value
 		}
 	case attrXmlns:
+		if a.Value == NamespaceTriangleSets {
+			// Core namespace, declared by the encoder when needed.
+			return
+		}
 		if ext, ok := d.model.Specs[a.Value]; ok {
 			ext.SetLocal(a.Name.Local)
 		} else {
@@ -80,10 +85,34 @@ func (d *modelDecoder) noCoreAttribute(a xml.Attr) {
 	default:
 		if ext, ok := d.Scanner.extensionDecoder[a.Name.Space]; ok {
 			ext.DecodeAttribute(d.Scanner, d.model, a)
+		} else {
+			d.Scanner.addUnknownAttr(&d.model.AnyAttr, a)
 		}
 	}
 }
 
+// childAttributes keeps the unknown attributes of a non-root model
+// in its ChildModel, as the core ones are only defined for the root model.
+func (d *modelDecoder) childAttributes(attrs []xml.Attr) {
+	child, ok := d.model.Childs[d.Scanner.ModelPath]
+	if !ok {
+		return
+	}
+	for _, a := range attrs {
+		d.Scanner.addUnknownAttr(&child.AnyAttr, a)
+	}
+}
+
+func (d *modelDecoder) anyMarshalers() *Marshalers {
+	if d.Scanner.IsRoot {
+		return &d.model.Any
+	}
+	if child, ok := d.model.Childs[d.Scanner.ModelPath]; ok {
+		return &child.Any
+	}
+	return nil
+}
+
 type metadataGroupDecoder struct {
 	baseDecoder
 	metadatas *[]Metadata
@@ -151,10 +180,16 @@ func (d *buildDecoder) Start(attrs []xml.Attr) {
 	for _, a := range attrs {
 		if ext, ok := d.Scanner.extensionDecoder[a.Name.Space]; ok {
 			ext.DecodeAttribute(d.Scanner, d.build, a)
+		} else {
+			d.Scanner.addUnknownAttr(&d.build.AnyAttr, a)
 		}
 	}
 }
 
+func (d *buildDecoder) anyMarshalers() *Marshalers {
+	return &d.build.Any
+}
+
 type buildItemDecoder struct {
 	baseDecoder
 	item Item
@@ -178,9 +213,14 @@ func (d *buildItemDecoder) Start(attrs []xml.Attr) {
 			d.parseCoreAttr(a)
 		} else if ext, ok := d.Scanner.extensionDecoder[a.Name.Space]; ok {
 			ext.DecodeAttribute(d.Scanner, &d.item, a)
+		} else {
+			d.Scanner.addUnknownAttr(&d.item.AnyAttr, a)
 		}
 	}
-	return
+}
+
+func (d *buildItemDecoder) anyMarshalers() *Marshalers {
+	return &d.item.Any
 }
 
 func (d *buildItemDecoder) parseCoreAttr(a xml.Attr) {
@@ -207,6 +247,20 @@ type resourceDecoder struct {
 	baseDecoder
 }
 
+func (d *resourceDecoder) Start(attrs []xml.Attr) {
+	for _, a := range attrs {
+		if ext, ok := d.Scanner.extensionDecoder[a.Name.Space]; ok {
+			ext.DecodeAttribute(d.Scanner, &d.Scanner.Resources, a)
+		} else {
+			d.Scanner.addUnknownAttr(&d.Scanner.Resources.AnyAttr, a)
+		}
+	}
+}
+
+func (d *resourceDecoder) anyMarshalers() *Marshalers {
+	return &d.Scanner.Resources.Any
+}
+
 func (d *resourceDecoder) Child(name xml.Name) (child NodeDecoder) {
 	if name.Space == Namespace {
 		switch name.Local {
@@ -241,13 +295,18 @@ func (d *baseMaterialsDecoder) Child(name xml.Name) (child NodeDecoder) {
 func (d *baseMaterialsDecoder) Start(attrs []xml.Attr) {
 	d.baseMaterialDecoder.resource = &d.resource
 	for _, a := range attrs {
-		if a.Name.Space == "" && a.Name.Local == attrID {
-			id, err := strconv.ParseUint(a.Value, 10, 32)
-			if err != nil {
-				d.Scanner.InvalidAttr(a.Name.Local, true)
+		if a.Name.Space == "" {
+			if a.Name.Local == attrID {
+				id, err := strconv.ParseUint(a.Value, 10, 32)
+				if err != nil {
+					d.Scanner.InvalidAttr(a.Name.Local, true)
+				}
+				d.resource.ID, d.Scanner.ResourceID = uint32(id), uint32(id)
 			}
-			d.resource.ID, d.Scanner.ResourceID = uint32(id), uint32(id)
-			break
+		} else if ext, ok := d.Scanner.extensionDecoder[a.Name.Space]; ok {
+			ext.DecodeAttribute(d.Scanner, &d.resource, a)
+		} else {
+			d.Scanner.addUnknownAttr(&d.resource.AnyAttr, a)
 		}
 	}
 }
@@ -281,8 +340,19 @@ type meshDecoder struct {
 	resource *Object
 }
 
-func (d *meshDecoder) Start(_ []xml.Attr) {
+func (d *meshDecoder) Start(attrs []xml.Attr) {
 	d.resource.Mesh = new(Mesh)
+	for _, a := range attrs {
+		if ext, ok := d.Scanner.extensionDecoder[a.Name.Space]; ok {
+			ext.DecodeAttribute(d.Scanner, d.resource.Mesh, a)
+		} else {
+			d.Scanner.addUnknownAttr(&d.resource.Mesh.AnyAttr, a)
+		}
+	}
+}
+
+func (d *meshDecoder) anyMarshalers() *Marshalers {
+	return &d.resource.Mesh.Any
 }
 
 func (d *meshDecoder) Child(name xml.Name) (child NodeDecoder) {
@@ -292,12 +362,119 @@ func (d *meshDecoder) Child(name xml.Name) (child NodeDecoder) {
 		} else if name.Local == attrTriangles {
 			child = &trianglesDecoder{resource: d.resource}
 		}
+	} else if name.Space == NamespaceTriangleSets && name.Local == attrTriangleSets {
+		child = &triangleSetsDecoder{mesh: d.resource.Mesh}
 	} else if ext, ok := d.Scanner.extensionDecoder[name.Space]; ok {
 		child = ext.NewNodeDecoder(d.resource.Mesh, name.Local)
 	}
 	return
 }
 
+type triangleSetsDecoder struct {
+	baseDecoder
+	mesh *Mesh
+}
+
+func (d *triangleSetsDecoder) Child(name xml.Name) (child NodeDecoder) {
+	if name.Space == NamespaceTriangleSets && name.Local == attrTriangleSet {
+		child = &triangleSetDecoder{mesh: d.mesh}
+	}
+	return
+}
+
+type triangleSetDecoder struct {
+	baseDecoder
+	mesh *Mesh
+	set  TriangleSet
+}
+
+func (d *triangleSetDecoder) Start(attrs []xml.Attr) {
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			d.set.Name = a.Value
+		case attrIdentifier:
+			d.set.Identifier = a.Value
+		}
+	}
+}
+
+func (d *triangleSetDecoder) Child(name xml.Name) (child NodeDecoder) {
+	if name.Space == NamespaceTriangleSets {
+		if name.Local == attrRef {
+			child = &triangleRefDecoder{set: &d.set, mesh: d.mesh}
+		} else if name.Local == attrRefRange {
+			child = &triangleRefRangeDecoder{set: &d.set, mesh: d.mesh}
+		}
+	}
+	return
+}
+
+func (d *triangleSetDecoder) End() {
+	d.mesh.TriangleSets = append(d.mesh.TriangleSets, d.set)
+}
+
+type triangleRefDecoder struct {
+	baseDecoder
+	set  *TriangleSet
+	mesh *Mesh
+}
+
+func (d *triangleRefDecoder) Start(attrs []xml.Attr) {
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrIndex {
+			val, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil || val >= uint64(len(d.mesh.Triangles)) {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+				return
+			}
+			d.set.Indices = append(d.set.Indices, uint32(val))
+		}
+	}
+}
+
+type triangleRefRangeDecoder struct {
+	baseDecoder
+	set  *TriangleSet
+	mesh *Mesh
+}
+
+func (d *triangleRefRangeDecoder) Start(attrs []xml.Attr) {
+	var (
+		start, end uint64
+		err        error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrStartIndex:
+			start, err = strconv.ParseUint(a.Value, 10, 32)
+		case attrEndIndex:
+			end, err = strconv.ParseUint(a.Value, 10, 32)
+		default:
+			continue
+		}
+		if err != nil {
+			d.Scanner.InvalidAttr(a.Name.Local, true)
+			return
+		}
+	}
+	// The triangles are decoded before the triangle sets,
+	// so the range can be bounded to avoid expanding huge ranges.
+	if end < start || end >= uint64(len(d.mesh.Triangles)) {
+		d.Scanner.InvalidAttr(attrEndIndex, true)
+		return
+	}
+	for i := start; i <= end; i++ {
+		d.set.Indices = append(d.set.Indices, uint32(i))
+	}
+}
+
 type verticesDecoder struct {
 	baseDecoder
 	mesh          *Mesh
@@ -432,10 +609,16 @@ func (d *objectDecoder) Start(attrs []xml.Attr) {
 			d.parseCoreAttr(a)
 		} else if ext, ok := d.Scanner.extensionDecoder[a.Name.Space]; ok {
 			ext.DecodeAttribute(d.Scanner, &d.resource, a)
+		} else {
+			d.Scanner.addUnknownAttr(&d.resource.AnyAttr, a)
 		}
 	}
 }
 
+func (d *objectDecoder) anyMarshalers() *Marshalers {
+	return &d.resource.Any
+}
+
 func (d *objectDecoder) Child(name xml.Name) (child NodeDecoder) {
 	if name.Space == Namespace {
 		if name.Local == attrMesh {
@@ -445,6 +628,8 @@ func (d *objectDecoder) Child(name xml.Name) (child NodeDecoder) {
 		} else if name.Local == attrMetadataGroup {
 			child = &metadataGroupDecoder{metadatas: &d.resource.Metadata}
 		}
+	} else if ext, ok := d.Scanner.extensionDecoder[name.Space]; ok {
+		child = ext.NewNodeDecoder(&d.resource, name.Local)
 	}
 	return
 }
@@ -495,6 +680,10 @@ func (d *componentsDecoder) Start(_ []xml.Attr) {
 	d.componentDecoder.resource = d.resource
 }
 
+func (d *componentsDecoder) anyMarshalers() *Marshalers {
+	return &d.resource.ComponentsAny
+}
+
 func (d *componentsDecoder) Child(name xml.Name) (child NodeDecoder) {
 	if name.Space == Namespace && name.Local == attrComponent {
 		child = &d.componentDecoder
@@ -526,7 +715,61 @@ func (d *componentDecoder) Start(attrs []xml.Attr) {
 			}
 		} else if ext, ok := d.Scanner.extensionDecoder[a.Name.Space]; ok {
 			ext.DecodeAttribute(d.Scanner, &component, a)
+		} else {
+			d.Scanner.addUnknownAttr(&component.AnyAttr, a)
 		}
 	}
 	d.resource.Components = append(d.resource.Components, &component)
 }
+
+// anyHolder is implemented by the decoders whose node
+// can store elements of unknown extensions.
+type anyHolder interface {
+	anyMarshalers() *Marshalers
+}
+
+// unknownDecoder stores the tokens of an element
+// that does not belong to any registered extension.
+type unknownDecoder struct {
+	baseDecoder
+	name   xml.Name
+	tokens *UnknownTokens
+}
+
+// newUnknownDecoder returns a decoder that appends the unknown element
+// to the nearest ancestor implementing anyHolder.
+// Returns nil if there is no such ancestor.
+func newUnknownDecoder(ancestors []NodeDecoder, name xml.Name) NodeDecoder {
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if h, ok := ancestors[i].(anyHolder); ok {
+			if m := h.anyMarshalers(); m != nil {
+				tokens := new(UnknownTokens)
+				*m = append(*m, tokens)
+				return &unknownDecoder{name: name, tokens: tokens}
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func (d *unknownDecoder) Start(attrs []xml.Attr) {
+	start := xml.StartElement{Name: d.name}
+	if len(attrs) > 0 {
+		start.Attr = make([]xml.Attr, len(attrs))
+		copy(start.Attr, attrs)
+	}
+	*d.tokens = append(*d.tokens, start)
+}
+
+func (d *unknownDecoder) Text(txt []byte) {
+	*d.tokens = append(*d.tokens, xml.CharData(txt).Copy())
+}
+
+func (d *unknownDecoder) Child(name xml.Name) NodeDecoder {
+	return &unknownDecoder{name: name, tokens: d.tokens}
+}
+
+func (d *unknownDecoder) End() {
+	*d.tokens = append(*d.tokens, xml.EndElement{Name: d.name})
+}
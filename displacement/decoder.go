@@ -0,0 +1,394 @@
+package displacement
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/qmuntal/go3mf"
+)
+
+func (e Spec) OnDecoded(_ *go3mf.Model) error {
+	return nil
+}
+
+func (e Spec) NewNodeDecoder(parentNode interface{}, nodeName string) (child go3mf.NodeDecoder) {
+	switch nodeName {
+	case attrDisplacement2D:
+		child = new(displacement2DDecoder)
+	case attrNormVectorGroup:
+		child = new(normVectorGroupDecoder)
+	case attrDisp2DGroup:
+		child = new(disp2DGroupDecoder)
+	case attrDisplacementMesh:
+		if obj, ok := parentNode.(*go3mf.Object); ok {
+			child = &displacementMeshDecoder{object: obj}
+		}
+	}
+	return
+}
+
+func (e Spec) DecodeAttribute(_ *go3mf.Scanner, _ interface{}, _ xml.Attr) {}
+
+type displacement2DDecoder struct {
+	baseDecoder
+	resource Displacement2D
+}
+
+func (d *displacement2DDecoder) End() {
+	d.Scanner.AddAsset(&d.resource)
+}
+
+func (d *displacement2DDecoder) Start(attrs []xml.Attr) {
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		var ok bool
+		switch a.Name.Local {
+		case attrID:
+			id, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			d.resource.ID, d.Scanner.ResourceID = uint32(id), uint32(id)
+		case attrPath:
+			d.resource.Path = a.Value
+		case attrChannel:
+			d.resource.Channel, ok = newChannel(a.Value)
+			if !ok {
+				d.Scanner.InvalidAttr(a.Name.Local, false)
+			}
+		case attrTileStyleU:
+			d.resource.TileStyleU, ok = newTileStyle(a.Value)
+			if !ok {
+				d.Scanner.InvalidAttr(a.Name.Local, false)
+			}
+		case attrTileStyleV:
+			d.resource.TileStyleV, ok = newTileStyle(a.Value)
+			if !ok {
+				d.Scanner.InvalidAttr(a.Name.Local, false)
+			}
+		case attrFilter:
+			d.resource.Filter, ok = newTextureFilter(a.Value)
+			if !ok {
+				d.Scanner.InvalidAttr(a.Name.Local, false)
+			}
+		}
+	}
+}
+
+type normVectorGroupDecoder struct {
+	baseDecoder
+	resource          NormVectorGroup
+	normVectorDecoder normVectorDecoder
+}
+
+func (d *normVectorGroupDecoder) End() {
+	d.Scanner.AddAsset(&d.resource)
+}
+
+func (d *normVectorGroupDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
+	if name.Space == Namespace && name.Local == attrNormVector {
+		child = &d.normVectorDecoder
+	}
+	return
+}
+
+func (d *normVectorGroupDecoder) Start(attrs []xml.Attr) {
+	d.normVectorDecoder.resource = &d.resource
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrID {
+			id, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			d.resource.ID, d.Scanner.ResourceID = uint32(id), uint32(id)
+			break
+		}
+	}
+}
+
+type normVectorDecoder struct {
+	baseDecoder
+	resource *NormVectorGroup
+}
+
+func (d *normVectorDecoder) Start(attrs []xml.Attr) {
+	d.resource.Vectors = append(d.resource.Vectors, parsePoint3D(d.Scanner, attrs))
+}
+
+type disp2DGroupDecoder struct {
+	baseDecoder
+	resource           Disp2DGroup
+	disp2DCoordDecoder disp2DCoordDecoder
+}
+
+func (d *disp2DGroupDecoder) End() {
+	d.Scanner.AddAsset(&d.resource)
+}
+
+func (d *disp2DGroupDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
+	if name.Space == Namespace && name.Local == attrDisp2DCoord {
+		child = &d.disp2DCoordDecoder
+	}
+	return
+}
+
+func (d *disp2DGroupDecoder) Start(attrs []xml.Attr) {
+	d.disp2DCoordDecoder.resource = &d.resource
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID:
+			id, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			d.resource.ID, d.Scanner.ResourceID = uint32(id), uint32(id)
+		case attrDispID:
+			val, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			d.resource.DispID = uint32(val)
+		case attrNID:
+			val, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			d.resource.NID = uint32(val)
+		case attrHeight:
+			val, err := strconv.ParseFloat(a.Value, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			d.resource.Height = float32(val)
+		case attrOffset:
+			val, err := strconv.ParseFloat(a.Value, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, false)
+			}
+			d.resource.Offset = float32(val)
+		}
+	}
+}
+
+type disp2DCoordDecoder struct {
+	baseDecoder
+	resource *Disp2DGroup
+}
+
+func (d *disp2DCoordDecoder) Start(attrs []xml.Attr) {
+	coord := Disp2DCoord{F: 1}
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrU, attrV, attrF:
+			val, err := strconv.ParseFloat(a.Value, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, a.Name.Local != attrF)
+			}
+			switch a.Name.Local {
+			case attrU:
+				coord.U = float32(val)
+			case attrV:
+				coord.V = float32(val)
+			default:
+				coord.F = float32(val)
+			}
+		case attrN:
+			val, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			coord.N = uint32(val)
+		}
+	}
+	d.resource.Coords = append(d.resource.Coords, coord)
+}
+
+type displacementMeshDecoder struct {
+	baseDecoder
+	object *go3mf.Object
+	mesh   *DisplacementMesh
+}
+
+func (d *displacementMeshDecoder) Start(_ []xml.Attr) {
+	d.mesh = new(DisplacementMesh)
+	d.object.Any = append(d.object.Any, d.mesh)
+}
+
+func (d *displacementMeshDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
+	if name.Space == Namespace {
+		if name.Local == attrVertices {
+			child = &verticesDecoder{mesh: d.mesh}
+		} else if name.Local == attrTriangles {
+			child = &trianglesDecoder{mesh: d.mesh, object: d.object}
+		}
+	}
+	return
+}
+
+type verticesDecoder struct {
+	baseDecoder
+	mesh          *DisplacementMesh
+	vertexDecoder vertexDecoder
+}
+
+func (d *verticesDecoder) Start(_ []xml.Attr) {
+	d.vertexDecoder.mesh = d.mesh
+}
+
+func (d *verticesDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
+	if name.Space == Namespace && name.Local == attrVertex {
+		child = &d.vertexDecoder
+	}
+	return
+}
+
+type vertexDecoder struct {
+	baseDecoder
+	mesh *DisplacementMesh
+}
+
+func (d *vertexDecoder) Start(attrs []xml.Attr) {
+	d.mesh.Vertices = append(d.mesh.Vertices, parsePoint3D(d.Scanner, attrs))
+}
+
+type trianglesDecoder struct {
+	baseDecoder
+	mesh            *DisplacementMesh
+	object          *go3mf.Object
+	triangleDecoder triangleDecoder
+}
+
+func (d *trianglesDecoder) Start(attrs []xml.Attr) {
+	d.triangleDecoder.mesh = d.mesh
+	d.triangleDecoder.defaultPropertyID = d.object.PID
+	d.triangleDecoder.defaultPropertyIndex = d.object.PIndex
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrDID {
+			val, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			d.mesh.DID = uint32(val)
+			break
+		}
+	}
+}
+
+func (d *trianglesDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
+	if name.Space == Namespace && name.Local == attrTriangle {
+		child = &d.triangleDecoder
+	}
+	return
+}
+
+type triangleDecoder struct {
+	baseDecoder
+	mesh                                    *DisplacementMesh
+	defaultPropertyIndex, defaultPropertyID uint32
+}
+
+func (d *triangleDecoder) Start(attrs []xml.Attr) {
+	var t Triangle
+	var hasD1, hasD2, hasD3, hasPID, hasP1, hasP2, hasP3 bool
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		required := true
+		val, err := strconv.ParseUint(a.Value, 10, 32)
+		switch a.Name.Local {
+		case attrV1:
+			t.Indices[0] = uint32(val)
+		case attrV2:
+			t.Indices[1] = uint32(val)
+		case attrV3:
+			t.Indices[2] = uint32(val)
+		case attrD1:
+			t.DIndices[0], hasD1 = uint32(val), true
+		case attrD2:
+			t.DIndices[1], hasD2 = uint32(val), true
+			required = false
+		case attrD3:
+			t.DIndices[2], hasD3 = uint32(val), true
+			required = false
+		case attrDID:
+			t.DID = uint32(val)
+			required = false
+		case attrPID:
+			t.PID, hasPID = uint32(val), true
+			required = false
+		case attrP1:
+			t.PIndices[0], hasP1 = uint32(val), true
+			required = false
+		case attrP2:
+			t.PIndices[1], hasP2 = uint32(val), true
+			required = false
+		case attrP3:
+			t.PIndices[2], hasP3 = uint32(val), true
+			required = false
+		default:
+			continue
+		}
+		if err != nil {
+			d.Scanner.InvalidAttr(a.Name.Local, required)
+		}
+	}
+	if hasD1 {
+		t.DIndices[1] = applyDefault(t.DIndices[1], t.DIndices[0], hasD2)
+		t.DIndices[2] = applyDefault(t.DIndices[2], t.DIndices[0], hasD3)
+	}
+	t.PIndices[0] = applyDefault(t.PIndices[0], d.defaultPropertyIndex, hasP1)
+	t.PIndices[1] = applyDefault(t.PIndices[1], t.PIndices[0], hasP2)
+	t.PIndices[2] = applyDefault(t.PIndices[2], t.PIndices[0], hasP3)
+	t.PID = applyDefault(t.PID, d.defaultPropertyID, hasPID)
+	d.mesh.Triangles = append(d.mesh.Triangles, t)
+}
+
+func applyDefault(val, defVal uint32, noDef bool) uint32 {
+	if noDef {
+		return val
+	}
+	return defVal
+}
+
+func parsePoint3D(s *go3mf.Scanner, attrs []xml.Attr) (p go3mf.Point3D) {
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		var i int
+		switch a.Name.Local {
+		case attrX:
+			i = 0
+		case attrY:
+			i = 1
+		case attrZ:
+			i = 2
+		default:
+			continue
+		}
+		val, err := strconv.ParseFloat(a.Value, 32)
+		if err != nil {
+			s.InvalidAttr(a.Name.Local, true)
+		}
+		p[i] = float32(val)
+	}
+	return
+}
+
+type baseDecoder struct {
+	Scanner *go3mf.Scanner
+}
+
+func (d *baseDecoder) Text([]byte)                      {}
+func (d *baseDecoder) Child(xml.Name) go3mf.NodeDecoder { return nil }
+func (d *baseDecoder) End()                             {}
+func (d *baseDecoder) SetScanner(s *go3mf.Scanner)      { d.Scanner = s }
@@ -0,0 +1,142 @@
+package displacement
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func TestDecode(t *testing.T) {
+	disp := &Displacement2D{ID: 1, Path: "/3D/Textures/disp.png", Channel: ChannelR, TileStyleU: TileClamp, TileStyleV: TileMirror, Filter: TextureFilterLinear}
+	normals := &NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, 1}, {0, 0, -1}}}
+	group := &Disp2DGroup{ID: 3, DispID: 1, NID: 2, Height: 2.5, Offset: 0.5, Coords: []Disp2DCoord{
+		{U: 0.5, V: 0.25, N: 0, F: 1}, {U: 1, V: 0, N: 1, F: 0.5},
+	}}
+	dm := &DisplacementMesh{DID: 3, Vertices: []go3mf.Point3D{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}, {0, 0, 10}}, Triangles: []Triangle{
+		{Indices: [3]uint32{0, 2, 1}, DIndices: [3]uint32{0, 0, 0}, PID: 5, PIndices: [3]uint32{1, 1, 1}},
+		{Indices: [3]uint32{0, 1, 3}, DIndices: [3]uint32{0, 1, 0}, DID: 3, PID: 5, PIndices: [3]uint32{2, 2, 2}},
+		{Indices: [3]uint32{1, 2, 3}, DIndices: [3]uint32{1, 1, 1}, PID: 6, PIndices: [3]uint32{0, 1, 2}},
+	}}
+	want := &go3mf.Model{Path: "/3D/3dmodel.model"}
+	want.Resources.Assets = append(want.Resources.Assets, disp, normals, group)
+	want.Resources.Objects = append(want.Resources.Objects, &go3mf.Object{ID: 4, PID: 5, PIndex: 1, Any: go3mf.Marshalers{dm}})
+	got := new(go3mf.Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := `
+	<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:d="http://schemas.microsoft.com/3dmanufacturing/displacement/2022/07">
+		<resources>
+			<d:displacement2d id="1" path="/3D/Textures/disp.png" channel="R" tilestyleu="clamp" tilestylev="mirror" filter="linear" />
+			<d:normvectorgroup id="2">
+				<d:normvector x="0" y="0" z="1" />
+				<d:normvector x="0" y="0" z="-1" />
+			</d:normvectorgroup>
+			<d:disp2dgroup id="3" dispid="1" nid="2" height="2.5" offset="0.5">
+				<d:disp2dcoord u="0.5" v="0.25" n="0" />
+				<d:disp2dcoord u="1" v="0" n="1" f="0.5" />
+			</d:disp2dgroup>
+			<object id="4" pid="5" pindex="1">
+				<d:displacementmesh>
+					<d:vertices>
+						<d:vertex x="0" y="0" z="0" />
+						<d:vertex x="10" y="0" z="0" />
+						<d:vertex x="0" y="10" z="0" />
+						<d:vertex x="0" y="0" z="10" />
+					</d:vertices>
+					<d:triangles did="3">
+						<d:triangle v1="0" v2="2" v3="1" d1="0" />
+						<d:triangle v1="0" v2="1" v3="3" d1="0" d2="1" d3="0" did="3" p1="2" />
+						<d:triangle v1="1" v2="2" v3="3" d1="1" pid="6" p1="0" p2="1" p3="2" />
+					</d:triangles>
+				</d:displacementmesh>
+			</object>
+		</resources>
+		<build>
+		</build>
+	</model>`
+	t.Run("base", func(t *testing.T) {
+		got.WithSpec(&Spec{LocalName: "d"})
+		want.WithSpec(&Spec{LocalName: "d"})
+		if err := go3mf.UnmarshalModel([]byte(rootFile), got); err != nil {
+			t.Errorf("DecodeRawModel() unexpected error = %v", err)
+			return
+		}
+		if diff := deep.Equal(got, want); diff != nil {
+			t.Errorf("DecodeRawModell() = %v", diff)
+			return
+		}
+	})
+}
+
+func TestDecode_warns(t *testing.T) {
+	want := &errors.List{Errors: []error{
+		&errors.ParseFieldError{Required: true, ResourceID: 0, Name: "id", Context: "model@resources@displacement2d"},
+		&errors.ParseFieldError{Required: false, ResourceID: 0, Name: "channel", Context: "model@resources@displacement2d"},
+		&errors.ParseFieldError{Required: true, ResourceID: 2, Name: "x", Context: "model@resources@normvectorgroup@normvector"},
+		&errors.ParseFieldError{Required: true, ResourceID: 3, Name: "dispid", Context: "model@resources@disp2dgroup"},
+		&errors.ParseFieldError{Required: true, ResourceID: 3, Name: "n", Context: "model@resources@disp2dgroup@disp2dcoord"},
+		&errors.ParseFieldError{Required: false, ResourceID: 3, Name: "f", Context: "model@resources@disp2dgroup@disp2dcoord"},
+		&errors.ParseFieldError{Required: true, ResourceID: 4, Name: "x", Context: "model@resources@object@displacementmesh@vertices@vertex"},
+		&errors.ParseFieldError{Required: true, ResourceID: 4, Name: "did", Context: "model@resources@object@displacementmesh@triangles"},
+		&errors.ParseFieldError{Required: true, ResourceID: 4, Name: "d1", Context: "model@resources@object@displacementmesh@triangles@triangle"},
+		&errors.ParseFieldError{Required: false, ResourceID: 4, Name: "p1", Context: "model@resources@object@displacementmesh@triangles@triangle"},
+	}}
+	got := new(go3mf.Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := `
+	<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:d="http://schemas.microsoft.com/3dmanufacturing/displacement/2022/07">
+		<resources>
+			<d:displacement2d id="a" path="/3D/Textures/disp.png" channel="C" qm:mq="other" />
+			<d:normvectorgroup id="2">
+				<d:normvector x="a" y="0" z="1" />
+			</d:normvectorgroup>
+			<d:disp2dgroup id="3" dispid="a" nid="2" height="2.5">
+				<d:disp2dcoord u="0.5" v="0.25" n="a" f="b" />
+			</d:disp2dgroup>
+			<object id="4">
+				<d:displacementmesh>
+					<d:vertices>
+						<d:vertex x="a" y="0" z="0" />
+					</d:vertices>
+					<d:triangles did="a">
+						<d:triangle v1="0" v2="2" v3="1" d1="a" p1="b" />
+					</d:triangles>
+				</d:displacementmesh>
+			</object>
+		</resources>
+		<build>
+		</build>
+	</model>`
+	t.Run("base", func(t *testing.T) {
+		got.WithSpec(&Spec{LocalName: "d"})
+		err := go3mf.UnmarshalModel([]byte(rootFile), got)
+		if diff := deep.Equal(err, want); diff != nil {
+			t.Errorf("UnmarshalModel_warn() = %v", diff)
+			return
+		}
+	})
+}
+
+func Test_baseDecoder_Child(t *testing.T) {
+	type args struct {
+		in0 xml.Name
+	}
+	tests := []struct {
+		name string
+		d    *baseDecoder
+		args args
+		want go3mf.NodeDecoder
+	}{
+		{"base", new(baseDecoder), args{xml.Name{}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Child(tt.args.in0); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("baseDecoder.Child() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
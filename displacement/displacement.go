@@ -0,0 +1,241 @@
+package displacement
+
+import "github.com/qmuntal/go3mf"
+
+const (
+	// Namespace is the canonical name of this extension.
+	Namespace = "http://schemas.microsoft.com/3dmanufacturing/displacement/2022/07"
+	// RelTypeTexture3D is the canonical 3D texture relationship type.
+	RelTypeTexture3D = "http://schemas.microsoft.com/3dmanufacturing/2013/01/3dtexture"
+)
+
+type Spec struct {
+	LocalName  string
+	IsRequired bool
+}
+
+func (e Spec) Namespace() string   { return Namespace }
+func (e Spec) Required() bool      { return e.IsRequired }
+func (e *Spec) SetRequired(r bool) { e.IsRequired = r }
+func (e *Spec) SetLocal(l string)  { e.LocalName = l }
+
+func (e Spec) Local() string {
+	if e.LocalName != "" {
+		return e.LocalName
+	}
+	return "d"
+}
+
+// Channel defines the texture channel that contains the displacement.
+type Channel uint8
+
+// Supported channels.
+const (
+	ChannelG Channel = iota
+	ChannelR
+	ChannelB
+	ChannelA
+)
+
+func (c Channel) String() string {
+	return map[Channel]string{
+		ChannelG: "G",
+		ChannelR: "R",
+		ChannelB: "B",
+		ChannelA: "A",
+	}[c]
+}
+
+// TileStyle defines the allowed tile styles.
+type TileStyle uint8
+
+// Supported tile style.
+const (
+	TileWrap TileStyle = iota
+	TileMirror
+	TileClamp
+	TileNone
+)
+
+func (t TileStyle) String() string {
+	return map[TileStyle]string{
+		TileWrap:   "wrap",
+		TileMirror: "mirror",
+		TileClamp:  "clamp",
+		TileNone:   "none",
+	}[t]
+}
+
+// TextureFilter defines the allowed texture filters.
+type TextureFilter uint8
+
+// Supported texture filters.
+const (
+	TextureFilterAuto TextureFilter = iota
+	TextureFilterLinear
+	TextureFilterNearest
+)
+
+func (t TextureFilter) String() string {
+	return map[TextureFilter]string{
+		TextureFilterAuto:    "auto",
+		TextureFilterLinear:  "linear",
+		TextureFilterNearest: "nearest",
+	}[t]
+}
+
+// Displacement2D defines a PNG texture whose Channel
+// contains the normalized displacement values.
+type Displacement2D struct {
+	ID         uint32
+	Path       string
+	Channel    Channel
+	TileStyleU TileStyle
+	TileStyleV TileStyle
+	Filter     TextureFilter
+}
+
+// Identify returns the unique ID of the resource.
+func (r *Displacement2D) Identify() uint32 {
+	return r.ID
+}
+
+// NormVectorGroup acts as a container for the normal vectors
+// that define the direction of the displacements.
+type NormVectorGroup struct {
+	ID      uint32
+	Vectors []go3mf.Point3D
+}
+
+// Len returns the normal vectors count.
+func (r *NormVectorGroup) Len() int {
+	return len(r.Vectors)
+}
+
+// Identify returns the unique ID of the resource.
+func (r *NormVectorGroup) Identify() uint32 {
+	return r.ID
+}
+
+// Disp2DCoord maps a vertex of a triangle to a position in the displacement texture,
+// a normal vector index and a displacement factor.
+type Disp2DCoord struct {
+	U float32
+	V float32
+	N uint32
+	F float32
+}
+
+// Disp2DGroup acts as a container for the displacement coordinates
+// of a displacement texture, whose values are scaled by Height
+// and shifted by Offset.
+type Disp2DGroup struct {
+	ID     uint32
+	DispID uint32
+	NID    uint32
+	Height float32
+	Offset float32
+	Coords []Disp2DCoord
+}
+
+// Len returns the displacement coordinates count.
+func (r *Disp2DGroup) Len() int {
+	return len(r.Coords)
+}
+
+// Identify returns the unique ID of the resource.
+func (r *Disp2DGroup) Identify() uint32 {
+	return r.ID
+}
+
+// A Triangle is a displacement mesh triangle.
+//
+// DIndices are the indices of the displacement coordinates in the
+// Disp2DGroup DID, or the DisplacementMesh DID if it is zero.
+// PID and PIndices are optional material properties.
+type Triangle struct {
+	Indices  [3]uint32
+	DIndices [3]uint32
+	DID      uint32
+	PID      uint32
+	PIndices [3]uint32
+}
+
+// DisplacementMesh is an object geometry whose triangles
+// are displaced using displacement textures.
+// DID is the default displacement group of the triangles.
+type DisplacementMesh struct {
+	Vertices  []go3mf.Point3D
+	Triangles []Triangle
+	DID       uint32
+}
+
+func newChannel(s string) (c Channel, ok bool) {
+	c, ok = map[string]Channel{
+		"G": ChannelG,
+		"R": ChannelR,
+		"B": ChannelB,
+		"A": ChannelA,
+	}[s]
+	return
+}
+
+func newTextureFilter(s string) (t TextureFilter, ok bool) {
+	t, ok = map[string]TextureFilter{
+		"auto":    TextureFilterAuto,
+		"linear":  TextureFilterLinear,
+		"nearest": TextureFilterNearest,
+	}[s]
+	return
+}
+
+func newTileStyle(s string) (t TileStyle, ok bool) {
+	t, ok = map[string]TileStyle{
+		"wrap":   TileWrap,
+		"mirror": TileMirror,
+		"clamp":  TileClamp,
+		"none":   TileNone,
+	}[s]
+	return
+}
+
+const (
+	attrID               = "id"
+	attrPath             = "path"
+	attrChannel          = "channel"
+	attrTileStyleU       = "tilestyleu"
+	attrTileStyleV       = "tilestylev"
+	attrFilter           = "filter"
+	attrDisplacement2D   = "displacement2d"
+	attrNormVectorGroup  = "normvectorgroup"
+	attrNormVector       = "normvector"
+	attrDisp2DGroup      = "disp2dgroup"
+	attrDisp2DCoord      = "disp2dcoord"
+	attrDispID           = "dispid"
+	attrNID              = "nid"
+	attrHeight           = "height"
+	attrOffset           = "offset"
+	attrU                = "u"
+	attrV                = "v"
+	attrN                = "n"
+	attrF                = "f"
+	attrX                = "x"
+	attrY                = "y"
+	attrZ                = "z"
+	attrDisplacementMesh = "displacementmesh"
+	attrVertices         = "vertices"
+	attrVertex           = "vertex"
+	attrTriangles        = "triangles"
+	attrTriangle         = "triangle"
+	attrV1               = "v1"
+	attrV2               = "v2"
+	attrV3               = "v3"
+	attrD1               = "d1"
+	attrD2               = "d2"
+	attrD3               = "d3"
+	attrDID              = "did"
+	attrPID              = "pid"
+	attrP1               = "p1"
+	attrP2               = "p2"
+	attrP3               = "p3"
+)
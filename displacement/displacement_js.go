@@ -0,0 +1,100 @@
+package displacement
+
+import (
+	"reflect"
+	"syscall/js"
+	"unsafe"
+
+	"github.com/qmuntal/go3mf"
+)
+
+var (
+	jsNS                    = "DISPLACEMENT"
+	arrayConstructor        = js.Global().Get("Array")
+	uint8ArrayConstructor   = js.Global().Get("Uint8Array")
+	float32ArrayConstructor = js.Global().Get("Float32Array")
+	jsSpec                  = go3mf.RegisterClass(jsNS, "X")
+	jsDisplacement2D        = go3mf.RegisterClass("Displacement2D", "X", jsNS)
+	jsNormVectorGroup       = go3mf.RegisterClass("NormVectorGroup", "X", jsNS)
+	jsDisp2DGroup           = go3mf.RegisterClass("Disp2DGroup", "X", jsNS)
+	jsDisp2DCoord           = go3mf.RegisterClass("Disp2DCoord", "X", jsNS)
+	jsDisplacementMesh      = go3mf.RegisterClass("DisplacementMesh", "X", jsNS)
+)
+
+// JSValue returns a JavaScript value associated with the object.
+func (r *Displacement2D) JSValue() js.Value {
+	v := jsDisplacement2D.New()
+	v.Set(attrID, r.ID)
+	v.Set(attrPath, r.Path)
+	v.Set(attrChannel, r.Channel.String())
+	v.Set("tileStyleU", r.TileStyleU.String())
+	v.Set("tileStyleV", r.TileStyleV.String())
+	v.Set(attrFilter, r.Filter.String())
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+//
+// Vectors are encoded as a dense Float32Array where each vector
+// is defined with three elements as follows: x-y-z.
+func (r *NormVectorGroup) JSValue() js.Value {
+	v := jsNormVectorGroup.New()
+	v.Set(attrID, r.ID)
+	v.Set("vectors", float32Array(&r.Vectors))
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+func (c Disp2DCoord) JSValue() js.Value {
+	v := jsDisp2DCoord.New()
+	v.Set(attrU, c.U)
+	v.Set(attrV, c.V)
+	v.Set(attrN, c.N)
+	v.Set(attrF, c.F)
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+func (r *Disp2DGroup) JSValue() js.Value {
+	v := jsDisp2DGroup.New()
+	v.Set(attrID, r.ID)
+	v.Set("dispId", r.DispID)
+	v.Set("nId", r.NID)
+	v.Set(attrHeight, r.Height)
+	v.Set(attrOffset, r.Offset)
+	arr := arrayConstructor.New(len(r.Coords))
+	for i, c := range r.Coords {
+		arr.SetIndex(i, c)
+	}
+	v.Set("coords", arr)
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+//
+// Vertices are encoded as a dense Float32Array where each vertex
+// is defined with three elements as follows: x-y-z.
+// Triangles are encoded as a sparce Uint32Array where each triangle
+// is defined with eleven elements as follows: v1-v2-v3-d1-d2-d3-did-pid-p1-p2-p3.
+func (m *DisplacementMesh) JSValue() js.Value {
+	v := jsDisplacementMesh.New()
+	v.Set(attrVertices, float32Array(&m.Vertices))
+
+	ht := (*reflect.SliceHeader)(unsafe.Pointer(&m.Triangles))
+	ht.Len *= 11 * 4
+	ht.Cap *= 11 * 4
+	triangles := uint8ArrayConstructor.New(ht.Len)
+	js.CopyBytesToJS(triangles, *(*[]byte)(unsafe.Pointer(ht)))
+	v.Set(attrTriangles, triangles)
+	v.Set("did", m.DID)
+	return v
+}
+
+func float32Array(points *[]go3mf.Point3D) js.Value {
+	hv := (*reflect.SliceHeader)(unsafe.Pointer(points))
+	hv.Len *= 3 * 4
+	hv.Cap *= 3 * 4
+	b := uint8ArrayConstructor.New(hv.Len)
+	js.CopyBytesToJS(b, *(*[]byte)(unsafe.Pointer(hv)))
+	return float32ArrayConstructor.New(b.Get("buffer"), b.Get("byteOffset"), b.Get("byteLength").Int()/4)
+}
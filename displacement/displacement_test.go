@@ -0,0 +1,170 @@
+package displacement
+
+import (
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+)
+
+var _ go3mf.SpecDecoder = new(Spec)
+var _ go3mf.SpecValidator = new(Spec)
+var _ go3mf.Asset = new(Displacement2D)
+var _ go3mf.Asset = new(NormVectorGroup)
+var _ go3mf.Asset = new(Disp2DGroup)
+var _ go3mf.Marshaler = new(Displacement2D)
+var _ go3mf.Marshaler = new(NormVectorGroup)
+var _ go3mf.Marshaler = new(Disp2DGroup)
+var _ go3mf.Marshaler = new(DisplacementMesh)
+var _ go3mf.PropertyGroup = new(NormVectorGroup)
+var _ go3mf.PropertyGroup = new(Disp2DGroup)
+
+func TestDisplacement2D_Identify(t *testing.T) {
+	if got := (&Displacement2D{ID: 1}).Identify(); got != 1 {
+		t.Errorf("Displacement2D.Identify() = %v, want %v", got, 1)
+	}
+}
+
+func TestNormVectorGroup_Identify(t *testing.T) {
+	if got := (&NormVectorGroup{ID: 2}).Identify(); got != 2 {
+		t.Errorf("NormVectorGroup.Identify() = %v, want %v", got, 2)
+	}
+}
+
+func TestDisp2DGroup_Identify(t *testing.T) {
+	if got := (&Disp2DGroup{ID: 3}).Identify(); got != 3 {
+		t.Errorf("Disp2DGroup.Identify() = %v, want %v", got, 3)
+	}
+}
+
+func TestNormVectorGroup_Len(t *testing.T) {
+	if got := (&NormVectorGroup{Vectors: make([]go3mf.Point3D, 3)}).Len(); got != 3 {
+		t.Errorf("NormVectorGroup.Len() = %v, want %v", got, 3)
+	}
+}
+
+func TestDisp2DGroup_Len(t *testing.T) {
+	if got := (&Disp2DGroup{Coords: make([]Disp2DCoord, 2)}).Len(); got != 2 {
+		t.Errorf("Disp2DGroup.Len() = %v, want %v", got, 2)
+	}
+}
+
+func TestChannel_String(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Channel
+	}{
+		{"G", ChannelG},
+		{"R", ChannelR},
+		{"B", ChannelB},
+		{"A", ChannelA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.String(); got != tt.name {
+				t.Errorf("Channel.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func TestTileStyle_String(t *testing.T) {
+	tests := []struct {
+		name string
+		t    TileStyle
+	}{
+		{"wrap", TileWrap},
+		{"mirror", TileMirror},
+		{"clamp", TileClamp},
+		{"none", TileNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.String(); got != tt.name {
+				t.Errorf("TileStyle.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func TestTextureFilter_String(t *testing.T) {
+	tests := []struct {
+		name string
+		t    TextureFilter
+	}{
+		{"auto", TextureFilterAuto},
+		{"linear", TextureFilterLinear},
+		{"nearest", TextureFilterNearest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.String(); got != tt.name {
+				t.Errorf("TextureFilter.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func Test_newChannel(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   Channel
+		wantOk bool
+	}{
+		{"G", ChannelG, true},
+		{"R", ChannelR, true},
+		{"B", ChannelB, true},
+		{"A", ChannelA, true},
+		{"empty", ChannelG, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := newChannel(tt.name)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("newChannel() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_newTileStyle(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   TileStyle
+		wantOk bool
+	}{
+		{"wrap", TileWrap, true},
+		{"mirror", TileMirror, true},
+		{"clamp", TileClamp, true},
+		{"none", TileNone, true},
+		{"empty", TileWrap, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := newTileStyle(tt.name)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("newTileStyle() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_newTextureFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   TextureFilter
+		wantOk bool
+	}{
+		{"auto", TextureFilterAuto, true},
+		{"linear", TextureFilterLinear, true},
+		{"nearest", TextureFilterNearest, true},
+		{"empty", TextureFilterAuto, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := newTextureFilter(tt.name)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("newTextureFilter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
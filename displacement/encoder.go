@@ -0,0 +1,141 @@
+package displacement
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// Marshal3MF encodes the resource.
+func (r *Displacement2D) Marshal3MF(x *go3mf.XMLEncoder) error {
+	x.AddRelationship(go3mf.Relationship{Path: r.Path, Type: RelTypeTexture3D})
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrDisplacement2D}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrPath}, Value: r.Path},
+	}}
+	if r.Channel != ChannelG {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrChannel}, Value: r.Channel.String()})
+	}
+	if r.TileStyleU != TileWrap {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTileStyleU}, Value: r.TileStyleU.String()})
+	}
+	if r.TileStyleV != TileWrap {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTileStyleV}, Value: r.TileStyleV.String()})
+	}
+	if r.Filter != TextureFilterAuto {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrFilter}, Value: r.Filter.String()})
+	}
+	x.SetAutoClose(true)
+	x.EncodeToken(xs)
+	x.SetAutoClose(false)
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *NormVectorGroup) Marshal3MF(x *go3mf.XMLEncoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrNormVectorGroup}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	for _, n := range r.Vectors {
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrNormVector}, Attr: point3DAttrs(x, n)})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *Disp2DGroup) Marshal3MF(x *go3mf.XMLEncoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrDisp2DGroup}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrDispID}, Value: strconv.FormatUint(uint64(r.DispID), 10)},
+		{Name: xml.Name{Local: attrNID}, Value: strconv.FormatUint(uint64(r.NID), 10)},
+		{Name: xml.Name{Local: attrHeight}, Value: strconv.FormatFloat(float64(r.Height), 'f', x.FloatPresicion(), 32)},
+	}}
+	if r.Offset != 0 {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrOffset}, Value: strconv.FormatFloat(float64(r.Offset), 'f', x.FloatPresicion(), 32)})
+	}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	for _, c := range r.Coords {
+		xc := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrDisp2DCoord}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrU}, Value: strconv.FormatFloat(float64(c.U), 'f', x.FloatPresicion(), 32)},
+			{Name: xml.Name{Local: attrV}, Value: strconv.FormatFloat(float64(c.V), 'f', x.FloatPresicion(), 32)},
+			{Name: xml.Name{Local: attrN}, Value: strconv.FormatUint(uint64(c.N), 10)},
+		}}
+		if c.F != 1 {
+			xc.Attr = append(xc.Attr, xml.Attr{Name: xml.Name{Local: attrF}, Value: strconv.FormatFloat(float64(c.F), 'f', x.FloatPresicion(), 32)})
+		}
+		x.EncodeToken(xc)
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (m *DisplacementMesh) Marshal3MF(x *go3mf.XMLEncoder) error {
+	xm := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrDisplacementMesh}}
+	x.EncodeToken(xm)
+	xvs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrVertices}}
+	x.EncodeToken(xvs)
+	x.SetAutoClose(true)
+	for _, v := range m.Vertices {
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrVertex}, Attr: point3DAttrs(x, v)})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xvs.End())
+
+	xts := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrTriangles}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrDID}, Value: strconv.FormatUint(uint64(m.DID), 10)},
+	}}
+	x.EncodeToken(xts)
+	x.SetAutoClose(true)
+	for _, t := range m.Triangles {
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrTriangle}, Attr: triangleAttrs(t)})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xts.End())
+	x.EncodeToken(xm.End())
+	return nil
+}
+
+func triangleAttrs(t Triangle) []xml.Attr {
+	attrs := []xml.Attr{
+		{Name: xml.Name{Local: attrV1}, Value: strconv.FormatUint(uint64(t.Indices[0]), 10)},
+		{Name: xml.Name{Local: attrV2}, Value: strconv.FormatUint(uint64(t.Indices[1]), 10)},
+		{Name: xml.Name{Local: attrV3}, Value: strconv.FormatUint(uint64(t.Indices[2]), 10)},
+		{Name: xml.Name{Local: attrD1}, Value: strconv.FormatUint(uint64(t.DIndices[0]), 10)},
+	}
+	if t.DIndices[1] != t.DIndices[0] || t.DIndices[2] != t.DIndices[0] {
+		attrs = append(attrs,
+			xml.Attr{Name: xml.Name{Local: attrD2}, Value: strconv.FormatUint(uint64(t.DIndices[1]), 10)},
+			xml.Attr{Name: xml.Name{Local: attrD3}, Value: strconv.FormatUint(uint64(t.DIndices[2]), 10)},
+		)
+	}
+	if t.DID != 0 {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: attrDID}, Value: strconv.FormatUint(uint64(t.DID), 10)})
+	}
+	if t.PID != 0 {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: attrPID}, Value: strconv.FormatUint(uint64(t.PID), 10)})
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: attrP1}, Value: strconv.FormatUint(uint64(t.PIndices[0]), 10)})
+		if t.PIndices[1] != t.PIndices[0] || t.PIndices[2] != t.PIndices[0] {
+			attrs = append(attrs,
+				xml.Attr{Name: xml.Name{Local: attrP2}, Value: strconv.FormatUint(uint64(t.PIndices[1]), 10)},
+				xml.Attr{Name: xml.Name{Local: attrP3}, Value: strconv.FormatUint(uint64(t.PIndices[2]), 10)},
+			)
+		}
+	}
+	return attrs
+}
+
+func point3DAttrs(x *go3mf.XMLEncoder, p go3mf.Point3D) []xml.Attr {
+	return []xml.Attr{
+		{Name: xml.Name{Local: attrX}, Value: strconv.FormatFloat(float64(p.X()), 'f', x.FloatPresicion(), 32)},
+		{Name: xml.Name{Local: attrY}, Value: strconv.FormatFloat(float64(p.Y()), 'f', x.FloatPresicion(), 32)},
+		{Name: xml.Name{Local: attrZ}, Value: strconv.FormatFloat(float64(p.Z()), 'f', x.FloatPresicion(), 32)},
+	}
+}
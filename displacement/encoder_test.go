@@ -0,0 +1,43 @@
+package displacement
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+func TestMarshalModel(t *testing.T) {
+	disp := &Displacement2D{ID: 1, Path: "/3D/Textures/disp.png", Channel: ChannelA, TileStyleU: TileNone, TileStyleV: TileMirror, Filter: TextureFilterNearest}
+	normals := &NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, 1}, {0, 0, -1}}}
+	group := &Disp2DGroup{ID: 3, DispID: 1, NID: 2, Height: 2.5, Offset: 0.5, Coords: []Disp2DCoord{
+		{U: 0.5, V: 0.25, N: 0, F: 1}, {U: 1, V: 0, N: 1, F: 0.5},
+	}}
+	dm := &DisplacementMesh{DID: 3, Vertices: []go3mf.Point3D{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}, {0, 0, 10}}, Triangles: []Triangle{
+		{Indices: [3]uint32{0, 2, 1}, DIndices: [3]uint32{0, 0, 0}},
+		{Indices: [3]uint32{0, 1, 3}, DIndices: [3]uint32{0, 1, 0}, DID: 3, PID: 5, PIndices: [3]uint32{2, 2, 2}},
+		{Indices: [3]uint32{1, 2, 3}, DIndices: [3]uint32{1, 1, 1}, PID: 5, PIndices: [3]uint32{0, 1, 2}},
+	}}
+	m := &go3mf.Model{Path: "/3D/3dmodel.model"}
+	m.Resources.Assets = append(m.Resources.Assets, disp, normals, group)
+	m.Resources.Objects = append(m.Resources.Objects, &go3mf.Object{ID: 4, Any: go3mf.Marshalers{dm}})
+
+	t.Run("base", func(t *testing.T) {
+		m.WithSpec(&Spec{LocalName: "d"})
+		b, err := go3mf.MarshalModel(m)
+		if err != nil {
+			t.Errorf("displacement.MarshalModel() error = %v", err)
+			return
+		}
+		newModel := new(go3mf.Model)
+		newModel.WithSpec(&Spec{LocalName: "d"})
+		newModel.Path = m.Path
+		if err := go3mf.UnmarshalModel(b, newModel); err != nil {
+			t.Errorf("displacement.MarshalModel() error decoding = %v, s = %s", err, string(b))
+			return
+		}
+		if diff := deep.Equal(m, newModel); diff != nil {
+			t.Errorf("displacement.MarshalModel() = %v, s = %s", diff, string(b))
+		}
+	})
+}
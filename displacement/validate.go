@@ -0,0 +1,198 @@
+package displacement
+
+import (
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func (e *Spec) ValidateModel(_ *go3mf.Model) error {
+	return nil
+}
+
+func (e *Spec) ValidateAsset(m *go3mf.Model, path string, r go3mf.Asset) (errs error) {
+	switch r := r.(type) {
+	case *Displacement2D:
+		errs = e.validateDisplacement2D(m, path, r)
+	case *NormVectorGroup:
+		errs = e.validateNormVectorGroup(path, r)
+	case *Disp2DGroup:
+		errs = e.validateDisp2DGroup(m, path, r)
+	}
+	return
+}
+
+func (e *Spec) ValidateObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
+	var dm *DisplacementMesh
+	if !obj.Any.Get(&dm) {
+		return nil
+	}
+
+	var errs error
+	if len(dm.Vertices) < 3 {
+		errs = errors.Append(errs, errors.ErrInsufficientVertices)
+	}
+	if len(dm.Triangles) < 4 {
+		errs = errors.Append(errs, errors.ErrInsufficientTriangles)
+	}
+	defaultGroup, err := e.findDisp2DGroup(m, path, dm.DID)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+
+	for i, t := range dm.Triangles {
+		i0, i1, i2 := t.Indices[0], t.Indices[1], t.Indices[2]
+		if i0 == i1 || i0 == i2 || i1 == i2 {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrDuplicatedIndices, t, i))
+			continue
+		}
+		l := uint32(len(dm.Vertices))
+		if i0 >= l || i1 >= l || i2 >= l {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, t, i))
+			continue
+		}
+		group := defaultGroup
+		if t.DID != 0 {
+			if group, err = e.findDisp2DGroup(m, path, t.DID); err != nil {
+				errs = errors.Append(errs, errors.WrapIndex(err, t, i))
+			}
+		}
+		if t.PID != 0 {
+			if _, ok := m.FindAsset(path, t.PID); !ok {
+				errs = errors.Append(errs, errors.WrapIndex(errors.ErrMissingResource, t, i))
+			}
+		}
+		if group == nil {
+			continue
+		}
+		if t.DIndices[0] >= uint32(len(group.Coords)) || t.DIndices[1] >= uint32(len(group.Coords)) || t.DIndices[2] >= uint32(len(group.Coords)) {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, t, i))
+			continue
+		}
+		if err := e.validateOrientation(m, path, dm, group, t); err != nil {
+			errs = errors.Append(errs, errors.WrapIndex(err, t, i))
+		}
+	}
+	if errs != nil {
+		errs = errors.Wrap(errs, dm)
+	}
+	if obj.Mesh != nil || len(obj.Components) > 0 {
+		errs = errors.Append(errs, errors.ErrDisplacementMeshObject)
+	}
+	return errs
+}
+
+func (e *Spec) findDisp2DGroup(m *go3mf.Model, path string, id uint32) (*Disp2DGroup, error) {
+	if id == 0 {
+		return nil, errors.NewMissingFieldError(attrDID)
+	}
+	if r, ok := m.FindAsset(path, id); ok {
+		if group, ok := r.(*Disp2DGroup); ok {
+			return group, nil
+		}
+	}
+	return nil, errors.ErrDisp2DGroupReference
+}
+
+func (e *Spec) validateOrientation(m *go3mf.Model, path string, dm *DisplacementMesh, group *Disp2DGroup, t Triangle) error {
+	r, ok := m.FindAsset(path, group.NID)
+	if !ok {
+		return nil
+	}
+	normals, ok := r.(*NormVectorGroup)
+	if !ok {
+		return nil
+	}
+	v0 := dm.Vertices[t.Indices[0]]
+	n := dm.Vertices[t.Indices[1]].Sub(v0).Cross(dm.Vertices[t.Indices[2]].Sub(v0))
+	for _, idx := range t.DIndices {
+		ni := group.Coords[idx].N
+		if int(ni) >= len(normals.Vectors) {
+			continue
+		}
+		nv := normals.Vectors[ni]
+		if nv.X()*n.X()+nv.Y()*n.Y()+nv.Z()*n.Z() <= 0 {
+			return errors.ErrNormVectorOrientation
+		}
+	}
+	return nil
+}
+
+func (e *Spec) validateDisplacement2D(m *go3mf.Model, path string, r *Displacement2D) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.Path == "" {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrPath))
+	} else {
+		var (
+			hasTexture bool
+			isPNG      bool
+		)
+		for _, a := range m.Attachments {
+			if strings.EqualFold(a.Path, r.Path) {
+				hasTexture = true
+				isPNG = a.ContentType == "image/png"
+				break
+			}
+		}
+		if !hasTexture {
+			errs = errors.Append(errs, errors.ErrMissingTexturePart)
+		} else if !isPNG {
+			errs = errors.Append(errs, errors.ErrDisplacementPNG)
+		}
+	}
+	return
+}
+
+func (e *Spec) validateNormVectorGroup(path string, r *NormVectorGroup) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if len(r.Vectors) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	for j, n := range r.Vectors {
+		if n.Len() == 0 {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrNormVectorZero, n, j))
+		}
+	}
+	return
+}
+
+func (e *Spec) validateDisp2DGroup(m *go3mf.Model, path string, r *Disp2DGroup) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.DispID == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrDispID))
+	} else if disp, ok := m.FindAsset(path, r.DispID); ok {
+		if _, ok := disp.(*Displacement2D); !ok {
+			errs = errors.Append(errs, errors.ErrDisplacementReference)
+		}
+	} else {
+		errs = errors.Append(errs, errors.ErrDisplacementReference)
+	}
+	var normals *NormVectorGroup
+	if r.NID == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrNID))
+	} else if nr, ok := m.FindAsset(path, r.NID); ok {
+		if normals, ok = nr.(*NormVectorGroup); !ok {
+			errs = errors.Append(errs, errors.ErrNormVectorReference)
+		}
+	} else {
+		errs = errors.Append(errs, errors.ErrNormVectorReference)
+	}
+	if len(r.Coords) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	if normals != nil {
+		for j, c := range r.Coords {
+			if int(c.N) >= len(normals.Vectors) {
+				errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, c, j))
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,113 @@
+package displacement
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func TestValidate(t *testing.T) {
+	tetrahedron := func(did uint32, triangles ...Triangle) *DisplacementMesh {
+		return &DisplacementMesh{DID: did, Vertices: []go3mf.Point3D{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}, {0, 0, 10}}, Triangles: append([]Triangle{
+			{Indices: [3]uint32{0, 2, 1}, DIndices: [3]uint32{0, 0, 0}},
+			{Indices: [3]uint32{0, 1, 3}, DIndices: [3]uint32{1, 1, 1}},
+			{Indices: [3]uint32{0, 3, 2}, DIndices: [3]uint32{2, 2, 2}},
+			{Indices: [3]uint32{1, 2, 3}, DIndices: [3]uint32{3, 3, 3}},
+		}, triangles...)}
+	}
+	validAssets := []go3mf.Asset{
+		&Displacement2D{ID: 1, Path: "/disp.png"},
+		&NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, -1}, {0, -1, 0}, {-1, 0, 0}, {1, 1, 1}}},
+		&Disp2DGroup{ID: 3, DispID: 1, NID: 2, Height: 1, Coords: []Disp2DCoord{{N: 0, F: 1}, {N: 1, F: 1}, {N: 2, F: 1}, {N: 3, F: 1}}},
+	}
+	tests := []struct {
+		name  string
+		model *go3mf.Model
+		want  []error
+	}{
+		{"child", &go3mf.Model{Childs: map[string]*go3mf.ChildModel{
+			"/other.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&NormVectorGroup{ID: 1},
+			}}},
+		}}, []error{
+			fmt.Errorf("/other.model@Resources@NormVectorGroup#0: %v", errors.ErrEmptyResourceProps),
+		}},
+		{"displacement2d", &go3mf.Model{
+			Attachments: []go3mf.Attachment{{Path: "/a.png", ContentType: "image/png"}, {Path: "/b.jpg", ContentType: "image/jpeg"}},
+			Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&Displacement2D{},
+				&Displacement2D{ID: 2, Path: "/a.png"},
+				&Displacement2D{ID: 3, Path: "/b.jpg"},
+				&Displacement2D{ID: 4, Path: "/c.png"},
+			}},
+		}, []error{
+			fmt.Errorf("Resources@Displacement2D#0: %v", errors.ErrMissingID),
+			fmt.Errorf("Resources@Displacement2D#0: %v", &errors.MissingFieldError{Name: attrPath}),
+			fmt.Errorf("Resources@Displacement2D#2: %v", errors.ErrDisplacementPNG),
+			fmt.Errorf("Resources@Displacement2D#3: %v", errors.ErrMissingTexturePart),
+		}},
+		{"normvectorgroup", &go3mf.Model{
+			Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&NormVectorGroup{ID: 1, Vectors: []go3mf.Point3D{{0, 0, 1}, {}}},
+			}},
+		}, []error{
+			fmt.Errorf("Resources@NormVectorGroup#0@Point3D#1: %v", errors.ErrNormVectorZero),
+		}},
+		{"disp2dgroup", &go3mf.Model{
+			Attachments: []go3mf.Attachment{{Path: "/disp.png", ContentType: "image/png"}},
+			Resources: go3mf.Resources{Assets: append(validAssets[:3:3],
+				&Disp2DGroup{ID: 4},
+				&Disp2DGroup{ID: 5, DispID: 2, NID: 1, Coords: []Disp2DCoord{{}}},
+				&Disp2DGroup{ID: 6, DispID: 100, NID: 100, Coords: []Disp2DCoord{{}}},
+				&Disp2DGroup{ID: 7, DispID: 1, NID: 2, Coords: []Disp2DCoord{{N: 1}, {N: 4}}},
+			)},
+		}, []error{
+			fmt.Errorf("Resources@Disp2DGroup#3: %v", &errors.MissingFieldError{Name: attrDispID}),
+			fmt.Errorf("Resources@Disp2DGroup#3: %v", &errors.MissingFieldError{Name: attrNID}),
+			fmt.Errorf("Resources@Disp2DGroup#3: %v", errors.ErrEmptyResourceProps),
+			fmt.Errorf("Resources@Disp2DGroup#4: %v", errors.ErrDisplacementReference),
+			fmt.Errorf("Resources@Disp2DGroup#4: %v", errors.ErrNormVectorReference),
+			fmt.Errorf("Resources@Disp2DGroup#5: %v", errors.ErrDisplacementReference),
+			fmt.Errorf("Resources@Disp2DGroup#5: %v", errors.ErrNormVectorReference),
+			fmt.Errorf("Resources@Disp2DGroup#6@Disp2DCoord#1: %v", errors.ErrIndexOutOfBounds),
+		}},
+		{"displacementmesh", &go3mf.Model{
+			Attachments: []go3mf.Attachment{{Path: "/disp.png", ContentType: "image/png"}},
+			Resources: go3mf.Resources{Assets: validAssets, Objects: []*go3mf.Object{
+				{ID: 4, Any: go3mf.Marshalers{tetrahedron(3)}},
+				{ID: 5, Any: go3mf.Marshalers{&DisplacementMesh{}}},
+				{ID: 6, Any: go3mf.Marshalers{tetrahedron(2,
+					Triangle{Indices: [3]uint32{0, 0, 1}, DID: 3},
+					Triangle{Indices: [3]uint32{0, 1, 4}, DID: 3},
+					Triangle{Indices: [3]uint32{1, 2, 3}, DIndices: [3]uint32{0, 5, 0}, DID: 3},
+					Triangle{Indices: [3]uint32{1, 2, 3}, DIndices: [3]uint32{3, 3, 3}, DID: 3, PID: 100},
+					Triangle{Indices: [3]uint32{1, 3, 2}, DIndices: [3]uint32{3, 3, 3}, DID: 3},
+				)}},
+				{ID: 7, Components: []*go3mf.Component{{ObjectID: 4}}, Any: go3mf.Marshalers{tetrahedron(3)}},
+			}},
+		}, []error{
+			fmt.Errorf("Resources@Object#1@DisplacementMesh: %v", errors.ErrInsufficientVertices),
+			fmt.Errorf("Resources@Object#1@DisplacementMesh: %v", errors.ErrInsufficientTriangles),
+			fmt.Errorf("Resources@Object#1@DisplacementMesh: %v", &errors.MissingFieldError{Name: attrDID}),
+			fmt.Errorf("Resources@Object#2@DisplacementMesh: %v", errors.ErrDisp2DGroupReference),
+			fmt.Errorf("Resources@Object#2@DisplacementMesh@Triangle#4: %v", errors.ErrDuplicatedIndices),
+			fmt.Errorf("Resources@Object#2@DisplacementMesh@Triangle#5: %v", errors.ErrIndexOutOfBounds),
+			fmt.Errorf("Resources@Object#2@DisplacementMesh@Triangle#6: %v", errors.ErrIndexOutOfBounds),
+			fmt.Errorf("Resources@Object#2@DisplacementMesh@Triangle#7: %v", errors.ErrMissingResource),
+			fmt.Errorf("Resources@Object#2@DisplacementMesh@Triangle#8: %v", errors.ErrNormVectorOrientation),
+			fmt.Errorf("Resources@Object#3: %v", errors.ErrDisplacementMeshObject),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.model.WithSpec(&Spec{})
+			got := tt.model.Validate()
+			if diff := deep.Equal(got.(*errors.List).Errors, tt.want); diff != nil {
+				t.Errorf("Validate() = %v", diff)
+			}
+		})
+	}
+}
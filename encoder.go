@@ -3,8 +3,11 @@ package go3mf
 import (
 	"bufio"
 	"bytes"
+	"crypto"
+	"crypto/x509"
 	"encoding/xml"
 	"io"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -102,8 +105,16 @@ func MarshalModel(m *Model) ([]byte, error) {
 // An Encoder writes Model data to an output stream.
 //
 // See the documentation for strconv.FormatFloat for details about the FloatPrecision behaviour.
+// If Encrypter is not nil it is used to encrypt the written parts.
+//
+// If Signer is not nil the package is signed with an OPC digital signature
+// that embeds Certificate. Preserved signatures whose signed parts and relationships
+// are written unchanged are kept, the other ones are removed.
 type Encoder struct {
 	FloatPrecision int
+	Encrypter      PartEncrypter
+	Signer         crypto.Signer
+	Certificate    *x509.Certificate
 	w              packageWriter
 }
 
@@ -117,13 +128,33 @@ func NewEncoder(w io.Writer) *Encoder {
 
 // Encode writes the XML encoding of m to the stream.
 func (e *Encoder) Encode(m *Model) error {
-	if err := e.writeAttachements(m.Attachments); err != nil {
+	signatures, attachments := splitSignatures(m.Attachments)
+	if e.Signer != nil || len(signatures) > 0 {
+		sw, err := newSignedWriter(e.w, e.Signer, e.Certificate, signatures)
+		if err != nil {
+			return err
+		}
+		w := e.w
+		e.w = sw
+		defer func() { e.w = w }()
+	}
+	if e.Encrypter != nil {
+		w := e.w
+		e.w = &encryptedWriter{packageWriter: w, enc: e.Encrypter}
+		defer func() { e.w = w }()
+	}
+	if err := e.writeAttachements(attachments); err != nil {
+		return err
+	}
+	if err := e.writePrintTicket(m.PrintTicket, DefaultPrintTicketName); err != nil {
 		return err
 	}
 	rootName := m.PathOrDefault()
 	e.w.AddRelationship(Relationship{Type: RelType3DModel, Path: rootName})
 	for _, r := range m.RootRelationships {
-		e.w.AddRelationship(r)
+		if r.Type != RelTypeSignatureOrigin {
+			e.w.AddRelationship(r)
+		}
 	}
 
 	w, err := e.w.Create(rootName, ContentType3DModel)
@@ -139,6 +170,9 @@ func (e *Encoder) Encode(m *Model) error {
 	for path := range m.Childs {
 		enc.AddRelationship(Relationship{Type: RelType3DModel, Path: path})
 	}
+	if m.PrintTicket != nil {
+		enc.AddRelationship(Relationship{Type: RelTypePrintTicket, Path: DefaultPrintTicketName})
+	}
 	if err = e.writeModel(enc, m); err != nil {
 		return err
 	}
@@ -158,6 +192,10 @@ func (e *Encoder) writeChildModels(m *Model) error {
 			w   packagePart
 			err error
 		)
+		ticketName := printTicketName(path)
+		if err = e.writePrintTicket(child.PrintTicket, ticketName); err != nil {
+			return err
+		}
 		if w, err = e.w.Create(path, ContentType3DModel); err != nil {
 			return err
 		}
@@ -166,6 +204,9 @@ func (e *Encoder) writeChildModels(m *Model) error {
 		}
 		enc := newXMLEncoder(w, e.FloatPrecision)
 		enc.relationships = child.Relationships
+		if child.PrintTicket != nil {
+			enc.AddRelationship(Relationship{Type: RelTypePrintTicket, Path: ticketName})
+		}
 		if err = e.writeChildModel(enc, m, path); err != nil {
 			return err
 		}
@@ -176,6 +217,27 @@ func (e *Encoder) writeChildModels(m *Model) error {
 	return nil
 }
 
+func (e *Encoder) writePrintTicket(ticket *PrintTicket, name string) error {
+	if ticket == nil {
+		return nil
+	}
+	w, err := e.w.Create(name, ContentTypePrintTicket)
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write([]byte(xml.Header)); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(ticket)
+}
+
+// printTicketName returns the print ticket part name of a non-root model part.
+func printTicketName(modelPath string) string {
+	base := path.Base(modelPath)
+	base = strings.TrimSuffix(base, path.Ext(base))
+	return path.Join(path.Dir(modelPath), "Metadata", base+"_PT.xml")
+}
+
 func (e *Encoder) writeAttachements(att []Attachment) error {
 	for _, a := range att {
 		w, err := e.w.Create(a.Path, a.ContentType)
@@ -185,11 +247,14 @@ func (e *Encoder) writeAttachements(att []Attachment) error {
 		if err != nil {
 			return err
 		}
+		for _, r := range a.Relationships {
+			w.AddRelationship(r)
+		}
 	}
 	return nil
 }
 
-func (e *Encoder) modelToken(x *XMLEncoder, m *Model, isRoot bool) (xml.StartElement, error) {
+func (e *Encoder) modelToken(x *XMLEncoder, m *Model, rs *Resources, isRoot bool) (xml.StartElement, error) {
 	attrs := []xml.Attr{
 		{Name: xml.Name{Local: attrXmlns}, Value: Namespace},
 		{Name: xml.Name{Local: attrUnit}, Value: m.Units.String()},
@@ -204,6 +269,9 @@ func (e *Encoder) modelToken(x *XMLEncoder, m *Model, isRoot bool) (xml.StartEle
 		a := m.Specs[ns]
 		attrs = append(attrs, xml.Attr{Name: xml.Name{Space: attrXmlns, Local: a.Local()}, Value: a.Namespace()})
 	}
+	if _, ok := m.Specs[NamespaceTriangleSets]; !ok && rs.hasTriangleSets() {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Space: attrXmlns, Local: "t"}, Value: NamespaceTriangleSets})
+	}
 	var exts []string
 	for _, ns := range sortedSpecs {
 		a := m.Specs[ns]
@@ -216,15 +284,18 @@ func (e *Encoder) modelToken(x *XMLEncoder, m *Model, isRoot bool) (xml.StartEle
 		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: attrReqExt}, Value: strings.Join(exts, " ")})
 	}
 	tm := xml.StartElement{Name: xml.Name{Local: attrModel}, Attr: attrs}
-	m.AnyAttr.encode(x, &tm)
+	if isRoot {
+		m.AnyAttr.encode(x, &tm)
+	}
 	return tm, nil
 }
 
 func (e *Encoder) writeChildModel(x *XMLEncoder, m *Model, path string) error {
-	tm, _ := e.modelToken(x, m, false) // error already checked before
+	child := m.Childs[path]
+	tm, _ := e.modelToken(x, m, &child.Resources, false) // error already checked before
+	child.AnyAttr.encode(x, &tm)
 	x.EncodeToken(tm)
 
-	child := m.Childs[path]
 	if err := e.writeResources(x, &child.Resources); err != nil {
 		return err
 	}
@@ -238,7 +309,7 @@ func (e *Encoder) writeChildModel(x *XMLEncoder, m *Model, path string) error {
 }
 
 func (e *Encoder) writeModel(x *XMLEncoder, m *Model) error {
-	tm, err := e.modelToken(x, m, true)
+	tm, err := e.modelToken(x, m, &m.Resources, true)
 	if err != nil {
 		return err
 	}
@@ -281,10 +352,13 @@ func (e *Encoder) writeBuild(x *XMLEncoder, m *Model) {
 			})
 		}
 		item.AnyAttr.encode(x, &xi)
-		if len(item.Metadata) != 0 {
+		if len(item.Metadata) != 0 || len(item.Any) != 0 {
 			x.SetAutoClose(false)
 			x.EncodeToken(xi)
-			e.writeMetadataGroup(x, item.Metadata)
+			if len(item.Metadata) != 0 {
+				e.writeMetadataGroup(x, item.Metadata)
+			}
+			item.Any.encode(x)
 			x.EncodeToken(xi.End())
 			x.SetAutoClose(true)
 		} else {
@@ -292,11 +366,13 @@ func (e *Encoder) writeBuild(x *XMLEncoder, m *Model) {
 		}
 	}
 	x.SetAutoClose(false)
+	m.Build.Any.encode(x)
 	x.EncodeToken(xb.End())
 }
 
 func (e *Encoder) writeResources(x *XMLEncoder, rs *Resources) error {
 	xt := xml.StartElement{Name: xml.Name{Local: attrResources}}
+	rs.AnyAttr.encode(x, &xt)
 	x.EncodeToken(xt)
 	for _, r := range rs.Assets {
 		switch r := r.(type) {
@@ -318,6 +394,7 @@ func (e *Encoder) writeResources(x *XMLEncoder, rs *Resources) error {
 			return err
 		}
 	}
+	rs.Any.encode(x)
 	x.EncodeToken(xt.End())
 	return nil
 }
@@ -364,7 +441,7 @@ func (e *Encoder) writeObject(x *XMLEncoder, r *Object) {
 	if r.Name != "" {
 		xo.Attr = append(xo.Attr, xml.Attr{Name: xml.Name{Local: attrName}, Value: r.Name})
 	}
-	if r.Mesh != nil {
+	if len(r.Components) == 0 {
 		if r.PID != 0 {
 			xo.Attr = append(xo.Attr, xml.Attr{
 				Name: xml.Name{Local: attrPID}, Value: strconv.FormatUint(uint64(r.PID), 10),
@@ -385,13 +462,14 @@ func (e *Encoder) writeObject(x *XMLEncoder, r *Object) {
 
 	if r.Mesh != nil {
 		e.writeMesh(x, r, r.Mesh)
-	} else {
-		e.writeComponents(x, r.Components)
+	} else if len(r.Components) > 0 || len(r.ComponentsAny) > 0 || len(r.Any) == 0 {
+		e.writeComponents(x, r.Components, r.ComponentsAny)
 	}
+	r.Any.encode(x)
 	x.EncodeToken(xo.End())
 }
 
-func (e *Encoder) writeComponents(x *XMLEncoder, comps []*Component) {
+func (e *Encoder) writeComponents(x *XMLEncoder, comps []*Component, ext Marshalers) {
 	xcs := xml.StartElement{Name: xml.Name{Local: attrComponents}}
 	x.EncodeToken(xcs)
 	x.SetAutoClose(true)
@@ -408,6 +486,7 @@ func (e *Encoder) writeComponents(x *XMLEncoder, comps []*Component) {
 		x.EncodeToken(xt)
 	}
 	x.SetAutoClose(false)
+	ext.encode(x)
 	x.EncodeToken(xcs.End())
 }
 
@@ -465,14 +544,62 @@ func (e *Encoder) writeMesh(x *XMLEncoder, r *Object, m *Mesh) {
 	}
 	x.SetAutoClose(false)
 	x.EncodeToken(xvt.End())
+	if len(m.TriangleSets) > 0 {
+		e.writeTriangleSets(x, m.TriangleSets)
+	}
 	m.Any.encode(x)
 	x.EncodeToken(xm.End())
 }
 
+// hasTriangleSets reports whether any mesh of rs has triangle sets,
+// which require the triangle sets namespace to be declared in the model.
+func (rs *Resources) hasTriangleSets() bool {
+	for _, o := range rs.Objects {
+		if o.Mesh != nil && len(o.Mesh.TriangleSets) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Encoder) writeTriangleSets(x *XMLEncoder, sets []TriangleSet) {
+	xts := xml.StartElement{Name: xml.Name{Space: NamespaceTriangleSets, Local: attrTriangleSets}}
+	x.EncodeToken(xts)
+	for _, set := range sets {
+		xt := xml.StartElement{Name: xml.Name{Space: NamespaceTriangleSets, Local: attrTriangleSet}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: set.Name},
+			{Name: xml.Name{Local: attrIdentifier}, Value: set.Identifier},
+		}}
+		x.EncodeToken(xt)
+		x.SetAutoClose(true)
+		for i := 0; i < len(set.Indices); {
+			j := i
+			for j+1 < len(set.Indices) && set.Indices[j+1] == set.Indices[j]+1 {
+				j++
+			}
+			if j > i {
+				x.EncodeToken(xml.StartElement{Name: xml.Name{Space: NamespaceTriangleSets, Local: attrRefRange}, Attr: []xml.Attr{
+					{Name: xml.Name{Local: attrStartIndex}, Value: strconv.FormatUint(uint64(set.Indices[i]), 10)},
+					{Name: xml.Name{Local: attrEndIndex}, Value: strconv.FormatUint(uint64(set.Indices[j]), 10)},
+				}})
+			} else {
+				x.EncodeToken(xml.StartElement{Name: xml.Name{Space: NamespaceTriangleSets, Local: attrRef}, Attr: []xml.Attr{
+					{Name: xml.Name{Local: attrIndex}, Value: strconv.FormatUint(uint64(set.Indices[i]), 10)},
+				}})
+			}
+			i = j + 1
+		}
+		x.SetAutoClose(false)
+		x.EncodeToken(xt.End())
+	}
+	x.EncodeToken(xts.End())
+}
+
 func (e *Encoder) writeBaseMaterial(x *XMLEncoder, r *BaseMaterials) {
 	xt := xml.StartElement{Name: xml.Name{Local: attrBaseMaterials}, Attr: []xml.Attr{
 		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
 	}}
+	r.AnyAttr.encode(x, &xt)
 	x.EncodeToken(xt)
 	x.SetAutoClose(true)
 	for _, ma := range r.Materials {
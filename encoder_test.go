@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"image/color"
+	"io/ioutil"
 	"reflect"
 	"strconv"
 	"testing"
@@ -76,6 +77,10 @@ func TestMarshalModel(t *testing.T) {
 							NewTrianglePID(3, 0, 4, 5, 0, 0, 0),
 							NewTrianglePID(4, 7, 3, 5, 0, 0, 0),
 						},
+						TriangleSets: []TriangleSet{
+							{Name: "Top", Identifier: "top", Indices: []uint32{2, 3}},
+							{Name: "Mixed", Identifier: "mixed", Indices: []uint32{0, 4, 5, 6, 9, 11}},
+						},
 					}},
 				{
 					ID: 20, Type: ObjectTypeSupport,
@@ -293,3 +298,141 @@ func TestEncoder_Encode_Roundtrip(t *testing.T) {
 		})
 	}
 }
+
+func TestEncoder_Encode_PreserveUnknown(t *testing.T) {
+	const nsVendor = "http://vendor.com/ext"
+	vname := xml.Name{Space: nsVendor, Local: "data"}
+	m := &Model{
+		Path:    DefaultModelPath,
+		Specs:   map[string]Spec{nsVendor: &UnknownSpec{SpaceName: nsVendor, LocalName: "v"}},
+		AnyAttr: AttrMarshalers{&UnknownAttrs{{Name: xml.Name{Space: nsVendor, Local: "model"}, Value: "1"}}},
+		Any: Marshalers{
+			&UnknownTokens{xml.StartElement{Name: vname}, xml.CharData("a&b"), xml.EndElement{Name: vname}},
+			&UnknownTokens{xml.StartElement{Name: vname, Attr: []xml.Attr{{Name: xml.Name{Local: "id"}, Value: "2"}}}, xml.EndElement{Name: vname}},
+		},
+		Resources: Resources{
+			Any:     Marshalers{&UnknownTokens{xml.StartElement{Name: vname}, xml.EndElement{Name: vname}}},
+			AnyAttr: AttrMarshalers{&UnknownAttrs{{Name: xml.Name{Space: nsVendor, Local: "resources"}, Value: "4"}}},
+			Objects: []*Object{{
+				ID: 1, Any: Marshalers{&UnknownTokens{xml.StartElement{Name: vname}, xml.EndElement{Name: vname}}},
+				AnyAttr: AttrMarshalers{&UnknownAttrs{{Name: xml.Name{Space: nsVendor, Local: "object"}, Value: "2"}}},
+				Mesh: &Mesh{
+					Any:     Marshalers{&UnknownTokens{xml.StartElement{Name: vname}, xml.EndElement{Name: vname}}},
+					AnyAttr: AttrMarshalers{&UnknownAttrs{{Name: xml.Name{Space: nsVendor, Local: "mesh"}, Value: "3"}}},
+				},
+			}, {
+				ID: 2, Components: []*Component{{
+					ObjectID: 1, AnyAttr: AttrMarshalers{&UnknownAttrs{{Name: xml.Name{Space: nsVendor, Local: "component"}, Value: "5"}}},
+				}},
+				ComponentsAny: Marshalers{&UnknownTokens{xml.StartElement{Name: vname}, xml.EndElement{Name: vname}}},
+			}},
+		},
+		Childs: map[string]*ChildModel{"/3D/other.model": {
+			AnyAttr: AttrMarshalers{&UnknownAttrs{{Name: xml.Name{Space: nsVendor, Local: "child"}, Value: "6"}}},
+			Any:     Marshalers{&UnknownTokens{xml.StartElement{Name: vname}, xml.EndElement{Name: vname}}},
+			Resources: Resources{
+				Any: Marshalers{&UnknownTokens{xml.StartElement{Name: vname}, xml.EndElement{Name: vname}}},
+			},
+		}},
+		Build: Build{
+			Any: Marshalers{&UnknownTokens{xml.StartElement{Name: vname}, xml.EndElement{Name: vname}}},
+			Items: []*Item{{
+				ObjectID: 1, Any: Marshalers{&UnknownTokens{xml.StartElement{Name: vname}, xml.EndElement{Name: vname}}},
+			}},
+		},
+	}
+	buff := new(bytes.Buffer)
+	if err := NewEncoder(buff).Encode(m); err != nil {
+		t.Errorf("Encoder.Encode() error = %v", err)
+		return
+	}
+	newModel := new(Model)
+	d := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+	d.PreserveUnknown = true
+	if err := d.Decode(newModel); err != nil {
+		t.Errorf("Encoder.Encode() malformed = %v", err)
+		return
+	}
+	if diff := deep.Equal(newModel, m); diff != nil {
+		t.Errorf("Encoder.Encode() = %v", diff)
+	}
+}
+
+func TestEncoder_Encode_PreservedParts(t *testing.T) {
+	m := &Model{
+		Path:              DefaultModelPath,
+		RootRelationships: []Relationship{{Path: "/Metadata/a.bin", Type: RelTypeMustPreserve}},
+		Attachments: []Attachment{
+			{Path: "/Metadata/a.bin", ContentType: "application/a", Stream: bytes.NewBufferString("a"),
+				Relationships: []Relationship{{ID: "1", Path: "/Metadata/b.bin", Type: "fake_type"}}},
+			{Path: "/Metadata/b.bin", ContentType: "application/b", Stream: bytes.NewBufferString("b")},
+			{Path: "/Metadata/c.bin", ContentType: "application/c", Stream: bytes.NewBufferString("c")},
+		},
+	}
+	buff := new(bytes.Buffer)
+	if err := NewEncoder(buff).Encode(m); err != nil {
+		t.Errorf("Encoder.Encode() error = %v", err)
+		return
+	}
+	for _, unreferenced := range []bool{false, true} {
+		newModel := new(Model)
+		d := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+		d.PreserveUnreferenced = unreferenced
+		if err := d.Decode(newModel); err != nil {
+			t.Errorf("Encoder.Encode() malformed = %v", err)
+			return
+		}
+		want := m.Attachments
+		if !unreferenced {
+			want = want[:2]
+		}
+		if len(newModel.Attachments) != len(want) {
+			t.Errorf("Encoder.Encode() attachments = %v, want %v", len(newModel.Attachments), len(want))
+			continue
+		}
+		for i, a := range newModel.Attachments {
+			content, _ := ioutil.ReadAll(a.Stream)
+			if string(content) != want[i].Path[len(want[i].Path)-5:len(want[i].Path)-4] {
+				t.Errorf("Encoder.Encode() attachment %s content = %s", a.Path, content)
+			}
+			a.Stream, want[i].Stream = nil, nil
+			if diff := deep.Equal(a, want[i]); diff != nil {
+				t.Errorf("Encoder.Encode() = %v", diff)
+			}
+		}
+	}
+}
+
+func TestEncoder_Encode_PrintTicket(t *testing.T) {
+	m := &Model{
+		Path:        DefaultModelPath,
+		PrintTicket: testPrintTicketModel(),
+		Childs: map[string]*ChildModel{
+			"/3D/other.model": {PrintTicket: &PrintTicket{Version: 1, Parameters: []*PrintParameter{
+				{Name: PrintJobCopies, Value: PrintValue{Type: PrintValueInteger, Text: "1"}},
+			}}},
+		},
+	}
+	buff := new(bytes.Buffer)
+	if err := NewEncoder(buff).Encode(m); err != nil {
+		t.Errorf("Encoder.Encode() error = %v", err)
+		return
+	}
+	newModel := new(Model)
+	d := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+	if err := d.Decode(newModel); err != nil {
+		t.Errorf("Encoder.Encode() malformed = %v", err)
+		return
+	}
+	if diff := deep.Equal(newModel, m); diff != nil {
+		t.Errorf("Encoder.Encode() = %v", diff)
+	}
+	newModel = new(Model)
+	if err := d.Decode(newModel); err != nil {
+		t.Errorf("Decoder.Decode() reused error = %v", err)
+		return
+	}
+	if diff := deep.Equal(newModel, m); diff != nil {
+		t.Errorf("Decoder.Decode() reused = %v", diff)
+	}
+}
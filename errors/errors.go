@@ -33,6 +33,7 @@ var (
 	ErrRecursion              = errors.New("MUST NOT contain recursive references")
 	ErrInvalidObject          = errors.New("MUST contain a mesh or components")
 	ErrMeshConsistency        = errors.New("mesh has non-manifold edges without consistent triangle orientation")
+	ErrTriangleSetIdentifier  = errors.New("triangle set identifiers MUST be unique within a mesh")
 	// materials
 	ErrMultiBlend         = errors.New("there MUST NOT be more blendmethods than layers – 1")
 	ErrMaterialMulti      = errors.New("a material, if included, MUST be positioned as the first layer")
@@ -41,6 +42,8 @@ var (
 	ErrTextureReference   = errors.New("MUST reference to a texture resource")
 	ErrCompositeBase      = errors.New("MUST reference to a basematerials group")
 	ErrMissingTexturePart = errors.New("texture part MUST be added as an attachment")
+	ErrDisplayPropsRef    = errors.New("displaypropertiesid MUST reference a display properties resource")
+	ErrDisplayPropsCount  = errors.New("display properties MUST contain as many elements as the referencing group")
 	// production
 	ErrUUID             = errors.New("UUID MUST be any of the four UUID variants described in IETF RFC 4122")
 	ErrProdExtRequired  = errors.New("a 3MF package which uses referenced objects MUST enlist the production extension as required")
@@ -58,12 +61,31 @@ var (
 	ErrSliceInsufficientSegments = errors.New("slice polygon MUST contain at least 1 segment")
 	ErrSlicePolygonNotClosed     = errors.New("objects with type 'model' and 'solidsupport' MUST not reference slices with open polygons")
 	ErrSliceInvalidTranform      = errors.New("any transform applied to an object that references a slice stack MUST be planar")
+	ErrSliceDuplicatedVertex     = errors.New("slice vertices SHOULD be unique")
+	ErrSliceZeroLengthSegment    = errors.New("slice polygon segments SHOULD NOT have zero length")
+	ErrSliceSelfIntersection     = errors.New("slice polygons SHOULD NOT self-intersect")
+	ErrSlicePolygonIntersection  = errors.New("slice polygons SHOULD NOT cross or overlap other polygons")
 	// beamlattice
-	ErrLatticeObjType       = errors.New("MUST only be added to a mesh object of type model or solidsupport")
-	ErrLatticeClippedNoMesh = errors.New("if clipping mode is not equal to none, a clippingmesh resource MUST be specified")
-	ErrLatticeInvalidMesh   = errors.New("the clippingmesh and representationmesh MUST be a mesh object of type model and MUST NOT contain a beamlattice")
-	ErrLatticeSameVertex    = errors.New("a beam MUST consist of two distinct vertex indices")
-	ErrLatticeBeamR2        = errors.New("r2 MUST not be defined, if r1 is not defined")
+	ErrLatticeObjType        = errors.New("MUST only be added to a mesh object of type model or solidsupport")
+	ErrLatticeClippedNoMesh  = errors.New("if clipping mode is not equal to none, a clippingmesh resource MUST be specified")
+	ErrLatticeInvalidMesh    = errors.New("the clippingmesh and representationmesh MUST be a mesh object of type model and MUST NOT contain a beamlattice")
+	ErrLatticeSameVertex     = errors.New("a beam MUST consist of two distinct vertex indices")
+	ErrLatticeBeamR2         = errors.New("r2 MUST not be defined, if r1 is not defined")
+	ErrLatticeDuplicatedBall = errors.New("a ball MUST reference a vertex index that is not used by another ball")
+	ErrLatticeBeamTooShort   = errors.New("a beam MUST NOT be shorter than the beam lattice minlength")
+	ErrLatticeRadius         = errors.New("radius MUST be a positive number")
+	// booleanoperations
+	ErrBooleanObject  = errors.New("an object containing a booleanshape MUST NOT contain a mesh or components")
+	ErrBooleanBase    = errors.New("the base object of a booleanshape MUST be an object of type model that defines a shape and MUST NOT contain components")
+	ErrBooleanOperand = errors.New("the operands of a booleanshape MUST reference mesh objects of type model")
+	// displacement
+	ErrDisplacementPNG        = errors.New("displacement texture part MUST be a PNG image")
+	ErrDisplacementReference  = errors.New("dispid MUST reference a displacement2d resource")
+	ErrNormVectorReference    = errors.New("nid MUST reference a normvectorgroup resource")
+	ErrDisp2DGroupReference   = errors.New("did MUST reference a disp2dgroup resource")
+	ErrNormVectorZero         = errors.New("normal vectors MUST NOT have zero length")
+	ErrNormVectorOrientation  = errors.New("displacement normal vectors MUST point to the outer side of the triangle")
+	ErrDisplacementMeshObject = errors.New("an object with a displacementmesh MUST NOT contain a mesh or components")
 )
 
 type Level struct {
@@ -3,6 +3,7 @@ package stl
 import (
 	"bufio"
 	"context"
+	"fmt"
 	"io"
 	"strconv"
 	"strings"
@@ -53,3 +54,37 @@ func (d *asciiDecoder) decode(ctx context.Context, m *go3mf.Mesh) (err error) {
 	}
 	return scanner.Err()
 }
+
+// asciiEncoder can write facets to a ASCII STL.
+type asciiEncoder struct {
+	w io.Writer
+}
+
+func (e *asciiEncoder) encode(ctx context.Context, name string, facets []facet) error {
+	w := bufio.NewWriter(e.w)
+	fmt.Fprintf(w, "solid %s\n", name)
+	nextFaceCheck := checkEveryFaces
+	for i, f := range facets {
+		if i > nextFaceCheck {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default: // Default is must to avoid blocking
+			}
+			nextFaceCheck += checkEveryFaces
+		}
+		fmt.Fprintf(w, "  facet normal %s\n    outer loop\n", formatPoint(f.normal))
+		for _, v := range f.vertices {
+			fmt.Fprintf(w, "      vertex %s\n", formatPoint(v))
+		}
+		fmt.Fprint(w, "    endloop\n  endfacet\n")
+	}
+	fmt.Fprintf(w, "endsolid %s\n", name)
+	return w.Flush()
+}
+
+func formatPoint(p go3mf.Point3D) string {
+	return strconv.FormatFloat(float64(p.X()), 'g', -1, 32) + " " +
+		strconv.FormatFloat(float64(p.Y()), 'g', -1, 32) + " " +
+		strconv.FormatFloat(float64(p.Z()), 'g', -1, 32)
+}
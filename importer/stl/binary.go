@@ -1,22 +1,24 @@
 package stl
 
 import (
+	"bufio"
 	"context"
 	"encoding/binary"
 	"io"
+	"strings"
 
 	"github.com/qmuntal/go3mf"
 )
 
 type binaryHeader struct {
-	_         [80]byte
+	Header    [80]byte
 	FaceCount uint32
 }
 
 type binaryFace struct {
-	Normal   [3]float32
-	Vertices [3][3]float32
-	_        uint16
+	Normal    [3]float32
+	Vertices  [3][3]float32
+	Attribute uint16
 }
 
 // binaryDecoder can create a Mesh from a Read stream that is feeded with a binary STL.
@@ -63,3 +65,64 @@ func (d *binaryDecoder) decodeFace(facet *binaryFace, mb *go3mf.MeshBuilder) {
 	}
 	mb.Mesh.Triangles = append(mb.Mesh.Triangles, go3mf.NewTriangle(nodes[0], nodes[1], nodes[2]))
 }
+
+// magicsDefaultColor is the color of the facets that do not have their own color
+// when the Magics color format is used.
+var magicsDefaultColor = [4]byte{255, 255, 255, 255}
+
+// magicsMarkers returns the Magics header markers, which use the default color
+// for the object and for the diffuse, specular and ambient material colors.
+func magicsMarkers() string {
+	c := string(magicsDefaultColor[:])
+	return "COLOR=" + c + " MATERIAL=" + c + c + c
+}
+
+// binaryEncoder can write facets to a binary STL.
+type binaryEncoder struct {
+	w     io.Writer
+	color ColorFormat
+}
+
+// encode writes the facets with name in the header.
+// Names that start with "solid" are prefixed so the output is not confused with an ASCII STL.
+// The Magics color format writes its markers after the name, which is truncated if they do not fit.
+func (e *binaryEncoder) encode(ctx context.Context, name string, facets []facet) error {
+	w := bufio.NewWriter(e.w)
+	if strings.HasPrefix(strings.ToLower(name), "solid") {
+		name = "binary " + name
+	}
+	if e.color == ColorMagics {
+		markers := magicsMarkers()
+		if max := len(binaryHeader{}.Header) - len(markers) - 1; len(name) > max {
+			name = name[:max]
+		}
+		if name != "" {
+			name += " "
+		}
+		name += markers
+	}
+	header := binaryHeader{FaceCount: uint32(len(facets))}
+	copy(header.Header[:], name)
+	if err := binary.Write(w, binary.LittleEndian, &header); err != nil {
+		return err
+	}
+	nextFaceCheck := checkEveryFaces
+	for i, f := range facets {
+		if i > nextFaceCheck {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default: // Default is must to avoid blocking
+			}
+			nextFaceCheck += checkEveryFaces
+		}
+		face := binaryFace{Normal: f.normal, Attribute: e.color.attribute(f.color, f.hasColor)}
+		for j, v := range f.vertices {
+			face.Vertices[j] = v
+		}
+		if err := binary.Write(w, binary.LittleEndian, &face); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
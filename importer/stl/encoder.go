@@ -0,0 +1,179 @@
+package stl
+
+import (
+	"context"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/materials"
+)
+
+// ColorFormat defines how the facet colors are stored
+// in the attribute of the binary stl facets.
+// Each channel is stored with 5 bits.
+type ColorFormat uint8
+
+// Supported color formats.
+const (
+	// ColorNone does not store the colors.
+	ColorNone ColorFormat = iota
+	// ColorVisCAM follows the VisCAM and SolidView convention:
+	// blue is stored in the bits 0-4, green in 5-9, red in 10-14
+	// and the bit 15 is set if the facet has a color.
+	ColorVisCAM
+	// ColorMagics follows the Materialise Magics convention:
+	// red is stored in the bits 0-4, green in 5-9, blue in 10-14
+	// and the bit 15 is set if the facet does not have its own color.
+	// The header stores the color of the facets that do not have their own color,
+	// which is opaque white, after the COLOR= and MATERIAL= markers.
+	ColorMagics
+)
+
+func (c ColorFormat) attribute(rgba color.RGBA, ok bool) uint16 {
+	switch c {
+	case ColorVisCAM:
+		if ok {
+			return 1<<15 | uint16(rgba.R>>3)<<10 | uint16(rgba.G>>3)<<5 | uint16(rgba.B>>3)
+		}
+	case ColorMagics:
+		if !ok {
+			return 1 << 15
+		}
+		return uint16(rgba.B>>3)<<10 | uint16(rgba.G>>3)<<5 | uint16(rgba.R>>3)
+	}
+	return 0
+}
+
+// Encoder can encode a model as stl.
+// Binary stl is encoded by default, which stores the facet colors
+// resolved from base materials and color groups according to Color.
+// The ASCII encoding does not support colors.
+type Encoder struct {
+	ASCII bool
+	Color ColorFormat
+	w     io.Writer
+}
+
+// NewEncoder creates a new encoder.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		w: w,
+	}
+}
+
+// Encode writes the meshes of the build items as a single solid,
+// applying the item and component transforms.
+// The solid name is the model Title metadata.
+func (e *Encoder) Encode(m *go3mf.Model) error {
+	return e.EncodeContext(context.Background(), m)
+}
+
+// EncodeContext writes the meshes of the build items as a single solid.
+// See Encode for more details.
+func (e *Encoder) EncodeContext(ctx context.Context, m *go3mf.Model) error {
+	f := flattener{m: m, colors: !e.ASCII && e.Color != ColorNone}
+	m.WalkBuild(f.addObject)
+	var name string
+	for _, md := range m.Metadata {
+		if md.Name.Space == "" && md.Name.Local == "Title" {
+			name = md.Value
+		}
+	}
+	return e.encode(ctx, name, f.facets)
+}
+
+// EncodeObject writes obj, which is defined in the model part path,
+// including the meshes of its components with their transforms.
+// The root model path can be empty. The solid name is the object name.
+func (e *Encoder) EncodeObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
+	return e.EncodeObjectContext(context.Background(), m, path, obj)
+}
+
+// EncodeObjectContext writes obj, which is defined in the model part path.
+// See EncodeObject for more details.
+func (e *Encoder) EncodeObjectContext(ctx context.Context, m *go3mf.Model, path string, obj *go3mf.Object) error {
+	f := flattener{m: m, colors: !e.ASCII && e.Color != ColorNone}
+	m.WalkComponents(path, obj, go3mf.Identity(), f.addObject)
+	return e.encode(ctx, obj.Name, f.facets)
+}
+
+func (e *Encoder) encode(ctx context.Context, name string, facets []facet) error {
+	name = strings.Map(func(r rune) rune {
+		if r < ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, name)
+	if e.ASCII {
+		encoder := asciiEncoder{w: e.w}
+		return encoder.encode(ctx, name, facets)
+	}
+	encoder := binaryEncoder{w: e.w, color: e.Color}
+	return encoder.encode(ctx, name, facets)
+}
+
+type facet struct {
+	normal   go3mf.Point3D
+	vertices [3]go3mf.Point3D
+	color    color.RGBA
+	hasColor bool
+}
+
+// flattener collects the transformed triangles of the objects.
+type flattener struct {
+	m      *go3mf.Model
+	colors bool
+	facets []facet
+}
+
+func (f *flattener) addObject(path string, obj *go3mf.Object, transform go3mf.Matrix) error {
+	if obj.Mesh != nil {
+		f.addMesh(path, obj, transform)
+	}
+	return nil
+}
+
+func (f *flattener) addMesh(path string, obj *go3mf.Object, transform go3mf.Matrix) {
+	mesh := obj.Mesh
+	// Mirroring transforms flip the triangles, which are reversed to keep them facing outwards.
+	mirror := transform[0]*(transform[5]*transform[10]-transform[6]*transform[9])-
+		transform[1]*(transform[4]*transform[10]-transform[6]*transform[8])+
+		transform[2]*(transform[4]*transform[9]-transform[5]*transform[8]) < 0
+	colors := materials.NewColorResolver(f.m, path)
+	for _, t := range mesh.Triangles {
+		indices := [3]uint32{}
+		indices[0], indices[1], indices[2] = t.Indices()
+		pid := t.PID()
+		pindices := [3]uint32{}
+		pindices[0], pindices[1], pindices[2] = t.PIndices()
+		if pid == 0 {
+			pid, pindices = obj.PID, [3]uint32{obj.PIndex, obj.PIndex, obj.PIndex}
+		}
+		if mirror {
+			indices[1], indices[2] = indices[2], indices[1]
+			pindices[1], pindices[2] = pindices[2], pindices[1]
+		}
+		if int(indices[0]) >= len(mesh.Vertices) || int(indices[1]) >= len(mesh.Vertices) || int(indices[2]) >= len(mesh.Vertices) {
+			continue
+		}
+		var fc facet
+		for j, idx := range indices {
+			fc.vertices[j] = transform.Mul3D(mesh.Vertices[idx])
+		}
+		// Degenerate triangles do not have a normal, which is written as zero.
+		if n := fc.vertices[1].Sub(fc.vertices[0]).Cross(fc.vertices[2].Sub(fc.vertices[0])); n.Len() != 0 {
+			fc.normal = n.Normalize()
+		}
+		for j := range fc.normal {
+			if fc.normal[j] == 0 {
+				fc.normal[j] = 0 // Avoid writing negative zeros.
+			}
+		}
+		if f.colors {
+			fc.color, fc.hasColor = colors.Average(pid, pindices)
+		}
+		f.facets = append(f.facets, fc)
+	}
+}
@@ -0,0 +1,246 @@
+package stl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/xml"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/materials"
+)
+
+func decodeBinaryFaces(t *testing.T, data []byte) (binaryHeader, []binaryFace) {
+	t.Helper()
+	r := bytes.NewReader(data)
+	var header binaryHeader
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		t.Fatalf("binary.Read() error = %v", err)
+	}
+	faces := make([]binaryFace, header.FaceCount)
+	if err := binary.Read(r, binary.LittleEndian, faces); err != nil {
+		t.Fatalf("binary.Read() error = %v", err)
+	}
+	if r.Len() != 0 {
+		t.Errorf("binary stl has %d trailing bytes", r.Len())
+	}
+	return header, faces
+}
+
+func TestEncoder_EncodeObject_roundTrip(t *testing.T) {
+	for _, ascii := range []bool{false, true} {
+		obj := createMeshTriangle(1)
+		var buf bytes.Buffer
+		e := NewEncoder(&buf)
+		e.ASCII = ascii
+		if err := e.EncodeObject(new(go3mf.Model), "", obj); err != nil {
+			t.Fatalf("Encoder.EncodeObject() ascii %v error = %v", ascii, err)
+		}
+		got := new(go3mf.Model)
+		if err := NewDecoder(&buf).Decode(got); err != nil {
+			t.Fatalf("Decoder.Decode() ascii %v error = %v", ascii, err)
+		}
+		if diff := deep.Equal(got.Resources.Objects, []*go3mf.Object{obj}); diff != nil {
+			t.Errorf("Encoder.EncodeObject() ascii %v = %v", ascii, diff)
+		}
+	}
+}
+
+func TestEncoder_EncodeObject_ascii(t *testing.T) {
+	obj := &go3mf.Object{Name: "my part\nñ", Mesh: &go3mf.Mesh{
+		Vertices:  []go3mf.Point3D{{0, 0, 0}, {1.5, 0, 0}, {0, 2, 0}},
+		Triangles: []go3mf.Triangle{go3mf.NewTriangle(0, 1, 2), go3mf.NewTriangle(0, 1, 5)},
+	}}
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	e.ASCII = true
+	if err := e.EncodeObject(new(go3mf.Model), "", obj); err != nil {
+		t.Fatalf("Encoder.EncodeObject() error = %v", err)
+	}
+	want := `solid my part__
+  facet normal 0 0 1
+    outer loop
+      vertex 0 0 0
+      vertex 1.5 0 0
+      vertex 0 2 0
+    endloop
+  endfacet
+endsolid my part__
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Encoder.EncodeObject() = %v, want %v", got, want)
+	}
+}
+
+func TestEncoder_Encode(t *testing.T) {
+	triangle := &go3mf.Mesh{
+		Vertices:  []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+		Triangles: []go3mf.Triangle{go3mf.NewTriangle(0, 1, 2)},
+	}
+	m := &go3mf.Model{
+		Metadata: []go3mf.Metadata{{Name: xml.Name{Local: "Title"}, Value: "solid build"}},
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Mesh: triangle},
+			{ID: 2, Components: []*go3mf.Component{
+				{ObjectID: 1, Transform: go3mf.Matrix{-1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 5, 1}},
+				{ObjectID: 10},
+			}},
+		}},
+		Build: go3mf.Build{Items: []*go3mf.Item{
+			{ObjectID: 1, Transform: go3mf.Identity().Translate(0, 0, 1)},
+			{ObjectID: 2, Transform: go3mf.Identity().Translate(2, 0, 0)},
+			{ObjectID: 20},
+		}},
+	}
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	header, got := decodeBinaryFaces(t, buf.Bytes())
+	if name := string(bytes.TrimRight(header.Header[:], "\x00")); name != "binary solid build" {
+		t.Errorf("Encoder.Encode() header = %v, want binary solid build", name)
+	}
+	want := []binaryFace{
+		{Normal: [3]float32{0, 0, 1}, Vertices: [3][3]float32{{0, 0, 1}, {1, 0, 1}, {0, 1, 1}}},
+		{Normal: [3]float32{0, 0, 1}, Vertices: [3][3]float32{{2, 0, 5}, {2, 1, 5}, {1, 0, 5}}},
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Encoder.Encode() = %v", diff)
+	}
+}
+
+func TestEncoder_EncodeObject_color(t *testing.T) {
+	m := &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+		&go3mf.BaseMaterials{ID: 1, Materials: []go3mf.Base{{Color: color.RGBA{R: 255, A: 255}}}},
+		&materials.ColorGroup{ID: 2, Colors: []color.RGBA{{R: 255, A: 255}, {G: 255, A: 255}, {B: 255, A: 255}}},
+	}}}
+	obj := &go3mf.Object{PID: 1, Mesh: &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+		Triangles: []go3mf.Triangle{
+			go3mf.NewTriangle(0, 1, 2),
+			go3mf.NewTrianglePID(0, 1, 2, 2, 0, 1, 2),
+			go3mf.NewTrianglePID(0, 1, 2, 2, 0, 1, 5),
+			go3mf.NewTrianglePID(0, 1, 2, 10, 0, 0, 0),
+		},
+	}}
+	tests := []struct {
+		name   string
+		format ColorFormat
+		want   []uint16
+	}{
+		{"none", ColorNone, []uint16{0, 0, 0, 0}},
+		{"viscam", ColorVisCAM, []uint16{0xfc00, 0xa94a, 0, 0}},
+		{"magics", ColorMagics, []uint16{0x001f, 0x294a, 0x8000, 0x8000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			e := NewEncoder(&buf)
+			e.Color = tt.format
+			if err := e.EncodeObject(m, "", obj); err != nil {
+				t.Fatalf("Encoder.EncodeObject() error = %v", err)
+			}
+			_, faces := decodeBinaryFaces(t, buf.Bytes())
+			got := make([]uint16, len(faces))
+			for i, f := range faces {
+				got[i] = f.Attribute
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("Encoder.EncodeObject() = %v", diff)
+			}
+		})
+	}
+}
+
+// decodeMagicsColors reads the facet colors as Magics does,
+// using the header COLOR= marker for the facets without their own color.
+func decodeMagicsColors(t *testing.T, data []byte) []color.RGBA {
+	t.Helper()
+	header, faces := decodeBinaryFaces(t, data)
+	i := bytes.Index(header.Header[:], []byte("COLOR="))
+	if i == -1 || i+10 > len(header.Header) {
+		t.Fatal("binary stl header does not contain the COLOR= marker")
+	}
+	def := color.RGBA{R: header.Header[i+6], G: header.Header[i+7], B: header.Header[i+8], A: header.Header[i+9]}
+	if j := bytes.Index(header.Header[:], []byte("MATERIAL=")); j == -1 || j+21 > len(header.Header) {
+		t.Fatal("binary stl header does not contain the MATERIAL= marker")
+	}
+	expand := func(c uint16) uint8 { return uint8(c<<3 | c>>2) }
+	colors := make([]color.RGBA, len(faces))
+	for k, f := range faces {
+		if f.Attribute&0x8000 != 0 {
+			colors[k] = def
+		} else {
+			colors[k] = color.RGBA{R: expand(f.Attribute & 0x1f), G: expand(f.Attribute >> 5 & 0x1f), B: expand(f.Attribute >> 10 & 0x1f), A: 255}
+		}
+	}
+	return colors
+}
+
+func TestEncoder_EncodeObject_magics(t *testing.T) {
+	m := &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+		&materials.ColorGroup{ID: 1, Colors: []color.RGBA{{R: 255, A: 255}, {B: 255, A: 255}}},
+	}}}
+	obj := &go3mf.Object{Name: strings.Repeat("a", 80), Mesh: &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+		Triangles: []go3mf.Triangle{
+			go3mf.NewTrianglePID(0, 1, 2, 1, 0, 0, 0),
+			go3mf.NewTrianglePID(0, 1, 2, 1, 1, 1, 1),
+			go3mf.NewTriangle(0, 1, 2),
+		},
+	}}
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	e.Color = ColorMagics
+	if err := e.EncodeObject(m, "", obj); err != nil {
+		t.Fatalf("Encoder.EncodeObject() error = %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("aaaa")) {
+		t.Errorf("Encoder.EncodeObject() header = %q, want name prefix", buf.Bytes()[:80])
+	}
+	want := []color.RGBA{{R: 255, A: 255}, {B: 255, A: 255}, {R: 255, G: 255, B: 255, A: 255}}
+	if diff := deep.Equal(decodeMagicsColors(t, buf.Bytes()), want); diff != nil {
+		t.Errorf("Encoder.EncodeObject() = %v", diff)
+	}
+}
+
+func TestEncoder_EncodeObject_degenerate(t *testing.T) {
+	obj := &go3mf.Object{Mesh: &go3mf.Mesh{
+		Vertices:  []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {2, 0, 0}},
+		Triangles: []go3mf.Triangle{go3mf.NewTriangle(0, 1, 2)},
+	}}
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).EncodeObject(new(go3mf.Model), "", obj); err != nil {
+		t.Fatalf("Encoder.EncodeObject() error = %v", err)
+	}
+	_, faces := decodeBinaryFaces(t, buf.Bytes())
+	if got := faces[0].Normal; got != [3]float32{} {
+		t.Errorf("Encoder.EncodeObject() normal = %v, want zero", got)
+	}
+	buf.Reset()
+	e := NewEncoder(&buf)
+	e.ASCII = true
+	if err := e.EncodeObject(new(go3mf.Model), "", obj); err != nil {
+		t.Fatalf("Encoder.EncodeObject() ascii error = %v", err)
+	}
+	if strings.Contains(buf.String(), "NaN") {
+		t.Errorf("Encoder.EncodeObject() ascii = %s, want a zero normal", buf.String())
+	}
+}
+
+func TestEncoder_EncodeObjectContext_cancel(t *testing.T) {
+	checkEveryFaces = 1
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, ascii := range []bool{false, true} {
+		e := NewEncoder(new(bytes.Buffer))
+		e.ASCII = ascii
+		if err := e.EncodeObjectContext(ctx, new(go3mf.Model), "", createMeshTriangle(1)); err != context.Canceled {
+			t.Errorf("Encoder.EncodeObjectContext() ascii %v error = %v, want %v", ascii, err, context.Canceled)
+		}
+	}
+}
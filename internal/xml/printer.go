@@ -47,6 +47,13 @@ func (p *Printer) EscapeString(s string) {
 
 // WriteStart writes the given start element.
 func (p *Printer) WriteStart(start *xml.StartElement) {
+	// Register the name spaces declared in this element
+	// so the element name can use them.
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" {
+			p.createAttrPrefix(&attr)
+		}
+	}
 	p.WriteByte('<')
 	if start.Name.Space != "" {
 		if prefix := p.attrPrefix[start.Name.Space]; prefix != "" {
@@ -0,0 +1,61 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// A ColorResolver resolves the color of the properties of a model part
+// defined by base materials or color groups.
+// The assets are searched once and cached.
+type ColorResolver struct {
+	m      *go3mf.Model
+	path   string
+	assets map[uint32]go3mf.Asset
+}
+
+// NewColorResolver returns a resolver for the properties of the model part path.
+// The root model path can be empty.
+func NewColorResolver(m *go3mf.Model, path string) *ColorResolver {
+	return &ColorResolver{m: m, path: path, assets: make(map[uint32]go3mf.Asset)}
+}
+
+// Color returns the color of the property index of the group pid.
+// Returns false if pid is zero, the group does not exist or does not define colors
+// or index is out of range.
+func (c *ColorResolver) Color(pid, index uint32) (color.RGBA, bool) {
+	if pid == 0 {
+		return color.RGBA{}, false
+	}
+	a, ok := c.assets[pid]
+	if !ok {
+		a, _ = c.m.FindAsset(c.path, pid)
+		c.assets[pid] = a
+	}
+	switch a := a.(type) {
+	case *go3mf.BaseMaterials:
+		if int(index) < len(a.Materials) {
+			return a.Materials[index].Color, true
+		}
+	case *ColorGroup:
+		if int(index) < len(a.Colors) {
+			return a.Colors[index], true
+		}
+	}
+	return color.RGBA{}, false
+}
+
+// Average returns the average color of the properties of the triangle vertices.
+// Returns false if any of them cannot be resolved.
+func (c *ColorResolver) Average(pid uint32, indices [3]uint32) (color.RGBA, bool) {
+	var sum [4]int
+	for _, index := range indices {
+		rgba, ok := c.Color(pid, index)
+		if !ok {
+			return color.RGBA{}, false
+		}
+		sum[0], sum[1], sum[2], sum[3] = sum[0]+int(rgba.R), sum[1]+int(rgba.G), sum[2]+int(rgba.B), sum[3]+int(rgba.A)
+	}
+	return color.RGBA{R: uint8(sum[0] / 3), G: uint8(sum[1] / 3), B: uint8(sum[2] / 3), A: uint8(sum[3] / 3)}, true
+}
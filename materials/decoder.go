@@ -2,6 +2,7 @@ package materials
 
 import (
 	"encoding/xml"
+	"image/color"
 	"strconv"
 	"strings"
 
@@ -24,11 +25,30 @@ func (e Spec) NewNodeDecoder(_ interface{}, nodeName string) (child go3mf.NodeDe
 		child = new(compositeMaterialsDecoder)
 	case attrMultiProps:
 		child = new(multiPropertiesDecoder)
+	case attrPBSpecularProps:
+		child = new(pbSpecularPropsDecoder)
+	case attrPBMetallicProps:
+		child = new(pbMetallicPropsDecoder)
+	case attrPBSpecularTexProps:
+		child = new(pbSpecularTexPropsDecoder)
+	case attrPBMetallicTexProps:
+		child = new(pbMetallicTexPropsDecoder)
+	case attrTranslucentProps:
+		child = new(translucentPropsDecoder)
 	}
 	return
 }
 
-func (e Spec) DecodeAttribute(_ *go3mf.Scanner, _ interface{}, _ xml.Attr) {}
+func (e Spec) DecodeAttribute(s *go3mf.Scanner, parentNode interface{}, attr xml.Attr) {
+	if t, ok := parentNode.(*go3mf.BaseMaterials); ok && attr.Name.Local == attrDisplayPropsID {
+		val, err := strconv.ParseUint(attr.Value, 10, 32)
+		if err != nil {
+			s.InvalidAttr(attr.Name.Local, false)
+		}
+		id := DisplayPropertiesID(val)
+		t.AnyAttr = append(t.AnyAttr, &id)
+	}
+}
 
 type colorGroupDecoder struct {
 	baseDecoder
@@ -50,13 +70,18 @@ func (d *colorGroupDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
 func (d *colorGroupDecoder) Start(attrs []xml.Attr) {
 	d.colorDecoder.resource = &d.resource
 	for _, a := range attrs {
-		if a.Name.Space == "" && a.Name.Local == attrID {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID:
 			id, err := strconv.ParseUint(a.Value, 10, 32)
 			if err != nil {
 				d.Scanner.InvalidAttr(a.Name.Local, true)
 			}
 			d.resource.ID, d.Scanner.ResourceID = uint32(id), uint32(id)
-			break
+		case attrDisplayPropsID:
+			d.resource.DisplayPropertiesID = parseDisplayPropsID(d.Scanner, a)
 		}
 	}
 }
@@ -139,6 +164,8 @@ func (d *tex2DGroupDecoder) Start(attrs []xml.Attr) {
 				d.Scanner.InvalidAttr(a.Name.Local, true)
 			}
 			d.resource.TextureID = uint32(val)
+		case attrDisplayPropsID:
+			d.resource.DisplayPropertiesID = parseDisplayPropsID(d.Scanner, a)
 		}
 	}
 }
@@ -214,6 +241,8 @@ func (d *compositeMaterialsDecoder) Start(attrs []xml.Attr) {
 				d.Scanner.InvalidAttr(a.Name.Local, true)
 			}
 			d.resource.MaterialID = uint32(val)
+		case attrDisplayPropsID:
+			d.resource.DisplayPropertiesID = parseDisplayPropsID(d.Scanner, a)
 		case attrMatIndices:
 			for _, f := range strings.Fields(a.Value) {
 				val, err := strconv.ParseUint(f, 10, 32)
@@ -277,6 +306,8 @@ func (d *multiPropertiesDecoder) Start(attrs []xml.Attr) {
 				d.Scanner.InvalidAttr(a.Name.Local, true)
 			}
 			d.resource.ID, d.Scanner.ResourceID = uint32(id), uint32(id)
+		case attrDisplayPropsID:
+			d.resource.DisplayPropertiesID = parseDisplayPropsID(d.Scanner, a)
 		case attrBlendMethods:
 			for _, f := range strings.Fields(a.Value) {
 				val, _ := newBlendMethod(f)
@@ -315,6 +346,283 @@ func (d *multiDecoder) Start(attrs []xml.Attr) {
 	d.resource.Multis = append(d.resource.Multis, multi)
 }
 
+type pbSpecularPropsDecoder struct {
+	baseDecoder
+	resource          PBSpecularDisplayProperties
+	pbSpecularDecoder pbSpecularDecoder
+}
+
+func (d *pbSpecularPropsDecoder) End() {
+	d.Scanner.AddAsset(&d.resource)
+}
+
+func (d *pbSpecularPropsDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
+	if name.Space == Namespace && name.Local == attrPBSpecular {
+		child = &d.pbSpecularDecoder
+	}
+	return
+}
+
+func (d *pbSpecularPropsDecoder) Start(attrs []xml.Attr) {
+	d.pbSpecularDecoder.resource = &d.resource
+	d.resource.ID = parseResourceID(d.Scanner, attrs)
+}
+
+type pbSpecularDecoder struct {
+	baseDecoder
+	resource *PBSpecularDisplayProperties
+}
+
+func (d *pbSpecularDecoder) Start(attrs []xml.Attr) {
+	s := PBSpecular{SpecularColor: color.RGBA{R: 0x38, G: 0x38, B: 0x38, A: 0xff}}
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			s.Name = a.Value
+		case attrSpecularColor:
+			s.SpecularColor = parseColor(d.Scanner, a)
+		case attrGlossiness:
+			s.Glossiness = parseFloat(d.Scanner, a)
+		}
+	}
+	d.resource.Specular = append(d.resource.Specular, s)
+}
+
+type pbMetallicPropsDecoder struct {
+	baseDecoder
+	resource          PBMetallicDisplayProperties
+	pbMetallicDecoder pbMetallicDecoder
+}
+
+func (d *pbMetallicPropsDecoder) End() {
+	d.Scanner.AddAsset(&d.resource)
+}
+
+func (d *pbMetallicPropsDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
+	if name.Space == Namespace && name.Local == attrPBMetallic {
+		child = &d.pbMetallicDecoder
+	}
+	return
+}
+
+func (d *pbMetallicPropsDecoder) Start(attrs []xml.Attr) {
+	d.pbMetallicDecoder.resource = &d.resource
+	d.resource.ID = parseResourceID(d.Scanner, attrs)
+}
+
+type pbMetallicDecoder struct {
+	baseDecoder
+	resource *PBMetallicDisplayProperties
+}
+
+func (d *pbMetallicDecoder) Start(attrs []xml.Attr) {
+	m := PBMetallic{Roughness: 1}
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			m.Name = a.Value
+		case attrMetallicness:
+			m.Metallicness = parseFloat(d.Scanner, a)
+		case attrRoughness:
+			m.Roughness = parseFloat(d.Scanner, a)
+		}
+	}
+	d.resource.Metallic = append(d.resource.Metallic, m)
+}
+
+type pbSpecularTexPropsDecoder struct {
+	baseDecoder
+	resource PBSpecularTextureDisplayProperties
+}
+
+func (d *pbSpecularTexPropsDecoder) End() {
+	d.Scanner.AddAsset(&d.resource)
+}
+
+func (d *pbSpecularTexPropsDecoder) Start(attrs []xml.Attr) {
+	white := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	d.resource.DiffuseFactor, d.resource.SpecularFactor, d.resource.GlossinessFactor = white, white, 1
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID:
+			id, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			d.resource.ID, d.Scanner.ResourceID = uint32(id), uint32(id)
+		case attrName:
+			d.resource.Name = a.Value
+		case attrSpecularTexID:
+			d.resource.SpecularTextureID = parseTextureID(d.Scanner, a)
+		case attrGlossinessTexID:
+			d.resource.GlossinessTextureID = parseTextureID(d.Scanner, a)
+		case attrDiffuseFactor:
+			d.resource.DiffuseFactor = parseColor(d.Scanner, a)
+		case attrSpecularFactor:
+			d.resource.SpecularFactor = parseColor(d.Scanner, a)
+		case attrGlossinessFactor:
+			d.resource.GlossinessFactor = parseFloat(d.Scanner, a)
+		}
+	}
+}
+
+type pbMetallicTexPropsDecoder struct {
+	baseDecoder
+	resource PBMetallicTextureDisplayProperties
+}
+
+func (d *pbMetallicTexPropsDecoder) End() {
+	d.Scanner.AddAsset(&d.resource)
+}
+
+func (d *pbMetallicTexPropsDecoder) Start(attrs []xml.Attr) {
+	d.resource.BaseColorFactor = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	d.resource.MetallicFactor, d.resource.RoughnessFactor = 1, 1
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID:
+			id, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				d.Scanner.InvalidAttr(a.Name.Local, true)
+			}
+			d.resource.ID, d.Scanner.ResourceID = uint32(id), uint32(id)
+		case attrName:
+			d.resource.Name = a.Value
+		case attrMetallicTexID:
+			d.resource.MetallicTextureID = parseTextureID(d.Scanner, a)
+		case attrRoughnessTexID:
+			d.resource.RoughnessTextureID = parseTextureID(d.Scanner, a)
+		case attrBaseColorFactor:
+			d.resource.BaseColorFactor = parseColor(d.Scanner, a)
+		case attrMetallicFactor:
+			d.resource.MetallicFactor = parseFloat(d.Scanner, a)
+		case attrRoughnessFactor:
+			d.resource.RoughnessFactor = parseFloat(d.Scanner, a)
+		}
+	}
+}
+
+type translucentPropsDecoder struct {
+	baseDecoder
+	resource           TranslucentDisplayProperties
+	translucentDecoder translucentDecoder
+}
+
+func (d *translucentPropsDecoder) End() {
+	d.Scanner.AddAsset(&d.resource)
+}
+
+func (d *translucentPropsDecoder) Child(name xml.Name) (child go3mf.NodeDecoder) {
+	if name.Space == Namespace && name.Local == attrTranslucent {
+		child = &d.translucentDecoder
+	}
+	return
+}
+
+func (d *translucentPropsDecoder) Start(attrs []xml.Attr) {
+	d.translucentDecoder.resource = &d.resource
+	d.resource.ID = parseResourceID(d.Scanner, attrs)
+}
+
+type translucentDecoder struct {
+	baseDecoder
+	resource *TranslucentDisplayProperties
+}
+
+func (d *translucentDecoder) Start(attrs []xml.Attr) {
+	t := Translucent{RefractiveIndex: [3]float32{1, 1, 1}}
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			t.Name = a.Value
+		case attrAttenuation:
+			t.Attenuation = parseRGBValues(d.Scanner, a)
+		case attrRefractiveIndex:
+			t.RefractiveIndex = parseRGBValues(d.Scanner, a)
+		case attrRoughness:
+			t.Roughness = parseFloat(d.Scanner, a)
+		}
+	}
+	d.resource.Translucent = append(d.resource.Translucent, t)
+}
+
+func parseResourceID(s *go3mf.Scanner, attrs []xml.Attr) uint32 {
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrID {
+			id, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				s.InvalidAttr(a.Name.Local, true)
+			}
+			s.ResourceID = uint32(id)
+			return uint32(id)
+		}
+	}
+	return 0
+}
+
+func parseDisplayPropsID(s *go3mf.Scanner, a xml.Attr) uint32 {
+	val, err := strconv.ParseUint(a.Value, 10, 32)
+	if err != nil {
+		s.InvalidAttr(a.Name.Local, false)
+	}
+	return uint32(val)
+}
+
+func parseTextureID(s *go3mf.Scanner, a xml.Attr) uint32 {
+	val, err := strconv.ParseUint(a.Value, 10, 32)
+	if err != nil {
+		s.InvalidAttr(a.Name.Local, true)
+	}
+	return uint32(val)
+}
+
+func parseFloat(s *go3mf.Scanner, a xml.Attr) float32 {
+	val, err := strconv.ParseFloat(a.Value, 32)
+	if err != nil {
+		s.InvalidAttr(a.Name.Local, false)
+	}
+	return float32(val)
+}
+
+func parseColor(s *go3mf.Scanner, a xml.Attr) color.RGBA {
+	c, err := go3mf.ParseRGBA(a.Value)
+	if err != nil {
+		s.InvalidAttr(a.Name.Local, false)
+	}
+	return c
+}
+
+func parseRGBValues(s *go3mf.Scanner, a xml.Attr) (v [3]float32) {
+	fields := strings.Fields(a.Value)
+	valid := len(fields) == 3
+	for i := 0; i < len(fields) && i < 3; i++ {
+		val, err := strconv.ParseFloat(fields[i], 32)
+		if err != nil {
+			valid = false
+		}
+		v[i] = float32(val)
+	}
+	if !valid {
+		s.InvalidAttr(a.Name.Local, false)
+	}
+	return
+}
+
 type baseDecoder struct {
 	Scanner *go3mf.Scanner
 }
@@ -17,25 +17,66 @@ func TestDecode(t *testing.T) {
 	texGroup := &Texture2DGroup{ID: 2, TextureID: 6, Coords: []TextureCoord{{0.3, 0.5}, {0.3, 0.8}, {0.5, 0.8}, {0.5, 0.5}}}
 	compositeGroup := &CompositeMaterials{ID: 4, MaterialID: 5, Indices: []uint32{1, 2}, Composites: []Composite{{Values: []float32{0.5, 0.5}}, {Values: []float32{0.2, 0.8}}}}
 	multiGroup := &MultiProperties{ID: 9, BlendMethods: []BlendMethod{BlendMultiply}, PIDs: []uint32{5, 2}, Multis: []Multi{{PIndices: []uint32{0, 0}}, {PIndices: []uint32{1, 0}}, {PIndices: []uint32{2, 3}}}}
+	dpID := DisplayPropertiesID(10)
+	baseMaterials := &go3mf.BaseMaterials{ID: 5, Materials: []go3mf.Base{{Name: "Red", Color: color.RGBA{R: 255, A: 255}}, {Name: "Blue", Color: color.RGBA{B: 255, A: 255}}}, AnyAttr: go3mf.AttrMarshalers{&dpID}}
+	specularProps := &PBSpecularDisplayProperties{ID: 10, Specular: []PBSpecular{
+		{Name: "Shiny", SpecularColor: color.RGBA{R: 56, G: 56, B: 56, A: 255}, Glossiness: 0.8},
+		{Name: "Matte", SpecularColor: color.RGBA{R: 10, G: 20, B: 30, A: 255}},
+	}}
+	metallicProps := &PBMetallicDisplayProperties{ID: 11, Metallic: []PBMetallic{
+		{Name: "Metal", Metallicness: 1, Roughness: 0.2}, {Name: "Plastic", Roughness: 1},
+		{Name: "a", Roughness: 1}, {Name: "b", Roughness: 1},
+	}}
+	specularTexProps := &PBSpecularTextureDisplayProperties{ID: 12, Name: "Tex", SpecularTextureID: 6, GlossinessTextureID: 6,
+		DiffuseFactor: color.RGBA{R: 255, G: 255, B: 255, A: 255}, SpecularFactor: color.RGBA{R: 128, G: 128, B: 128, A: 255}, GlossinessFactor: 1}
+	metallicTexProps := &PBMetallicTextureDisplayProperties{ID: 13, Name: "Tex", MetallicTextureID: 6, RoughnessTextureID: 6,
+		BaseColorFactor: color.RGBA{R: 255, G: 255, B: 255, A: 255}, MetallicFactor: 0.5, RoughnessFactor: 1}
+	translucentProps := &TranslucentDisplayProperties{ID: 14, Translucent: []Translucent{
+		{Name: "Glass", Attenuation: [3]float32{0.1, 0.2, 0.3}, RefractiveIndex: [3]float32{1.5, 1.5, 1.5}, Roughness: 0.1},
+		{Name: "Water", RefractiveIndex: [3]float32{1, 1, 1}},
+	}}
+	colorGroup.DisplayPropertiesID = 11
+	texGroup.DisplayPropertiesID = 12
+	compositeGroup.DisplayPropertiesID = 14
+	multiGroup.DisplayPropertiesID = 13
 	want := &go3mf.Model{Path: "/3D/3dmodel.model"}
-	want.Resources.Assets = append(want.Resources.Assets, baseTexture, colorGroup, texGroup, compositeGroup, multiGroup)
+	want.Resources.Assets = append(want.Resources.Assets, baseTexture, baseMaterials, specularProps, metallicProps, specularTexProps, metallicTexProps, translucentProps, colorGroup, texGroup, compositeGroup, multiGroup)
 	got := new(go3mf.Model)
 	got.Path = "/3D/3dmodel.model"
 	rootFile := `
 	<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:m="http://schemas.microsoft.com/3dmanufacturing/material/2015/02">
 		<resources>
 			<m:texture2d id="6" path="/3D/Texture/msLogo.png" contenttype="image/png" tilestyleu="wrap" tilestylev="mirror" filter="auto" />
-			<m:colorgroup id="1">
+			<basematerials id="5" m:displaypropertiesid="10">
+				<base name="Red" displaycolor="#FF0000" />
+				<base name="Blue" displaycolor="#0000FF" />
+			</basematerials>
+			<m:pbspeculardisplayproperties id="10">
+				<m:pbspecular name="Shiny" glossiness="0.8" />
+				<m:pbspecular name="Matte" specularcolor="#0A141E" />
+			</m:pbspeculardisplayproperties>
+			<m:pbmetallicdisplayproperties id="11">
+				<m:pbmetallic name="Metal" metallicness="1" roughness="0.2" />
+				<m:pbmetallic name="Plastic" />
+				<m:pbmetallic name="a" /> <m:pbmetallic name="b" />
+			</m:pbmetallicdisplayproperties>
+			<m:pbspeculartexturedisplayproperties id="12" name="Tex" speculartextureid="6" glossinesstextureid="6" specularfactor="#808080" />
+			<m:pbmetallictexturedisplayproperties id="13" name="Tex" metallictextureid="6" roughnesstextureid="6" metallicfactor="0.5" />
+			<m:translucentdisplayproperties id="14">
+				<m:translucent name="Glass" attenuation="0.1 0.2 0.3" refractiveindex="1.5 1.5 1.5" roughness="0.1" />
+				<m:translucent name="Water" />
+			</m:translucentdisplayproperties>
+			<m:colorgroup id="1" displaypropertiesid="11">
 				<m:color color="#FFFFFF" /> <m:color color="#000000" /> <m:color color="#1AB567" /> <m:color color="#DF045A" />
 			</m:colorgroup>
-			<m:texture2dgroup id="2" texid="6">
+			<m:texture2dgroup id="2" texid="6" displaypropertiesid="12">
 				<m:tex2coord u="0.3" v="0.5" /> <m:tex2coord u="0.3" v="0.8" />	<m:tex2coord u="0.5" v="0.8" />	<m:tex2coord u="0.5" v="0.5" />
 			</m:texture2dgroup>
-			<m:compositematerials id="4" matid="5" matindices="1 2">
+			<m:compositematerials id="4" matid="5" matindices="1 2" displaypropertiesid="14">
 				<m:composite values="0.5 0.5"/>
 				<m:composite values="0.2 0.8"/>
 			</m:compositematerials>
-			<m:multiproperties id="9" pids="5 2" blendmethods="multiply">
+			<m:multiproperties id="9" pids="5 2" blendmethods="multiply" displaypropertiesid="13">
 				<m:multi pindices="0 0" />
 				<m:multi pindices="1 0" />
 				<m:multi pindices="2 3" />
@@ -68,6 +109,9 @@ func TestDecode_warns(t *testing.T) {
 		&errors.ParseFieldError{Required: true, ResourceID: 4, Name: "matid", Context: "model@resources@compositematerials"},
 		&errors.ParseFieldError{Required: true, ResourceID: 4, Name: "values", Context: "model@resources@compositematerials@composite"},
 		&errors.ParseFieldError{Required: true, ResourceID: 9, Name: "pids", Context: "model@resources@multiproperties"},
+		&errors.ParseFieldError{Required: false, ResourceID: 20, Name: "metallicness", Context: "model@resources@pbmetallicdisplayproperties@pbmetallic"},
+		&errors.ParseFieldError{Required: false, ResourceID: 21, Name: "attenuation", Context: "model@resources@translucentdisplayproperties@translucent"},
+		&errors.ParseFieldError{Required: true, ResourceID: 22, Name: "speculartextureid", Context: "model@resources@pbspeculartexturedisplayproperties"},
 	}}
 	got := new(go3mf.Model)
 	got.Path = "/3D/3dmodel.model"
@@ -90,6 +134,13 @@ func TestDecode_warns(t *testing.T) {
 				<m:multi />
 			</m:multiproperties>
 			<m:multiproperties id="19" />
+			<m:pbmetallicdisplayproperties id="20">
+				<m:pbmetallic name="a" metallicness="b" />
+			</m:pbmetallicdisplayproperties>
+			<m:translucentdisplayproperties id="21">
+				<m:translucent name="a" attenuation="1 2" />
+			</m:translucentdisplayproperties>
+			<m:pbspeculartexturedisplayproperties id="22" name="a" speculartextureid="b" glossinesstextureid="1" />
 			<object id="8" name="Box 1" pid="5" pindex="0" type="model">
 				<mesh>
 					<vertices>
@@ -13,6 +13,7 @@ func (r *ColorGroup) Marshal3MF(x *go3mf.XMLEncoder) error {
 	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrColorGroup}, Attr: []xml.Attr{
 		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
 	}}
+	appendDisplayPropsID(&xs, r.DisplayPropertiesID)
 	x.EncodeToken(xs)
 	x.SetAutoClose(true)
 	for _, c := range r.Colors {
@@ -31,6 +32,7 @@ func (r *Texture2DGroup) Marshal3MF(x *go3mf.XMLEncoder) error {
 		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
 		{Name: xml.Name{Local: attrTexID}, Value: strconv.FormatUint(uint64(r.TextureID), 10)},
 	}}
+	appendDisplayPropsID(&xs, r.DisplayPropertiesID)
 	x.EncodeToken(xs)
 	x.SetAutoClose(true)
 	for _, c := range r.Coords {
@@ -55,6 +57,7 @@ func (r *CompositeMaterials) Marshal3MF(x *go3mf.XMLEncoder) error {
 		{Name: xml.Name{Local: attrMatID}, Value: strconv.FormatUint(uint64(r.MaterialID), 10)},
 		{Name: xml.Name{Local: attrMatIndices}, Value: strings.Join(indices, " ")},
 	}}
+	appendDisplayPropsID(&xs, r.DisplayPropertiesID)
 	x.EncodeToken(xs)
 	x.SetAutoClose(true)
 	for _, c := range r.Composites {
@@ -86,6 +89,7 @@ func (r *MultiProperties) Marshal3MF(x *go3mf.XMLEncoder) error {
 		{Name: xml.Name{Local: attrPIDs}, Value: strings.Join(pids, " ")},
 		{Name: xml.Name{Local: attrBlendMethods}, Value: strings.Join(methods, " ")},
 	}}
+	appendDisplayPropsID(&xs, r.DisplayPropertiesID)
 	x.EncodeToken(xs)
 	x.SetAutoClose(true)
 	for _, mu := range r.Multis {
@@ -124,3 +128,116 @@ func (r *Texture2D) Marshal3MF(x *go3mf.XMLEncoder) error {
 	x.SetAutoClose(false)
 	return nil
 }
+
+// Marshal3MFAttr encodes the resource attributes.
+func (id *DisplayPropertiesID) Marshal3MFAttr(_ *go3mf.XMLEncoder) ([]xml.Attr, error) {
+	return []xml.Attr{
+		{Name: xml.Name{Space: Namespace, Local: attrDisplayPropsID}, Value: strconv.FormatUint(uint64(*id), 10)},
+	}, nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *PBSpecularDisplayProperties) Marshal3MF(x *go3mf.XMLEncoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBSpecularProps}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	for _, s := range r.Specular {
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBSpecular}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: s.Name},
+			{Name: xml.Name{Local: attrSpecularColor}, Value: go3mf.FormatRGBA(s.SpecularColor)},
+			{Name: xml.Name{Local: attrGlossiness}, Value: formatFloat(x, s.Glossiness)},
+		}})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *PBMetallicDisplayProperties) Marshal3MF(x *go3mf.XMLEncoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBMetallicProps}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	for _, m := range r.Metallic {
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBMetallic}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: m.Name},
+			{Name: xml.Name{Local: attrMetallicness}, Value: formatFloat(x, m.Metallicness)},
+			{Name: xml.Name{Local: attrRoughness}, Value: formatFloat(x, m.Roughness)},
+		}})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *PBSpecularTextureDisplayProperties) Marshal3MF(x *go3mf.XMLEncoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBSpecularTexProps}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrName}, Value: r.Name},
+		{Name: xml.Name{Local: attrSpecularTexID}, Value: strconv.FormatUint(uint64(r.SpecularTextureID), 10)},
+		{Name: xml.Name{Local: attrGlossinessTexID}, Value: strconv.FormatUint(uint64(r.GlossinessTextureID), 10)},
+		{Name: xml.Name{Local: attrDiffuseFactor}, Value: go3mf.FormatRGBA(r.DiffuseFactor)},
+		{Name: xml.Name{Local: attrSpecularFactor}, Value: go3mf.FormatRGBA(r.SpecularFactor)},
+		{Name: xml.Name{Local: attrGlossinessFactor}, Value: formatFloat(x, r.GlossinessFactor)},
+	}}
+	x.SetAutoClose(true)
+	x.EncodeToken(xs)
+	x.SetAutoClose(false)
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *PBMetallicTextureDisplayProperties) Marshal3MF(x *go3mf.XMLEncoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBMetallicTexProps}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrName}, Value: r.Name},
+		{Name: xml.Name{Local: attrMetallicTexID}, Value: strconv.FormatUint(uint64(r.MetallicTextureID), 10)},
+		{Name: xml.Name{Local: attrRoughnessTexID}, Value: strconv.FormatUint(uint64(r.RoughnessTextureID), 10)},
+		{Name: xml.Name{Local: attrBaseColorFactor}, Value: go3mf.FormatRGBA(r.BaseColorFactor)},
+		{Name: xml.Name{Local: attrMetallicFactor}, Value: formatFloat(x, r.MetallicFactor)},
+		{Name: xml.Name{Local: attrRoughnessFactor}, Value: formatFloat(x, r.RoughnessFactor)},
+	}}
+	x.SetAutoClose(true)
+	x.EncodeToken(xs)
+	x.SetAutoClose(false)
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *TranslucentDisplayProperties) Marshal3MF(x *go3mf.XMLEncoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrTranslucentProps}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	for _, t := range r.Translucent {
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrTranslucent}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: t.Name},
+			{Name: xml.Name{Local: attrAttenuation}, Value: formatRGBValues(x, t.Attenuation)},
+			{Name: xml.Name{Local: attrRefractiveIndex}, Value: formatRGBValues(x, t.RefractiveIndex)},
+			{Name: xml.Name{Local: attrRoughness}, Value: formatFloat(x, t.Roughness)},
+		}})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+func appendDisplayPropsID(xs *xml.StartElement, id uint32) {
+	if id != 0 {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrDisplayPropsID}, Value: strconv.FormatUint(uint64(id), 10)})
+	}
+}
+
+func formatFloat(x *go3mf.XMLEncoder, f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', x.FloatPresicion(), 32)
+}
+
+func formatRGBValues(x *go3mf.XMLEncoder, v [3]float32) string {
+	return formatFloat(x, v[0]) + " " + formatFloat(x, v[1]) + " " + formatFloat(x, v[2])
+}
@@ -14,8 +14,30 @@ func TestMarshalModel(t *testing.T) {
 	texGroup := &Texture2DGroup{ID: 2, TextureID: 6, Coords: []TextureCoord{{0.3, 0.5}, {0.3, 0.8}, {0.5, 0.8}, {0.5, 0.5}}}
 	compositeGroup := &CompositeMaterials{ID: 4, MaterialID: 5, Indices: []uint32{1, 2}, Composites: []Composite{{Values: []float32{0.5, 0.5}}, {Values: []float32{0.2, 0.8}}}}
 	multiGroup := &MultiProperties{ID: 9, BlendMethods: []BlendMethod{BlendMultiply}, PIDs: []uint32{5, 2}, Multis: []Multi{{PIndices: []uint32{0, 0}}, {PIndices: []uint32{1, 0}}, {PIndices: []uint32{2, 3}}}}
+	dpID := DisplayPropertiesID(10)
+	baseMaterials := &go3mf.BaseMaterials{ID: 5, Materials: []go3mf.Base{{Name: "Red", Color: color.RGBA{R: 255, A: 255}}, {Name: "Blue", Color: color.RGBA{B: 255, A: 255}}}, AnyAttr: go3mf.AttrMarshalers{&dpID}}
+	specularProps := &PBSpecularDisplayProperties{ID: 10, Specular: []PBSpecular{
+		{Name: "Shiny", SpecularColor: color.RGBA{R: 56, G: 56, B: 56, A: 255}, Glossiness: 0.8},
+		{Name: "Matte", SpecularColor: color.RGBA{R: 10, G: 20, B: 30, A: 255}},
+	}}
+	metallicProps := &PBMetallicDisplayProperties{ID: 11, Metallic: []PBMetallic{
+		{Name: "Metal", Metallicness: 1, Roughness: 0.2}, {Name: "Plastic", Roughness: 1},
+		{Name: "a", Roughness: 1}, {Name: "b", Roughness: 1},
+	}}
+	specularTexProps := &PBSpecularTextureDisplayProperties{ID: 12, Name: "Tex", SpecularTextureID: 6, GlossinessTextureID: 6,
+		DiffuseFactor: color.RGBA{R: 255, G: 255, B: 255, A: 255}, SpecularFactor: color.RGBA{R: 128, G: 128, B: 128, A: 255}, GlossinessFactor: 1}
+	metallicTexProps := &PBMetallicTextureDisplayProperties{ID: 13, Name: "Tex", MetallicTextureID: 6, RoughnessTextureID: 6,
+		BaseColorFactor: color.RGBA{R: 255, G: 255, B: 255, A: 255}, MetallicFactor: 0.5, RoughnessFactor: 1}
+	translucentProps := &TranslucentDisplayProperties{ID: 14, Translucent: []Translucent{
+		{Name: "Glass", Attenuation: [3]float32{0.1, 0.2, 0.3}, RefractiveIndex: [3]float32{1.5, 1.5, 1.5}, Roughness: 0.1},
+		{Name: "Water", RefractiveIndex: [3]float32{1, 1, 1}},
+	}}
+	colorGroup.DisplayPropertiesID = 11
+	texGroup.DisplayPropertiesID = 12
+	compositeGroup.DisplayPropertiesID = 14
+	multiGroup.DisplayPropertiesID = 13
 	m := &go3mf.Model{Path: "/3D/3dmodel.model"}
-	m.Resources.Assets = append(m.Resources.Assets, baseTexture, colorGroup, texGroup, compositeGroup, multiGroup)
+	m.Resources.Assets = append(m.Resources.Assets, baseTexture, baseMaterials, specularProps, metallicProps, specularTexProps, metallicTexProps, translucentProps, colorGroup, texGroup, compositeGroup, multiGroup)
 
 	t.Run("base", func(t *testing.T) {
 		m.WithSpec(&Spec{LocalName: "m"})
@@ -126,9 +126,10 @@ func (t TextureCoord) V() float32 {
 
 // Texture2DGroup acts as a container for texture coordinate properties.
 type Texture2DGroup struct {
-	ID        uint32
-	TextureID uint32
-	Coords    []TextureCoord
+	ID                  uint32
+	TextureID           uint32
+	DisplayPropertiesID uint32
+	Coords              []TextureCoord
 }
 
 // Len returns the materials count.
@@ -143,8 +144,9 @@ func (r *Texture2DGroup) Identify() uint32 {
 
 // ColorGroup acts as a container for color properties.
 type ColorGroup struct {
-	ID     uint32
-	Colors []color.RGBA
+	ID                  uint32
+	DisplayPropertiesID uint32
+	Colors              []color.RGBA
 }
 
 // Len returns the materials count.
@@ -164,10 +166,11 @@ type Composite struct {
 
 // CompositeMaterials defines materials derived by mixing 2 or more base materials in defined ratios.
 type CompositeMaterials struct {
-	ID         uint32
-	MaterialID uint32
-	Indices    []uint32
-	Composites []Composite
+	ID                  uint32
+	MaterialID          uint32
+	DisplayPropertiesID uint32
+	Indices             []uint32
+	Composites          []Composite
 }
 
 // Len returns the materials count.
@@ -188,10 +191,11 @@ type Multi struct {
 // A MultiProperties element acts as a container for Multi
 // elements which are indexable groups of property indices.
 type MultiProperties struct {
-	ID           uint32
-	PIDs         []uint32
-	BlendMethods []BlendMethod
-	Multis       []Multi
+	ID                  uint32
+	DisplayPropertiesID uint32
+	PIDs                []uint32
+	BlendMethods        []BlendMethod
+	Multis              []Multi
 }
 
 // Len returns the materials count.
@@ -204,6 +208,118 @@ func (c *MultiProperties) Identify() uint32 {
 	return c.ID
 }
 
+// DisplayPropertiesID links a basematerials group to a display properties resource.
+// It is stored as an extension attribute of go3mf.BaseMaterials.
+type DisplayPropertiesID uint32
+
+// PBSpecular defines the specular-glossiness physically based properties of a material.
+type PBSpecular struct {
+	Name          string
+	SpecularColor color.RGBA
+	Glossiness    float32
+}
+
+// PBSpecularDisplayProperties acts as a container for specular-glossiness
+// display properties, one for each element of the referencing group.
+type PBSpecularDisplayProperties struct {
+	ID       uint32
+	Specular []PBSpecular
+}
+
+// Len returns the materials count.
+func (r *PBSpecularDisplayProperties) Len() int {
+	return len(r.Specular)
+}
+
+// Identify returns the unique ID of the resource.
+func (r *PBSpecularDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// PBMetallic defines the metallic-roughness physically based properties of a material.
+type PBMetallic struct {
+	Name         string
+	Metallicness float32
+	Roughness    float32
+}
+
+// PBMetallicDisplayProperties acts as a container for metallic-roughness
+// display properties, one for each element of the referencing group.
+type PBMetallicDisplayProperties struct {
+	ID       uint32
+	Metallic []PBMetallic
+}
+
+// Len returns the materials count.
+func (r *PBMetallicDisplayProperties) Len() int {
+	return len(r.Metallic)
+}
+
+// Identify returns the unique ID of the resource.
+func (r *PBMetallicDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// PBSpecularTextureDisplayProperties defines specular-glossiness
+// display properties whose values are read from textures.
+type PBSpecularTextureDisplayProperties struct {
+	ID                  uint32
+	Name                string
+	SpecularTextureID   uint32
+	GlossinessTextureID uint32
+	DiffuseFactor       color.RGBA
+	SpecularFactor      color.RGBA
+	GlossinessFactor    float32
+}
+
+// Identify returns the unique ID of the resource.
+func (r *PBSpecularTextureDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// PBMetallicTextureDisplayProperties defines metallic-roughness
+// display properties whose values are read from textures.
+type PBMetallicTextureDisplayProperties struct {
+	ID                 uint32
+	Name               string
+	MetallicTextureID  uint32
+	RoughnessTextureID uint32
+	BaseColorFactor    color.RGBA
+	MetallicFactor     float32
+	RoughnessFactor    float32
+}
+
+// Identify returns the unique ID of the resource.
+func (r *PBMetallicTextureDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// Translucent defines the optical properties of a translucent material.
+// Attenuation and RefractiveIndex are defined per red, green and blue channels.
+type Translucent struct {
+	Name            string
+	Attenuation     [3]float32
+	RefractiveIndex [3]float32
+	Roughness       float32
+}
+
+// TranslucentDisplayProperties acts as a container for translucent
+// display properties, one for each element of the referencing group.
+type TranslucentDisplayProperties struct {
+	ID          uint32
+	Translucent []Translucent
+}
+
+// Len returns the materials count.
+func (r *TranslucentDisplayProperties) Len() int {
+	return len(r.Translucent)
+}
+
+// Identify returns the unique ID of the resource.
+func (r *TranslucentDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
 func newTexture2DType(s string) (t Texture2DType, ok bool) {
 	t, ok = map[string]Texture2DType{
 		"image/png":  TextureTypePNG,
@@ -264,4 +380,30 @@ const (
 	attrPIndices           = "pindices"
 	attrPIDs               = "pids"
 	attrBlendMethods       = "blendmethods"
+	attrName               = "name"
+	attrDisplayPropsID     = "displaypropertiesid"
+	attrPBSpecularProps    = "pbspeculardisplayproperties"
+	attrPBSpecular         = "pbspecular"
+	attrSpecularColor      = "specularcolor"
+	attrGlossiness         = "glossiness"
+	attrPBMetallicProps    = "pbmetallicdisplayproperties"
+	attrPBMetallic         = "pbmetallic"
+	attrMetallicness       = "metallicness"
+	attrRoughness          = "roughness"
+	attrPBSpecularTexProps = "pbspeculartexturedisplayproperties"
+	attrSpecularTexID      = "speculartextureid"
+	attrGlossinessTexID    = "glossinesstextureid"
+	attrDiffuseFactor      = "diffusefactor"
+	attrSpecularFactor     = "specularfactor"
+	attrGlossinessFactor   = "glossinessfactor"
+	attrPBMetallicTexProps = "pbmetallictexturedisplayproperties"
+	attrMetallicTexID      = "metallictextureid"
+	attrRoughnessTexID     = "roughnesstextureid"
+	attrBaseColorFactor    = "basecolorfactor"
+	attrMetallicFactor     = "metallicfactor"
+	attrRoughnessFactor    = "roughnessfactor"
+	attrTranslucentProps   = "translucentdisplayproperties"
+	attrTranslucent        = "translucent"
+	attrAttenuation        = "attenuation"
+	attrRefractiveIndex    = "refractiveindex"
 )
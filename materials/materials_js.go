@@ -21,6 +21,14 @@ var (
 	jsMultiProperties       = go3mf.RegisterClass("MultiProperties", "X", jsNS)
 	jsMulti                 = go3mf.RegisterClass("Multi", "X", jsNS)
 	jsColorGroup            = go3mf.RegisterClass("ColorGroup", "X", jsNS)
+	jsPBSpecularProps       = go3mf.RegisterClass("PBSpecularDisplayProperties", "X", jsNS)
+	jsPBSpecular            = go3mf.RegisterClass("PBSpecular", "X", jsNS)
+	jsPBMetallicProps       = go3mf.RegisterClass("PBMetallicDisplayProperties", "X", jsNS)
+	jsPBMetallic            = go3mf.RegisterClass("PBMetallic", "X", jsNS)
+	jsPBSpecularTexProps    = go3mf.RegisterClass("PBSpecularTextureDisplayProperties", "X", jsNS)
+	jsPBMetallicTexProps    = go3mf.RegisterClass("PBMetallicTextureDisplayProperties", "X", jsNS)
+	jsTranslucentProps      = go3mf.RegisterClass("TranslucentDisplayProperties", "X", jsNS)
+	jsTranslucent           = go3mf.RegisterClass("Translucent", "X", jsNS)
 )
 
 // JSValue returns a JavaScript value associated with the object.
@@ -40,6 +48,7 @@ func (r *Texture2DGroup) JSValue() js.Value {
 	v := jsTexture2DGroup.New()
 	v.Set(attrID, r.ID)
 	v.Set("texId", r.TextureID)
+	setDisplayPropsID(v, r.DisplayPropertiesID)
 	hv := (*reflect.SliceHeader)(unsafe.Pointer(&r.Coords))
 	hv.Len *= 2 * 4
 	hv.Cap *= 2 * 4
@@ -65,6 +74,7 @@ func (r *CompositeMaterials) JSValue() js.Value {
 	v := jsCompositeMaterials.New()
 	v.Set(attrID, r.ID)
 	v.Set("matId", r.MaterialID)
+	setDisplayPropsID(v, r.DisplayPropertiesID)
 	arri := arrayConstructor.New(len(r.Indices))
 	for i, r := range r.Indices {
 		arri.SetIndex(i, r)
@@ -93,6 +103,7 @@ func (m Multi) JSValue() js.Value {
 func (r *MultiProperties) JSValue() js.Value {
 	v := jsMultiProperties.New()
 	v.Set(attrID, r.ID)
+	setDisplayPropsID(v, r.DisplayPropertiesID)
 	arr := arrayConstructor.New(len(r.PIDs))
 	for i, pid := range r.PIDs {
 		arr.SetIndex(i, pid)
@@ -115,6 +126,7 @@ func (r *MultiProperties) JSValue() js.Value {
 func (r *ColorGroup) JSValue() js.Value {
 	v := jsColorGroup.New()
 	v.Set(attrID, r.ID)
+	setDisplayPropsID(v, r.DisplayPropertiesID)
 	arr := arrayConstructor.New(len(r.Colors))
 	for i, c := range r.Colors {
 		arr.SetIndex(i, go3mf.FormatRGBA(c))
@@ -122,3 +134,106 @@ func (r *ColorGroup) JSValue() js.Value {
 	v.Set("colors", arr)
 	return v
 }
+
+// JSValue returns a JavaScript value associated with the object.
+func (id *DisplayPropertiesID) JSValue() js.Value {
+	return js.ValueOf(uint32(*id))
+}
+
+// JSValue returns a JavaScript value associated with the object.
+func (s PBSpecular) JSValue() js.Value {
+	v := jsPBSpecular.New()
+	v.Set(attrName, s.Name)
+	v.Set("specularColor", go3mf.FormatRGBA(s.SpecularColor))
+	v.Set(attrGlossiness, s.Glossiness)
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+func (r *PBSpecularDisplayProperties) JSValue() js.Value {
+	v := jsPBSpecularProps.New()
+	v.Set(attrID, r.ID)
+	arr := arrayConstructor.New(len(r.Specular))
+	for i, s := range r.Specular {
+		arr.SetIndex(i, s)
+	}
+	v.Set("specular", arr)
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+func (m PBMetallic) JSValue() js.Value {
+	v := jsPBMetallic.New()
+	v.Set(attrName, m.Name)
+	v.Set(attrMetallicness, m.Metallicness)
+	v.Set(attrRoughness, m.Roughness)
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+func (r *PBMetallicDisplayProperties) JSValue() js.Value {
+	v := jsPBMetallicProps.New()
+	v.Set(attrID, r.ID)
+	arr := arrayConstructor.New(len(r.Metallic))
+	for i, m := range r.Metallic {
+		arr.SetIndex(i, m)
+	}
+	v.Set("metallic", arr)
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+func (r *PBSpecularTextureDisplayProperties) JSValue() js.Value {
+	v := jsPBSpecularTexProps.New()
+	v.Set(attrID, r.ID)
+	v.Set(attrName, r.Name)
+	v.Set("specularTextureId", r.SpecularTextureID)
+	v.Set("glossinessTextureId", r.GlossinessTextureID)
+	v.Set("diffuseFactor", go3mf.FormatRGBA(r.DiffuseFactor))
+	v.Set("specularFactor", go3mf.FormatRGBA(r.SpecularFactor))
+	v.Set("glossinessFactor", r.GlossinessFactor)
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+func (r *PBMetallicTextureDisplayProperties) JSValue() js.Value {
+	v := jsPBMetallicTexProps.New()
+	v.Set(attrID, r.ID)
+	v.Set(attrName, r.Name)
+	v.Set("metallicTextureId", r.MetallicTextureID)
+	v.Set("roughnessTextureId", r.RoughnessTextureID)
+	v.Set("baseColorFactor", go3mf.FormatRGBA(r.BaseColorFactor))
+	v.Set("metallicFactor", r.MetallicFactor)
+	v.Set("roughnessFactor", r.RoughnessFactor)
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+func (t Translucent) JSValue() js.Value {
+	v := jsTranslucent.New()
+	v.Set(attrName, t.Name)
+	v.Set(attrAttenuation, []interface{}{t.Attenuation[0], t.Attenuation[1], t.Attenuation[2]})
+	v.Set("refractiveIndex", []interface{}{t.RefractiveIndex[0], t.RefractiveIndex[1], t.RefractiveIndex[2]})
+	v.Set(attrRoughness, t.Roughness)
+	return v
+}
+
+// JSValue returns a JavaScript value associated with the object.
+func (r *TranslucentDisplayProperties) JSValue() js.Value {
+	v := jsTranslucentProps.New()
+	v.Set(attrID, r.ID)
+	arr := arrayConstructor.New(len(r.Translucent))
+	for i, t := range r.Translucent {
+		arr.SetIndex(i, t)
+	}
+	v.Set("translucent", arr)
+	return v
+}
+
+func setDisplayPropsID(v js.Value, id uint32) {
+	if id != 0 {
+		v.Set("displayPropertiesId", id)
+	} else {
+		v.Set("displayPropertiesId", js.Undefined())
+	}
+}
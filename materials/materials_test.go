@@ -24,6 +24,17 @@ var _ go3mf.PropertyGroup = new(ColorGroup)
 var _ go3mf.PropertyGroup = new(Texture2DGroup)
 var _ go3mf.PropertyGroup = new(CompositeMaterials)
 var _ go3mf.PropertyGroup = new(MultiProperties)
+var _ go3mf.Asset = new(PBSpecularDisplayProperties)
+var _ go3mf.Asset = new(PBMetallicDisplayProperties)
+var _ go3mf.Asset = new(PBSpecularTextureDisplayProperties)
+var _ go3mf.Asset = new(PBMetallicTextureDisplayProperties)
+var _ go3mf.Asset = new(TranslucentDisplayProperties)
+var _ go3mf.Marshaler = new(PBSpecularDisplayProperties)
+var _ go3mf.Marshaler = new(PBMetallicDisplayProperties)
+var _ go3mf.Marshaler = new(PBSpecularTextureDisplayProperties)
+var _ go3mf.Marshaler = new(PBMetallicTextureDisplayProperties)
+var _ go3mf.Marshaler = new(TranslucentDisplayProperties)
+var _ go3mf.AttrMarshaler = new(DisplayPropertiesID)
 
 func TestTexture2D_Identify(t *testing.T) {
 	tests := []struct {
@@ -392,3 +403,80 @@ func TestTexture2DGroup_Len(t *testing.T) {
 		})
 	}
 }
+
+func TestDisplayProperties_Identify(t *testing.T) {
+	tests := []struct {
+		name string
+		r    go3mf.Asset
+		want uint32
+	}{
+		{"pbspecular", &PBSpecularDisplayProperties{ID: 1}, 1},
+		{"pbmetallic", &PBMetallicDisplayProperties{ID: 2}, 2},
+		{"pbspeculartexture", &PBSpecularTextureDisplayProperties{ID: 3}, 3},
+		{"pbmetallictexture", &PBMetallicTextureDisplayProperties{ID: 4}, 4},
+		{"translucent", &TranslucentDisplayProperties{ID: 5}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Identify(); got != tt.want {
+				t.Errorf("Identify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisplayProperties_Len(t *testing.T) {
+	tests := []struct {
+		name string
+		r    go3mf.PropertyGroup
+		want int
+	}{
+		{"pbspecular", &PBSpecularDisplayProperties{Specular: make([]PBSpecular, 1)}, 1},
+		{"pbmetallic", &PBMetallicDisplayProperties{Metallic: make([]PBMetallic, 2)}, 2},
+		{"translucent", &TranslucentDisplayProperties{Translucent: make([]Translucent, 3)}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Len(); got != tt.want {
+				t.Errorf("Len() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorResolver(t *testing.T) {
+	m := &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+		&go3mf.BaseMaterials{ID: 1, Materials: []go3mf.Base{{Color: color.RGBA{R: 255, A: 255}}}},
+		&ColorGroup{ID: 2, Colors: []color.RGBA{{B: 255, A: 255}, {R: 30, G: 60, B: 90, A: 120}}},
+		&Texture2DGroup{ID: 3},
+	}}}
+	r := NewColorResolver(m, "")
+	tests := []struct {
+		name   string
+		pid    uint32
+		index  uint32
+		want   color.RGBA
+		wantOk bool
+	}{
+		{"none", 0, 0, color.RGBA{}, false},
+		{"base", 1, 0, color.RGBA{R: 255, A: 255}, true},
+		{"baseOutOfRange", 1, 1, color.RGBA{}, false},
+		{"colorGroup", 2, 1, color.RGBA{R: 30, G: 60, B: 90, A: 120}, true},
+		{"texture", 3, 0, color.RGBA{}, false},
+		{"missing", 4, 0, color.RGBA{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := r.Color(tt.pid, tt.index)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("ColorResolver.Color() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+	if got, ok := r.Average(2, [3]uint32{0, 1, 1}); !ok || got != (color.RGBA{R: 20, G: 40, B: 145, A: 165}) {
+		t.Errorf("ColorResolver.Average() = %v, %v", got, ok)
+	}
+	if _, ok := r.Average(2, [3]uint32{0, 1, 2}); ok {
+		t.Error("ColorResolver.Average() resolved an out of range property")
+	}
+}
@@ -19,7 +19,7 @@ func (e *Spec) ValidateObject(_ *go3mf.Model, _ string, _ *go3mf.Object) error {
 func (e *Spec) ValidateAsset(m *go3mf.Model, path string, r go3mf.Asset) (errs error) {
 	switch r := r.(type) {
 	case *ColorGroup:
-		errs = e.validateColorGroup(path, r)
+		errs = e.validateColorGroup(m, path, r)
 	case *Texture2DGroup:
 		errs = e.validateTexture2DGroup(m, path, r)
 	case *Texture2D:
@@ -28,11 +28,26 @@ func (e *Spec) ValidateAsset(m *go3mf.Model, path string, r go3mf.Asset) (errs e
 		errs = e.validateMultiProps(m, path, r)
 	case *CompositeMaterials:
 		errs = e.validateCompositeMat(m, path, r)
+	case *go3mf.BaseMaterials:
+		var id *DisplayPropertiesID
+		if r.AnyAttr.Get(&id) {
+			errs = e.validateDisplayPropsID(m, path, uint32(*id), len(r.Materials))
+		}
+	case *PBSpecularDisplayProperties:
+		errs = e.validatePBSpecular(r)
+	case *PBMetallicDisplayProperties:
+		errs = e.validatePBMetallic(r)
+	case *PBSpecularTextureDisplayProperties:
+		errs = e.validatePBSpecularTexture(m, path, r)
+	case *PBMetallicTextureDisplayProperties:
+		errs = e.validatePBMetallicTexture(m, path, r)
+	case *TranslucentDisplayProperties:
+		errs = e.validateTranslucent(r)
 	}
 	return
 }
 
-func (e *Spec) validateColorGroup(path string, r *ColorGroup) (errs error) {
+func (e *Spec) validateColorGroup(m *go3mf.Model, path string, r *ColorGroup) (errs error) {
 	if r.ID == 0 {
 		errs = errors.Append(errs, errors.ErrMissingID)
 	}
@@ -44,6 +59,7 @@ func (e *Spec) validateColorGroup(path string, r *ColorGroup) (errs error) {
 			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrColor), c, j))
 		}
 	}
+	errs = errors.Append(errs, e.validateDisplayPropsID(m, path, r.DisplayPropertiesID, len(r.Colors)))
 	return
 }
 
@@ -63,6 +79,7 @@ func (e *Spec) validateTexture2DGroup(m *go3mf.Model, path string, r *Texture2DG
 	if len(r.Coords) == 0 {
 		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
 	}
+	errs = errors.Append(errs, e.validateDisplayPropsID(m, path, r.DisplayPropertiesID, len(r.Coords)))
 	return
 }
 
@@ -143,6 +160,7 @@ func (e *Spec) validateMultiProps(m *go3mf.Model, path string, r *MultiPropertie
 			}
 		}
 	}
+	errs = errors.Append(errs, e.validateDisplayPropsID(m, path, r.DisplayPropertiesID, len(r.Multis)))
 	return
 }
 
@@ -172,5 +190,107 @@ func (e *Spec) validateCompositeMat(m *go3mf.Model, path string, r *CompositeMat
 	if len(r.Composites) == 0 {
 		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
 	}
+	errs = errors.Append(errs, e.validateDisplayPropsID(m, path, r.DisplayPropertiesID, len(r.Composites)))
+	return
+}
+
+func (e *Spec) validateDisplayPropsID(m *go3mf.Model, path string, id uint32, count int) error {
+	if id == 0 {
+		return nil
+	}
+	r, ok := m.FindAsset(path, id)
+	if !ok {
+		return errors.ErrDisplayPropsRef
+	}
+	switch r := r.(type) {
+	case *PBSpecularDisplayProperties, *PBMetallicDisplayProperties, *TranslucentDisplayProperties:
+		if r.(go3mf.PropertyGroup).Len() != count {
+			return errors.ErrDisplayPropsCount
+		}
+	case *PBSpecularTextureDisplayProperties, *PBMetallicTextureDisplayProperties:
+	default:
+		return errors.ErrDisplayPropsRef
+	}
+	return nil
+}
+
+func (e *Spec) validatePBSpecular(r *PBSpecularDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if len(r.Specular) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	for j, s := range r.Specular {
+		if s.Name == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrName), s, j))
+		}
+	}
+	return
+}
+
+func (e *Spec) validatePBMetallic(r *PBMetallicDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if len(r.Metallic) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	for j, s := range r.Metallic {
+		if s.Name == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrName), s, j))
+		}
+	}
+	return
+}
+
+func (e *Spec) validateTranslucent(r *TranslucentDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if len(r.Translucent) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	for j, s := range r.Translucent {
+		if s.Name == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrName), s, j))
+		}
+	}
 	return
 }
+
+func (e *Spec) validatePBSpecularTexture(m *go3mf.Model, path string, r *PBSpecularTextureDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.Name == "" {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrName))
+	}
+	errs = errors.Append(errs, e.validateTextureID(m, path, attrSpecularTexID, r.SpecularTextureID))
+	errs = errors.Append(errs, e.validateTextureID(m, path, attrGlossinessTexID, r.GlossinessTextureID))
+	return
+}
+
+func (e *Spec) validatePBMetallicTexture(m *go3mf.Model, path string, r *PBMetallicTextureDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.Name == "" {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrName))
+	}
+	errs = errors.Append(errs, e.validateTextureID(m, path, attrMetallicTexID, r.MetallicTextureID))
+	errs = errors.Append(errs, e.validateTextureID(m, path, attrRoughnessTexID, r.RoughnessTextureID))
+	return
+}
+
+func (e *Spec) validateTextureID(m *go3mf.Model, path, name string, id uint32) error {
+	if id == 0 {
+		return errors.NewMissingFieldError(name)
+	}
+	if text, ok := m.FindAsset(path, id); ok {
+		if _, ok := text.(*Texture2D); ok {
+			return nil
+		}
+	}
+	return errors.ErrTextureReference
+}
@@ -11,6 +11,7 @@ import (
 )
 
 func TestValidate(t *testing.T) {
+	dpID2 := DisplayPropertiesID(2)
 	tests := []struct {
 		name  string
 		model *go3mf.Model
@@ -109,6 +110,35 @@ func TestValidate(t *testing.T) {
 			fmt.Errorf("Resources@CompositeMaterials#4: %v", errors.ErrCompositeBase),
 			fmt.Errorf("Resources@CompositeMaterials#5: %v", errors.ErrMissingResource),
 		}},
+		{"displayProperties", &go3mf.Model{
+			Attachments: []go3mf.Attachment{{Path: "/a.png"}},
+			Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&Texture2D{ID: 1, ContentType: TextureTypePNG, Path: "/a.png"},
+				&PBSpecularDisplayProperties{ID: 2, Specular: []PBSpecular{{Name: "a"}, {}}},
+				&PBMetallicDisplayProperties{ID: 3},
+				&TranslucentDisplayProperties{ID: 4, Translucent: []Translucent{{}}},
+				&PBSpecularTextureDisplayProperties{ID: 5, SpecularTextureID: 1, GlossinessTextureID: 100},
+				&PBMetallicTextureDisplayProperties{ID: 6, Name: "a", RoughnessTextureID: 2},
+				&go3mf.BaseMaterials{ID: 7, Materials: []go3mf.Base{{Name: "a", Color: color.RGBA{R: 1}}}, AnyAttr: go3mf.AttrMarshalers{&dpID2}},
+				&ColorGroup{ID: 8, DisplayPropertiesID: 2, Colors: []color.RGBA{{R: 1}, {G: 1}}},
+				&ColorGroup{ID: 9, DisplayPropertiesID: 4, Colors: []color.RGBA{{R: 1}, {G: 1}}},
+				&ColorGroup{ID: 10, DisplayPropertiesID: 1, Colors: []color.RGBA{{R: 1}}},
+				&ColorGroup{ID: 11, DisplayPropertiesID: 100, Colors: []color.RGBA{{R: 1}}},
+				&Texture2DGroup{ID: 12, TextureID: 1, DisplayPropertiesID: 5, Coords: []TextureCoord{{}}},
+			}},
+		}, []error{
+			fmt.Errorf("Resources@PBSpecularDisplayProperties#1@PBSpecular#1: %v", &errors.MissingFieldError{Name: attrName}),
+			fmt.Errorf("Resources@PBMetallicDisplayProperties#2: %v", errors.ErrEmptyResourceProps),
+			fmt.Errorf("Resources@TranslucentDisplayProperties#3@Translucent#0: %v", &errors.MissingFieldError{Name: attrName}),
+			fmt.Errorf("Resources@PBSpecularTextureDisplayProperties#4: %v", &errors.MissingFieldError{Name: attrName}),
+			fmt.Errorf("Resources@PBSpecularTextureDisplayProperties#4: %v", errors.ErrTextureReference),
+			fmt.Errorf("Resources@PBMetallicTextureDisplayProperties#5: %v", &errors.MissingFieldError{Name: attrMetallicTexID}),
+			fmt.Errorf("Resources@PBMetallicTextureDisplayProperties#5: %v", errors.ErrTextureReference),
+			fmt.Errorf("Resources@BaseMaterials#6: %v", errors.ErrDisplayPropsCount),
+			fmt.Errorf("Resources@ColorGroup#8: %v", errors.ErrDisplayPropsCount),
+			fmt.Errorf("Resources@ColorGroup#9: %v", errors.ErrDisplayPropsRef),
+			fmt.Errorf("Resources@ColorGroup#10: %v", errors.ErrDisplayPropsRef),
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -110,6 +110,14 @@ func (o *opcReader) Relationships() []Relationship {
 	return newRelationships(o.r.Relationships)
 }
 
+func (o *opcReader) Files() []packageFile {
+	files := make([]packageFile, len(o.r.Files))
+	for i, f := range o.r.Files {
+		files[i] = &opcFile{o.r, f}
+	}
+	return files
+}
+
 func (o *opcReader) FindFileFromName(name string) (packageFile, bool) {
 	return findOPCFileFromName(name, o.r)
 }
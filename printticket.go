@@ -0,0 +1,528 @@
+package go3mf
+
+import (
+	"encoding/xml"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	// NamespacePrintSchema is the canonical name of the print schema framework.
+	NamespacePrintSchema = "http://schemas.microsoft.com/windows/2003/08/printing/printschemaframework"
+	// NamespacePrintKeywords is the canonical name of the print schema keywords.
+	NamespacePrintKeywords = "http://schemas.microsoft.com/windows/2003/08/printing/printschemakeywords"
+	// NamespacePrintKeywords3D is the canonical name of the 3D print schema keywords.
+	NamespacePrintKeywords3D = "http://schemas.microsoft.com/3dmanufacturing/2013/01/printschemakeywords3d"
+
+	nsXSI = "http://www.w3.org/2001/XMLSchema-instance"
+	nsXSD = "http://www.w3.org/2001/XMLSchema"
+)
+
+// Common print ticket keywords.
+var (
+	PrintJobCopies          = xml.Name{Space: NamespacePrintKeywords, Local: "JobCopiesAllDocuments"}
+	PrintJob3DOutputQuality = xml.Name{Space: NamespacePrintKeywords3D, Local: "Job3DOutputQuality"}
+	PrintJob3DDensity       = xml.Name{Space: NamespacePrintKeywords3D, Local: "Job3DDensity"}
+	PrintJob3DSupports      = xml.Name{Space: NamespacePrintKeywords3D, Local: "Job3DSupports"}
+	PrintJob3DRaft          = xml.Name{Space: NamespacePrintKeywords3D, Local: "Job3DRaft"}
+)
+
+// Print schema value types.
+const (
+	PrintValueInteger = "integer"
+	PrintValueDecimal = "decimal"
+	PrintValueString  = "string"
+	PrintValueQName   = "QName"
+)
+
+// A PrintTicket is an in memory representation of a PrintSchema print ticket.
+// It implements xml.Marshaler and xml.Unmarshaler, so it can be
+// read and written using the encoding/xml package.
+type PrintTicket struct {
+	Version    int
+	Features   []*PrintFeature
+	Parameters []*PrintParameter
+	Properties []*PrintProperty
+}
+
+// Feature returns the top level feature with the target name.
+func (t *PrintTicket) Feature(name xml.Name) (*PrintFeature, bool) {
+	return findPrintFeature(t.Features, name)
+}
+
+// FeatureOption returns the name of the option selected for the top level feature.
+func (t *PrintTicket) FeatureOption(name xml.Name) (xml.Name, bool) {
+	if f, ok := t.Feature(name); ok && len(f.Options) > 0 {
+		return f.Options[0].Name, true
+	}
+	return xml.Name{}, false
+}
+
+// SetFeatureOption selects the option for the top level feature,
+// adding the feature if it does not exist.
+func (t *PrintTicket) SetFeatureOption(name, option xml.Name) {
+	f, ok := t.Feature(name)
+	if !ok {
+		f = &PrintFeature{Name: name}
+		t.Features = append(t.Features, f)
+	}
+	f.Options = []*PrintOption{{Name: option}}
+}
+
+// Parameter returns the parameter initializer with the target name.
+func (t *PrintTicket) Parameter(name xml.Name) (*PrintParameter, bool) {
+	for _, p := range t.Parameters {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// SetParameter sets the value of the parameter initializer,
+// adding the parameter if it does not exist.
+func (t *PrintTicket) SetParameter(name xml.Name, value PrintValue) {
+	if p, ok := t.Parameter(name); ok {
+		p.Value = value
+		return
+	}
+	t.Parameters = append(t.Parameters, &PrintParameter{Name: name, Value: value})
+}
+
+// Copies returns the number of copies of the job.
+func (t *PrintTicket) Copies() (int, bool) {
+	if p, ok := t.Parameter(PrintJobCopies); ok {
+		if n, err := p.Value.Int(); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// SetCopies sets the number of copies of the job.
+func (t *PrintTicket) SetCopies(n int) {
+	t.SetParameter(PrintJobCopies, PrintValue{Type: PrintValueInteger, Text: strconv.Itoa(n)})
+}
+
+// A PrintFeature defines a print ticket feature and its selected options.
+type PrintFeature struct {
+	Name       xml.Name
+	Options    []*PrintOption
+	Features   []*PrintFeature
+	Properties []*PrintProperty
+}
+
+// Feature returns the sub feature with the target name.
+func (f *PrintFeature) Feature(name xml.Name) (*PrintFeature, bool) {
+	return findPrintFeature(f.Features, name)
+}
+
+// A PrintOption defines one of the options of a feature.
+// The name is optional.
+type PrintOption struct {
+	Name             xml.Name
+	Properties       []*PrintProperty
+	ScoredProperties []*PrintScoredProperty
+}
+
+// ScoredProperty returns the scored property with the target name.
+func (o *PrintOption) ScoredProperty(name xml.Name) (*PrintScoredProperty, bool) {
+	for _, p := range o.ScoredProperties {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// A PrintScoredProperty defines a property used to select an option.
+// Its value is either a Value or a reference to a parameter.
+type PrintScoredProperty struct {
+	Name             xml.Name
+	Value            *PrintValue
+	ParameterRef     xml.Name
+	ScoredProperties []*PrintScoredProperty
+	Properties       []*PrintProperty
+}
+
+// A PrintProperty defines a print schema property.
+type PrintProperty struct {
+	Name       xml.Name
+	Value      *PrintValue
+	Properties []*PrintProperty
+}
+
+// A PrintParameter defines a parameter initializer.
+type PrintParameter struct {
+	Name  xml.Name
+	Value PrintValue
+}
+
+// PrintValue defines a typed print schema value.
+// Type is the XML schema type name, such as integer, decimal or string.
+// QName values are stored resolved in Name, the other types use Text.
+type PrintValue struct {
+	Type string
+	Text string
+	Name xml.Name
+}
+
+// Int returns the value as an integer.
+func (v *PrintValue) Int() (int, error) {
+	return strconv.Atoi(strings.TrimSpace(v.Text))
+}
+
+// Float returns the value as a float.
+func (v *PrintValue) Float() (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(v.Text), 64)
+}
+
+func findPrintFeature(features []*PrintFeature, name xml.Name) (*PrintFeature, bool) {
+	for _, f := range features {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// UnmarshalXML decodes a PrintTicket element.
+func (t *PrintTicket) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	root, err := readPrintNode(d, start, nil)
+	if err != nil {
+		return err
+	}
+	if !root.is("PrintTicket") {
+		return errors.New("print ticket root element must be a PrintSchema PrintTicket")
+	}
+	*t = PrintTicket{}
+	if v, ok := root.attr("version"); ok {
+		t.Version, _ = strconv.Atoi(v)
+	}
+	for _, c := range root.children {
+		switch {
+		case c.is("Feature"):
+			t.Features = append(t.Features, c.feature())
+		case c.is("ParameterInit"):
+			p := &PrintParameter{Name: c.name()}
+			if v, ok := c.child("Value"); ok {
+				p.Value = v.value()
+			}
+			t.Parameters = append(t.Parameters, p)
+		case c.is("Property"):
+			t.Properties = append(t.Properties, c.property())
+		}
+	}
+	return nil
+}
+
+// MarshalXML encodes the PrintTicket element.
+func (t *PrintTicket) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	w := &printWriter{prefixes: map[string]string{
+		NamespacePrintSchema: "psf", nsXSI: "xsi", nsXSD: "xsd",
+	}, spaces: []string{NamespacePrintSchema, nsXSI, nsXSD}}
+	w.writeTicket(t)
+	w.e, w.attrs = e, make([]xml.Attr, 0, len(w.spaces))
+	for _, ns := range w.spaces {
+		w.attrs = append(w.attrs, xml.Attr{Name: xml.Name{Local: attrXmlns + ":" + w.prefixes[ns]}, Value: ns})
+	}
+	w.writeTicket(t)
+	if w.err != nil {
+		return w.err
+	}
+	return e.Flush()
+}
+
+type printScope struct {
+	parent *printScope
+	spaces map[string]string
+}
+
+func (s *printScope) resolve(qname string) xml.Name {
+	prefix, local := "", qname
+	if i := strings.IndexByte(qname, ':'); i >= 0 {
+		prefix, local = qname[:i], qname[i+1:]
+	}
+	for sc := s; sc != nil; sc = sc.parent {
+		if ns, ok := sc.spaces[prefix]; ok {
+			return xml.Name{Space: ns, Local: local}
+		}
+	}
+	return xml.Name{Local: local}
+}
+
+type printNode struct {
+	xmlName  xml.Name
+	attrs    []xml.Attr
+	text     strings.Builder
+	children []*printNode
+	scope    *printScope
+}
+
+func readPrintNode(d *xml.Decoder, start xml.StartElement, parent *printScope) (*printNode, error) {
+	n := &printNode{xmlName: start.Name, scope: &printScope{parent: parent, spaces: make(map[string]string)}}
+	for _, a := range start.Attr {
+		if a.Name.Space == attrXmlns {
+			n.scope.spaces[a.Name.Local] = a.Value
+		} else if a.Name.Space == "" && a.Name.Local == attrXmlns {
+			n.scope.spaces[""] = a.Value
+		} else {
+			n.attrs = append(n.attrs, a)
+		}
+	}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			c, err := readPrintNode(d, tok, n.scope)
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, c)
+		case xml.CharData:
+			n.text.Write(tok)
+		case xml.EndElement:
+			return n, nil
+		}
+	}
+}
+
+func (n *printNode) is(local string) bool {
+	return n.xmlName.Space == NamespacePrintSchema && n.xmlName.Local == local
+}
+
+func (n *printNode) attr(local string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.Name.Space == "" && a.Name.Local == local {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+func (n *printNode) name() xml.Name {
+	if v, ok := n.attr(attrName); ok {
+		return n.scope.resolve(strings.TrimSpace(v))
+	}
+	return xml.Name{}
+}
+
+func (n *printNode) child(local string) (*printNode, bool) {
+	for _, c := range n.children {
+		if c.is(local) {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+func (n *printNode) feature() *PrintFeature {
+	f := &PrintFeature{Name: n.name()}
+	for _, c := range n.children {
+		switch {
+		case c.is("Feature"):
+			f.Features = append(f.Features, c.feature())
+		case c.is("Option"):
+			f.Options = append(f.Options, c.option())
+		case c.is("Property"):
+			f.Properties = append(f.Properties, c.property())
+		}
+	}
+	return f
+}
+
+func (n *printNode) option() *PrintOption {
+	o := &PrintOption{Name: n.name()}
+	for _, c := range n.children {
+		switch {
+		case c.is("ScoredProperty"):
+			o.ScoredProperties = append(o.ScoredProperties, c.scoredProperty())
+		case c.is("Property"):
+			o.Properties = append(o.Properties, c.property())
+		}
+	}
+	return o
+}
+
+func (n *printNode) scoredProperty() *PrintScoredProperty {
+	p := &PrintScoredProperty{Name: n.name()}
+	for _, c := range n.children {
+		switch {
+		case c.is("Value"):
+			v := c.value()
+			p.Value = &v
+		case c.is("ParameterRef"):
+			p.ParameterRef = c.name()
+		case c.is("ScoredProperty"):
+			p.ScoredProperties = append(p.ScoredProperties, c.scoredProperty())
+		case c.is("Property"):
+			p.Properties = append(p.Properties, c.property())
+		}
+	}
+	return p
+}
+
+func (n *printNode) property() *PrintProperty {
+	p := &PrintProperty{Name: n.name()}
+	for _, c := range n.children {
+		switch {
+		case c.is("Value"):
+			v := c.value()
+			p.Value = &v
+		case c.is("Property"):
+			p.Properties = append(p.Properties, c.property())
+		}
+	}
+	return p
+}
+
+func (n *printNode) value() PrintValue {
+	var v PrintValue
+	for _, a := range n.attrs {
+		if a.Name.Space == nsXSI && a.Name.Local == attrType {
+			v.Type = n.scope.resolve(strings.TrimSpace(a.Value)).Local
+		}
+	}
+	if v.Type == PrintValueQName {
+		v.Name = n.scope.resolve(strings.TrimSpace(n.text.String()))
+	} else {
+		v.Text = n.text.String()
+	}
+	return v
+}
+
+// printWriter writes a print ticket in two passes,
+// the first one only collects the namespaces when e is nil.
+type printWriter struct {
+	e        *xml.Encoder
+	err      error
+	attrs    []xml.Attr
+	prefixes map[string]string
+	spaces   []string
+}
+
+func (w *printWriter) qname(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	prefix, ok := w.prefixes[name.Space]
+	if !ok {
+		switch name.Space {
+		case NamespacePrintKeywords:
+			prefix = "psk"
+		case NamespacePrintKeywords3D:
+			prefix = "psk3d"
+		default:
+			prefix = "ns" + strconv.Itoa(len(w.spaces))
+		}
+		w.prefixes[name.Space] = prefix
+		w.spaces = append(w.spaces, name.Space)
+	}
+	return prefix + ":" + name.Local
+}
+
+func (w *printWriter) start(local string, name xml.Name, attrs ...xml.Attr) {
+	if name.Local != "" {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: attrName}, Value: w.qname(name)})
+	}
+	if w.e != nil && w.err == nil {
+		w.err = w.e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "psf:" + local}, Attr: attrs})
+	}
+}
+
+func (w *printWriter) end(local string) {
+	if w.e != nil && w.err == nil {
+		w.err = w.e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "psf:" + local}})
+	}
+}
+
+func (w *printWriter) writeTicket(t *PrintTicket) {
+	version := t.Version
+	if version == 0 {
+		version = 1
+	}
+	attrs := append(w.attrs, xml.Attr{Name: xml.Name{Local: "version"}, Value: strconv.Itoa(version)})
+	w.start("PrintTicket", xml.Name{}, attrs...)
+	for _, f := range t.Features {
+		w.writeFeature(f)
+	}
+	for _, p := range t.Parameters {
+		w.start("ParameterInit", p.Name)
+		w.writeValue(&p.Value)
+		w.end("ParameterInit")
+	}
+	for _, p := range t.Properties {
+		w.writeProperty(p)
+	}
+	w.end("PrintTicket")
+}
+
+func (w *printWriter) writeFeature(f *PrintFeature) {
+	w.start("Feature", f.Name)
+	for _, p := range f.Properties {
+		w.writeProperty(p)
+	}
+	for _, o := range f.Options {
+		w.start("Option", o.Name)
+		for _, p := range o.Properties {
+			w.writeProperty(p)
+		}
+		for _, p := range o.ScoredProperties {
+			w.writeScoredProperty(p)
+		}
+		w.end("Option")
+	}
+	for _, sf := range f.Features {
+		w.writeFeature(sf)
+	}
+	w.end("Feature")
+}
+
+func (w *printWriter) writeScoredProperty(p *PrintScoredProperty) {
+	w.start("ScoredProperty", p.Name)
+	if p.Value != nil {
+		w.writeValue(p.Value)
+	}
+	if p.ParameterRef.Local != "" {
+		w.start("ParameterRef", p.ParameterRef)
+		w.end("ParameterRef")
+	}
+	for _, sp := range p.ScoredProperties {
+		w.writeScoredProperty(sp)
+	}
+	for _, sp := range p.Properties {
+		w.writeProperty(sp)
+	}
+	w.end("ScoredProperty")
+}
+
+func (w *printWriter) writeProperty(p *PrintProperty) {
+	w.start("Property", p.Name)
+	if p.Value != nil {
+		w.writeValue(p.Value)
+	}
+	for _, sp := range p.Properties {
+		w.writeProperty(sp)
+	}
+	w.end("Property")
+}
+
+func (w *printWriter) writeValue(v *PrintValue) {
+	text := v.Text
+	if v.Type == PrintValueQName {
+		text = w.qname(v.Name)
+	}
+	var attrs []xml.Attr
+	if v.Type != "" {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "xsi:type"}, Value: "xsd:" + v.Type})
+	}
+	w.start("Value", xml.Name{}, attrs...)
+	if w.e != nil && w.err == nil && text != "" {
+		w.err = w.e.EncodeToken(xml.CharData(text))
+	}
+	w.end("Value")
+}
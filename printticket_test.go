@@ -0,0 +1,141 @@
+package go3mf
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+const testPrintTicket = `<?xml version="1.0" encoding="UTF-8"?>
+<psf:PrintTicket xmlns:psf="http://schemas.microsoft.com/windows/2003/08/printing/printschemaframework"
+	xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:xsd="http://www.w3.org/2001/XMLSchema"
+	xmlns:psk="http://schemas.microsoft.com/windows/2003/08/printing/printschemakeywords"
+	xmlns:psk3d="http://schemas.microsoft.com/3dmanufacturing/2013/01/printschemakeywords3d" version="1">
+	<psf:Feature name="psk3d:Job3DOutputQuality">
+		<psf:Option name="psk3d:High"/>
+	</psf:Feature>
+	<psf:Feature xmlns:v="http://vendor.com/pt" name="v:Nozzle">
+		<psf:Property name="psf:SelectionType"><psf:Value xsi:type="xsd:QName">psk:PickOne</psf:Value></psf:Property>
+		<psf:Option>
+			<psf:ScoredProperty name="v:Diameter"><psf:Value xsi:type="xsd:decimal">0.4</psf:Value></psf:ScoredProperty>
+			<psf:ScoredProperty name="v:Temperature"><psf:ParameterRef name="v:NozzleTemperature"/></psf:ScoredProperty>
+		</psf:Option>
+		<psf:Feature name="v:Retraction"><psf:Option name="v:On"/></psf:Feature>
+	</psf:Feature>
+	<psf:ParameterInit name="psk:JobCopiesAllDocuments"><psf:Value xsi:type="xsd:integer">2</psf:Value></psf:ParameterInit>
+	<psf:Property name="psk:DisplayName"><psf:Value xsi:type="xsd:string">job</psf:Value></psf:Property>
+</psf:PrintTicket>`
+
+func testPrintTicketModel() *PrintTicket {
+	vendor := func(local string) xml.Name { return xml.Name{Space: "http://vendor.com/pt", Local: local} }
+	return &PrintTicket{
+		Version: 1,
+		Features: []*PrintFeature{
+			{Name: PrintJob3DOutputQuality, Options: []*PrintOption{{Name: xml.Name{Space: NamespacePrintKeywords3D, Local: "High"}}}},
+			{
+				Name: vendor("Nozzle"),
+				Properties: []*PrintProperty{{
+					Name:  xml.Name{Space: NamespacePrintSchema, Local: "SelectionType"},
+					Value: &PrintValue{Type: PrintValueQName, Name: xml.Name{Space: NamespacePrintKeywords, Local: "PickOne"}},
+				}},
+				Options: []*PrintOption{{ScoredProperties: []*PrintScoredProperty{
+					{Name: vendor("Diameter"), Value: &PrintValue{Type: PrintValueDecimal, Text: "0.4"}},
+					{Name: vendor("Temperature"), ParameterRef: vendor("NozzleTemperature")},
+				}}},
+				Features: []*PrintFeature{{Name: vendor("Retraction"), Options: []*PrintOption{{Name: vendor("On")}}}},
+			},
+		},
+		Parameters: []*PrintParameter{{Name: PrintJobCopies, Value: PrintValue{Type: PrintValueInteger, Text: "2"}}},
+		Properties: []*PrintProperty{{
+			Name: xml.Name{Space: NamespacePrintKeywords, Local: "DisplayName"}, Value: &PrintValue{Type: PrintValueString, Text: "job"},
+		}},
+	}
+}
+
+func TestPrintTicket_UnmarshalXML(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *PrintTicket
+		wantErr bool
+	}{
+		{"base", testPrintTicket, testPrintTicketModel(), false},
+		{"empty", `<PrintTicket xmlns="http://schemas.microsoft.com/windows/2003/08/printing/printschemaframework"/>`, new(PrintTicket), false},
+		{"invalidRoot", `<psf:Feature xmlns:psf="http://schemas.microsoft.com/windows/2003/08/printing/printschemaframework"/>`, nil, true},
+		{"malformed", `<psf:PrintTicket xmlns:psf="http://schemas.microsoft.com/windows/2003/08/printing/printschemaframework">`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(PrintTicket)
+			err := xml.Unmarshal([]byte(tt.data), got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PrintTicket.UnmarshalXML() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("PrintTicket.UnmarshalXML() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestPrintTicket_MarshalXML(t *testing.T) {
+	want := testPrintTicketModel()
+	b, err := xml.Marshal(want)
+	if err != nil {
+		t.Errorf("PrintTicket.MarshalXML() error = %v", err)
+		return
+	}
+	got := new(PrintTicket)
+	if err := xml.Unmarshal(b, got); err != nil {
+		t.Errorf("PrintTicket.MarshalXML() malformed = %v, s = %s", err, b)
+		return
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("PrintTicket.MarshalXML() = %v, s = %s", diff, b)
+	}
+}
+
+func TestPrintTicket_Helpers(t *testing.T) {
+	pt := new(PrintTicket)
+	if _, ok := pt.Copies(); ok {
+		t.Error("PrintTicket.Copies() want false")
+	}
+	if _, ok := pt.FeatureOption(PrintJob3DDensity); ok {
+		t.Error("PrintTicket.FeatureOption() want false")
+	}
+	pt.SetCopies(3)
+	pt.SetCopies(4)
+	if n, ok := pt.Copies(); !ok || n != 4 {
+		t.Errorf("PrintTicket.Copies() = %v, %v", n, ok)
+	}
+	solid := xml.Name{Space: NamespacePrintKeywords3D, Local: "Solid"}
+	pt.SetFeatureOption(PrintJob3DDensity, xml.Name{Space: NamespacePrintKeywords3D, Local: "Low"})
+	pt.SetFeatureOption(PrintJob3DDensity, solid)
+	if got, ok := pt.FeatureOption(PrintJob3DDensity); !ok || got != solid {
+		t.Errorf("PrintTicket.FeatureOption() = %v, %v", got, ok)
+	}
+	want := &PrintTicket{
+		Features:   []*PrintFeature{{Name: PrintJob3DDensity, Options: []*PrintOption{{Name: solid}}}},
+		Parameters: []*PrintParameter{{Name: PrintJobCopies, Value: PrintValue{Type: PrintValueInteger, Text: "4"}}},
+	}
+	if diff := deep.Equal(pt, want); diff != nil {
+		t.Errorf("PrintTicket helpers = %v", diff)
+	}
+	nozzle, _ := testPrintTicketModel().Feature(xml.Name{Space: "http://vendor.com/pt", Local: "Nozzle"})
+	if _, ok := nozzle.Feature(xml.Name{Space: "http://vendor.com/pt", Local: "Retraction"}); !ok {
+		t.Error("PrintFeature.Feature() want true")
+	}
+	p, ok := nozzle.Options[0].ScoredProperty(xml.Name{Space: "http://vendor.com/pt", Local: "Diameter"})
+	if !ok {
+		t.Error("PrintOption.ScoredProperty() want true")
+		return
+	}
+	if f, err := p.Value.Float(); err != nil || f != 0.4 {
+		t.Errorf("PrintValue.Float() = %v, %v", f, err)
+	}
+}
@@ -29,6 +29,7 @@ type packageReader interface {
 	Open(func(r io.Reader) io.ReadCloser) error
 	FindFileFromName(string) (packageFile, bool)
 	Relationships() []Relationship
+	Files() []packageFile
 }
 
 // ReadCloser wrapps a Decoder than can be closed.
@@ -70,12 +71,13 @@ func (d *topLevelDecoder) Child(name xml.Name) (child NodeDecoder) {
 	return
 }
 
-func decodeModelFile(ctx context.Context, r io.Reader, model *Model, path string, isRoot, strict bool) (*Scanner, error) {
+func decodeModelFile(ctx context.Context, r io.Reader, model *Model, path string, isRoot, strict, preserve bool) (*Scanner, error) {
 	x := xml3mf.NewDecoder(r)
 	scanner := Scanner{
 		extensionDecoder: make(map[string]SpecDecoder),
 		IsRoot:           isRoot,
 		ModelPath:        path,
+		preserve:         preserve,
 	}
 	for _, ext := range model.Specs {
 		if ext, ok := ext.(SpecDecoder); ok {
@@ -94,6 +96,9 @@ func decodeModelFile(ctx context.Context, r io.Reader, model *Model, path string
 	var err error
 	x.OnStart = func(tp xml.StartElement) {
 		tmpDecoder = currentDecoder.Child(tp.Name)
+		if tmpDecoder == nil && scanner.preserve && scanner.isUnknownSpace(tp.Name.Space) {
+			tmpDecoder = newUnknownDecoder(append(state, currentDecoder), tp.Name)
+		}
 		if tmpDecoder != nil {
 			tmpDecoder.SetScanner(&scanner)
 			state = append(state, currentDecoder)
@@ -145,17 +150,37 @@ func decodeModelFile(ctx context.Context, r io.Reader, model *Model, path string
 }
 
 // Decoder implements a 3mf file decoder.
+//
+// If PreserveUnknown is true, the elements and attributes
+// that do not belong to any registered extension are kept as
+// UnknownTokens and UnknownAttrs in the node that contains them,
+// so they can be encoded back.
+//
+// The parts targeted by a must preserve relationship are always kept
+// as attachments, together with the parts they reference. If PreserveUnreferenced
+// is true all the other parts of the package are also kept as attachments.
+// The digital signatures are also kept, so the Encoder can write them back.
+//
+// If Decrypter is not nil the encrypted parts are transparently decrypted.
 type Decoder struct {
-	Strict        bool
-	p             packageReader
-	flate         func(r io.Reader) io.ReadCloser
-	nonRootModels []packageFile
+	Strict               bool
+	PreserveUnknown      bool
+	PreserveUnreferenced bool
+	Decrypter            PartDecrypter
+	p                    packageReader
+	ra                   io.ReaderAt
+	size                 int64
+	flate                func(r io.Reader) io.ReadCloser
+	nonRootModels        []packageFile
+	ticketParts          []packageFile
 }
 
 // NewDecoder returns a new Decoder reading a 3mf file from r.
 func NewDecoder(r io.ReaderAt, size int64) *Decoder {
 	return &Decoder{
 		p:      &opcReader{ra: r, size: size},
+		ra:     r,
+		size:   size,
 		Strict: true,
 	}
 }
@@ -191,7 +216,7 @@ func (d *Decoder) processRootModel(ctx context.Context, rootFile packageFile, mo
 		return err
 	}
 	defer f.Close()
-	scanner, err := decodeModelFile(ctx, f, model, rootFile.Name(), true, d.Strict)
+	scanner, err := decodeModelFile(ctx, f, model, rootFile.Name(), true, d.Strict, d.PreserveUnknown)
 	if err != nil {
 		return err
 	}
@@ -211,6 +236,37 @@ func (d *Decoder) addModelFile(p *Scanner, model *Model) {
 		model.Build.Items = append(model.Build.Items, bi)
 	}
 	model.Resources = p.Resources
+	for _, child := range model.Childs {
+		removeSpecNamespaces(model, child)
+	}
+}
+
+// removeSpecNamespaces removes the preserved namespace declarations
+// of the child model that are already declared by the model specs
+// or that belong to the core triangle sets.
+func removeSpecNamespaces(model *Model, child *ChildModel) {
+	var attrs *UnknownAttrs
+	if !child.AnyAttr.Get(&attrs) {
+		return
+	}
+	kept := (*attrs)[:0]
+	for _, a := range *attrs {
+		if _, ok := model.Specs[a.Value]; (!ok && a.Value != NamespaceTriangleSets) || a.Name.Space != attrXmlns {
+			kept = append(kept, a)
+		}
+	}
+	*attrs = kept
+	if len(kept) == 0 {
+		anyAttr := child.AnyAttr[:0]
+		for _, a := range child.AnyAttr {
+			if a != AttrMarshaler(attrs) {
+				anyAttr = append(anyAttr, a)
+			}
+		}
+		if child.AnyAttr = anyAttr; len(anyAttr) == 0 {
+			child.AnyAttr = nil
+		}
+	}
 }
 
 func (d *Decoder) processNonRootModels(ctx context.Context, model *Model) (err error) {
@@ -256,9 +312,22 @@ func (d *Decoder) processNonRootModels(ctx context.Context, model *Model) (err e
 }
 
 func (d *Decoder) processOPC(model *Model) (packageFile, error) {
+	// The parts found by a previous Decode must not leak into this one.
+	d.nonRootModels, d.ticketParts = nil, nil
+	if p, ok := d.p.(*decryptedPackage); ok {
+		// Already wrapped by a previous Decode.
+		d.p = p.packageReader
+	}
 	if err := d.p.Open(d.flate); err != nil {
 		return nil, err
 	}
+	if d.Decrypter != nil {
+		p, err := newDecryptedPackage(d.p, d.Decrypter)
+		if err != nil {
+			return nil, err
+		}
+		d.p = p
+	}
 	var rootFile packageFile
 	for _, r := range d.p.Relationships() {
 		if r.Type == RelType3DModel {
@@ -280,9 +349,76 @@ func (d *Decoder) processOPC(model *Model) (packageFile, error) {
 	if rootFile == nil {
 		return nil, errors.New("package does not have root model")
 	}
+	d.extractPreservedParts(rootFile, model)
 	return rootFile, nil
 }
 
+// extractPreservedParts adds the must preserve parts and, if PreserveUnreferenced is true,
+// the parts not referenced by the models as attachments with their relationships.
+func (d *Decoder) extractPreservedParts(rootFile packageFile, model *Model) {
+	visited := make(map[string]struct{})
+	for _, r := range d.p.Relationships() {
+		if r.Type == RelTypeMustPreserve || r.Type == RelTypeSignatureOrigin {
+			if file, ok := d.p.FindFileFromName(r.Path); ok {
+				d.preservePart(rootFile, file, model, visited)
+			}
+		}
+	}
+	for _, modelFile := range append([]packageFile{rootFile}, d.nonRootModels...) {
+		for _, r := range modelFile.Relationships() {
+			if r.Type == RelTypeMustPreserve {
+				if file, ok := modelFile.FindFileFromName(r.Path); ok {
+					d.preservePart(rootFile, file, model, visited)
+				}
+			}
+		}
+	}
+	if d.PreserveUnreferenced {
+		for _, file := range d.p.Files() {
+			d.preservePart(rootFile, file, model, visited)
+		}
+	}
+}
+
+func (d *Decoder) preservePart(rootFile, file packageFile, model *Model, visited map[string]struct{}) {
+	name := strings.ToLower(file.Name())
+	if _, ok := visited[name]; ok || d.isDecodedPart(rootFile, file) {
+		return
+	}
+	visited[name] = struct{}{}
+	model.Attachments = d.addAttachment(model.Attachments, file)
+	var rels []Relationship
+	for _, r := range file.Relationships() {
+		if target, ok := file.FindFileFromName(r.Path); ok {
+			rels = append(rels, r)
+			d.preservePart(rootFile, target, model, visited)
+		}
+	}
+	if len(rels) == 0 {
+		return
+	}
+	for i := range model.Attachments {
+		if strings.EqualFold(model.Attachments[i].Path, file.Name()) {
+			model.Attachments[i].Relationships = rels
+			break
+		}
+	}
+}
+
+func (d *Decoder) isDecodedPart(rootFile, file packageFile) bool {
+	if strings.EqualFold(file.Name(), rootFile.Name()) {
+		return true
+	}
+	for _, files := range [][]packageFile{d.nonRootModels, d.ticketParts} {
+		for _, f := range files {
+			if strings.EqualFold(file.Name(), f.Name()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (d *Decoder) extractCoreAttachments(modelFile packageFile, model *Model, isRoot bool) {
 	for _, rel := range modelFile.Relationships() {
 		if file, ok := modelFile.FindFileFromName(rel.Path); ok {
@@ -293,20 +429,46 @@ func (d *Decoder) extractCoreAttachments(modelFile packageFile, model *Model, is
 						model.Childs = make(map[string]*ChildModel)
 					}
 					model.Childs[file.Name()] = new(ChildModel)
+				} else if ticket, ok := d.readPrintTicket(rel, file, model.PrintTicket); ok {
+					model.PrintTicket = ticket
 				} else {
 					model.Attachments = d.addAttachment(model.Attachments, file)
 					model.Relationships = append(model.Relationships, rel)
 				}
 			} else if rel.Type != RelType3DModel {
 				if child, ok := model.Childs[modelFile.Name()]; ok {
-					model.Attachments = d.addAttachment(model.Attachments, file)
-					child.Relationships = append(child.Relationships, rel)
+					if ticket, ok := d.readPrintTicket(rel, file, child.PrintTicket); ok {
+						child.PrintTicket = ticket
+					} else {
+						model.Attachments = d.addAttachment(model.Attachments, file)
+						child.Relationships = append(child.Relationships, rel)
+					}
 				}
 			}
 		}
 	}
 }
 
+// readPrintTicket decodes the print ticket targeted by rel.
+// Tickets that cannot be decoded or that would replace
+// the current ticket are kept as plain attachments.
+func (d *Decoder) readPrintTicket(rel Relationship, file packageFile, current *PrintTicket) (*PrintTicket, bool) {
+	if rel.Type != RelTypePrintTicket || current != nil {
+		return nil, false
+	}
+	f, err := file.Open()
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+	ticket := new(PrintTicket)
+	if err := xml.NewDecoder(f).Decode(ticket); err != nil {
+		return nil, false
+	}
+	d.ticketParts = append(d.ticketParts, file)
+	return ticket, true
+}
+
 func (d *Decoder) addAttachment(attachments []Attachment, file packageFile) []Attachment {
 	for _, att := range attachments {
 		if strings.EqualFold(att.Path, file.Name()) {
@@ -330,7 +492,7 @@ func (d *Decoder) readChildModel(ctx context.Context, i int, model *Model) (*Sca
 		return nil, err
 	}
 	defer file.Close()
-	scanner, err := decodeModelFile(ctx, file, model, attachment.Name(), false, d.Strict)
+	scanner, err := decodeModelFile(ctx, file, model, attachment.Name(), false, d.Strict, d.PreserveUnknown)
 	return scanner, err
 }
 
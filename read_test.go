@@ -201,12 +201,13 @@ type mockPackage struct {
 	mock.Mock
 }
 
-func newMockPackage(other *mockFile) *mockPackage {
+func newMockPackage(other *mockFile, files ...packageFile) *mockPackage {
 	m := new(mockPackage)
 	m.On("Open", mock.Anything).Return(nil).Maybe()
 	m.On("Create", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	m.On("Relationships").Return([]Relationship{{Path: DefaultModelPath, Type: RelType3DModel}}).Maybe()
 	m.On("FindFileFromName", mock.Anything).Return(other, other != nil).Maybe()
+	m.On("Files").Return(files).Maybe()
 	return m
 }
 
@@ -239,9 +240,19 @@ func (m *mockPackage) FindFileFromName(args0 string) (packageFile, bool) {
 	return args.Get(0).(packageFile), args.Bool(1)
 }
 
+func (m *mockPackage) Files() []packageFile {
+	args := m.Called()
+	return args.Get(0).([]packageFile)
+}
+
 func TestDecoder_processOPC(t *testing.T) {
 	extType := "fake_type"
 	otherModel := newMockFile("/other.model", nil, nil, false)
+	preserved := newMockFile("/a.bin", []Relationship{{Type: extType, Path: "/b.bin"}}, newMockFile("/b.bin", nil, nil, false), false)
+	rootModel := newMockFile("/a.model", nil, nil, false)
+	ticket := new(mockFile)
+	ticket.On("Name").Return("/pt.xml").Maybe()
+	ticket.On("Open").Return(ioutil.NopCloser(bytes.NewBufferString(testPrintTicket)), nil).Once()
 	tests := []struct {
 		name    string
 		d       *Decoder
@@ -264,6 +275,9 @@ func TestDecoder_processOPC(t *testing.T) {
 			Relationships: []Relationship{{Path: "/pc.png", Type: RelTypePrintTicket}},
 			Attachments:   []Attachment{{Path: "/pc.png", Stream: new(bytes.Buffer)}},
 		}, false},
+		{"withTypedPrintTicket", &Decoder{
+			p: newMockPackage(newMockFile("/a.model", []Relationship{{Type: RelTypePrintTicket, Path: "/pt.xml"}}, ticket, false)),
+		}, &Model{Path: "/a.model", PrintTicket: testPrintTicketModel()}, false},
 		{"withExtRel", &Decoder{
 			p: newMockPackage(newMockFile("/a.model", []Relationship{{Type: extType, Path: "/other.png"}}, newMockFile("/other.png", nil, nil, false), false)),
 		}, &Model{
@@ -277,6 +291,25 @@ func TestDecoder_processOPC(t *testing.T) {
 		{"withModelAttachment", &Decoder{
 			p: newMockPackage(newMockFile("/a.model", []Relationship{{Type: RelType3DModel, Path: "/other.model"}}, otherModel, false)),
 		}, &Model{Path: "/a.model", Childs: map[string]*ChildModel{"/other.model": new(ChildModel)}}, false},
+		{"withMustPreserve", &Decoder{
+			p: newMockPackage(newMockFile("/a.model", []Relationship{{Type: RelTypeMustPreserve, Path: "/a.bin"}}, preserved, false)),
+		}, &Model{
+			Path:          "/a.model",
+			Relationships: []Relationship{{Path: "/a.bin", Type: RelTypeMustPreserve}},
+			Attachments: []Attachment{
+				{Path: "/a.bin", Stream: new(bytes.Buffer), Relationships: []Relationship{{Path: "/b.bin", Type: extType}}},
+				{Path: "/b.bin", Stream: new(bytes.Buffer)},
+			},
+		}, false},
+		{"withUnreferencedIgnored", &Decoder{
+			p: newMockPackage(rootModel, rootModel, newMockFile("/c.bin", nil, nil, false)),
+		}, &Model{Path: "/a.model"}, false},
+		{"withUnreferenced", &Decoder{
+			PreserveUnreferenced: true, p: newMockPackage(rootModel, rootModel, newMockFile("/c.bin", nil, nil, false)),
+		}, &Model{
+			Path:        "/a.model",
+			Attachments: []Attachment{{Path: "/c.bin", Stream: new(bytes.Buffer)}},
+		}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -347,6 +380,9 @@ func TestDecoder_processRootModel(t *testing.T) {
 		NewTrianglePID(3, 0, 4, 5, 0, 0, 0),
 		NewTrianglePID(4, 7, 3, 5, 0, 0, 0),
 	}...)
+	meshRes.Mesh.TriangleSets = append(meshRes.Mesh.TriangleSets, TriangleSet{
+		Name: "Side", Identifier: "side", Indices: []uint32{0, 4, 5, 6, 10},
+	})
 
 	components := &Object{
 		ID: 20, Type: ObjectTypeSupport,
@@ -403,6 +439,13 @@ func TestDecoder_processRootModel(t *testing.T) {
 						<triangle v1="3" v2="0" v3="4" />
 						<triangle v1="4" v2="7" v3="3" />
 					</triangles>
+					<t:trianglesets xmlns:t="http://schemas.microsoft.com/3dmanufacturing/trianglesets/2021/07">
+						<t:triangleset name="Side" identifier="side">
+							<t:ref index="0" />
+							<t:refrange startindex="4" endindex="6" />
+							<t:ref index="10" />
+						</t:triangleset>
+					</t:trianglesets>
 				</mesh>
 			</object>
 			<object id="20" type="support">
@@ -442,6 +485,83 @@ func TestDecoder_processRootModel(t *testing.T) {
 	})
 }
 
+func TestDecoder_processRootModel_preserve(t *testing.T) {
+	const nsVendor = "http://vendor.com/ext"
+	vattr := func(local, value string) xml.Attr {
+		return xml.Attr{Name: xml.Name{Space: nsVendor, Local: local}, Value: value}
+	}
+	vtokens := func(local string, attrs ...xml.Attr) *UnknownTokens {
+		name := xml.Name{Space: nsVendor, Local: local}
+		return &UnknownTokens{xml.StartElement{Name: name, Attr: attrs}, xml.EndElement{Name: name}}
+	}
+	want := &Model{
+		Path: "/3D/3dmodel.model", Units: UnitMillimeter,
+		Specs:   map[string]Spec{nsVendor: &UnknownSpec{SpaceName: nsVendor, LocalName: "v"}},
+		AnyAttr: AttrMarshalers{&UnknownAttrs{vattr("model", "1")}},
+		Any: Marshalers{
+			&UnknownTokens{
+				xml.StartElement{Name: xml.Name{Space: nsVendor, Local: "data"}},
+				xml.StartElement{Name: xml.Name{Space: nsVendor, Local: "value"}},
+				xml.CharData("foo"),
+				xml.EndElement{Name: xml.Name{Space: nsVendor, Local: "value"}},
+				xml.EndElement{Name: xml.Name{Space: nsVendor, Local: "data"}},
+			},
+		},
+		Resources: Resources{Any: Marshalers{vtokens("resource", xml.Attr{Name: xml.Name{Local: "id"}, Value: "3"})}, Objects: []*Object{{
+			ID: 1, AnyAttr: AttrMarshalers{&UnknownAttrs{vattr("object", "2")}},
+			Any: Marshalers{vtokens("objectdata")},
+			Mesh: &Mesh{
+				Vertices: []Point3D{{0, 0, 0}},
+				AnyAttr:  AttrMarshalers{&UnknownAttrs{vattr("mesh", "3")}},
+				Any:      Marshalers{vtokens("vertexdata"), vtokens("meshdata")},
+			},
+		}}},
+		Build: Build{
+			AnyAttr: AttrMarshalers{&UnknownAttrs{vattr("build", "4")}},
+			Any:     Marshalers{vtokens("builddata")},
+			Items: []*Item{{
+				ObjectID: 1, AnyAttr: AttrMarshalers{&UnknownAttrs{vattr("item", "5"), vattr("item2", "6")}},
+				Any: Marshalers{vtokens("itemdata")},
+			}},
+		},
+	}
+	got := new(Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := new(modelBuilder).withElement(`
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:v="http://vendor.com/ext" unit="millimeter" v:model="1">
+		<resources>
+			<v:resource id="3" />
+			<object id="1" v:object="2">
+				<mesh v:mesh="3">
+					<vertices>
+						<vertex x="0" y="0" z="0" />
+						<v:vertexdata />
+					</vertices>
+					<v:meshdata />
+				</mesh>
+				<v:objectdata />
+			</object>
+		</resources>
+		<build v:build="4">
+			<item objectid="1" v:item="5" v:item2="6"><v:itemdata /></item>
+			<v:builddata />
+		</build>
+		<v:data><v:value>foo</v:value></v:data>
+		</model>
+		`).build("")
+
+	d := new(Decoder)
+	d.Strict = true
+	d.PreserveUnknown = true
+	if err := d.processRootModel(context.Background(), rootFile, got); err != nil {
+		t.Errorf("Decoder.processRootModel() unexpected error = %v", err)
+		return
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Decoder.processRootModel() = %v", diff)
+	}
+}
+
 func TestDecoder_processNonRootModels(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -539,7 +659,7 @@ func Test_modelFile_Decode(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if _, err := decodeModelFile(tt.args.ctx, tt.args.r, new(Model), "", true, false); (err != nil) != tt.wantErr {
+			if _, err := decodeModelFile(tt.args.ctx, tt.args.r, new(Model), "", true, false, false); (err != nil) != tt.wantErr {
 				t.Errorf("modelFile.Decode() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -559,6 +679,7 @@ func TestNewDecoder(t *testing.T) {
 		{"base", args{nil, 5}, &Decoder{
 			Strict: true,
 			p:      &opcReader{ra: nil, size: 5},
+			size:   5,
 		}},
 	}
 	for _, tt := range tests {
@@ -576,6 +697,8 @@ func TestDecoder_processRootModel_warns(t *testing.T) {
 		&specerr.ParseFieldError{Required: true, ResourceID: 0, Name: "id", Context: "model@resources@basematerials"},
 		&specerr.ParseFieldError{Required: true, ResourceID: 8, Name: "x", Context: "model@resources@object@mesh@vertices@vertex"},
 		&specerr.ParseFieldError{Required: true, ResourceID: 8, Name: "v1", Context: "model@resources@object@mesh@triangles@triangle"},
+		&specerr.ParseFieldError{Required: true, ResourceID: 8, Name: "endindex", Context: "model@resources@object@mesh@trianglesets@triangleset@refrange"},
+		&specerr.ParseFieldError{Required: true, ResourceID: 8, Name: "index", Context: "model@resources@object@mesh@trianglesets@triangleset@ref"},
 		&specerr.ParseFieldError{Required: false, ResourceID: 22, Name: "pid", Context: "model@resources@object"},
 		&specerr.ParseFieldError{Required: false, ResourceID: 22, Name: "pindex", Context: "model@resources@object"},
 		&specerr.ParseFieldError{Required: false, ResourceID: 22, Name: "type", Context: "model@resources@object"},
@@ -628,6 +751,12 @@ func TestDecoder_processRootModel_warns(t *testing.T) {
 						<triangle v1="3" v2="0" v3="4" />
 						<triangle v1="a" v2="7" v3="3" />
 					</triangles>
+					<t:trianglesets xmlns:t="http://schemas.microsoft.com/3dmanufacturing/trianglesets/2021/07">
+						<t:triangleset name="All" identifier="all">
+							<t:refrange startindex="0" endindex="4294967295" />
+							<t:ref index="20" />
+						</t:triangleset>
+					</t:trianglesets>
 				</mesh>
 			</object>
 			<object id="22" pid="a" pindex="a" type="invalid" />
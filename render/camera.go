@@ -0,0 +1,119 @@
+package render
+
+import (
+	"math"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// DefaultFOV is the vertical field of view, in degrees, used by fitted cameras.
+const DefaultFOV = 30
+
+// DefaultViewDirection is the direction, from the target to the eye,
+// used when the renderer has no camera.
+var DefaultViewDirection = go3mf.Point3D{1, -1, 1}
+
+// Camera defines the point of view of a render.
+//
+// FOV is the vertical field of view in degrees.
+// If Orthographic is true the visible height is the one
+// covered by FOV at the target distance.
+type Camera struct {
+	Eye          go3mf.Point3D
+	Target       go3mf.Point3D
+	Up           go3mf.Point3D
+	FOV          float32
+	Orthographic bool
+}
+
+// FitCamera returns a perspective camera that looks at the box
+// defined by min and max from dir, so the whole box is visible
+// in an image with the target aspect ratio (width / height).
+func FitCamera(min, max, dir go3mf.Point3D, fov, aspect float32) Camera {
+	if fov <= 0 {
+		fov = DefaultFOV
+	}
+	center := go3mf.Point3D{(min[0] + max[0]) / 2, (min[1] + max[1]) / 2, (min[2] + max[2]) / 2}
+	radius := max.Sub(min).Len() / 2
+	if radius == 0 {
+		radius = 1
+	}
+	if aspect > 0 && aspect < 1 {
+		radius /= aspect
+	}
+	dist := radius / float32(math.Sin(float64(fov)*math.Pi/360))
+	dir = dir.Normalize()
+	return Camera{
+		Eye:    center.Add(go3mf.Point3D{dir[0] * dist, dir[1] * dist, dir[2] * dist}),
+		Target: center,
+		Up:     go3mf.Point3D{0, 0, 1},
+		FOV:    fov,
+	}
+}
+
+// view contains the camera basis and the projection parameters.
+type view struct {
+	eye          go3mf.Point3D
+	right, up, f go3mf.Point3D
+	scale        float32
+	orthographic bool
+	width        float32
+	height       float32
+	aspect       float32
+}
+
+func newView(c Camera, width, height int) view {
+	fov := c.FOV
+	if fov <= 0 {
+		fov = DefaultFOV
+	}
+	f := c.Target.Sub(c.Eye)
+	dist := f.Len()
+	f = f.Normalize()
+	up := c.Up
+	if up == (go3mf.Point3D{}) {
+		up = go3mf.Point3D{0, 0, 1}
+	}
+	right := f.Cross(up)
+	if right.Len() < 1e-6 {
+		right = f.Cross(go3mf.Point3D{0, 1, 0})
+	}
+	right = right.Normalize()
+	v := view{
+		eye:          c.Eye,
+		right:        right,
+		up:           right.Cross(f),
+		f:            f,
+		orthographic: c.Orthographic,
+		width:        float32(width),
+		height:       float32(height),
+		aspect:       float32(width) / float32(height),
+	}
+	tan := float32(math.Tan(float64(fov) * math.Pi / 360))
+	v.scale = 1 / tan
+	if v.orthographic {
+		v.scale /= dist
+	}
+	return v
+}
+
+// project returns the screen coordinates and the depth of p.
+// It returns false if p is behind the eye.
+func (v *view) project(p go3mf.Point3D) (go3mf.Point3D, bool) {
+	d := p.Sub(v.eye)
+	zc := dot(d, v.f)
+	scale := v.scale
+	if !v.orthographic {
+		if zc <= 1e-6 {
+			return go3mf.Point3D{}, false
+		}
+		scale /= zc
+	}
+	x := dot(d, v.right) * scale / v.aspect
+	y := dot(d, v.up) * scale
+	return go3mf.Point3D{(x + 1) / 2 * v.width, (1 - y) / 2 * v.height, zc}, true
+}
+
+func dot(v1, v2 go3mf.Point3D) float32 {
+	return v1[0]*v2[0] + v1[1]*v2[1] + v1[2]*v2[2]
+}
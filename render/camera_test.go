@@ -0,0 +1,53 @@
+package render
+
+import (
+	"math"
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+)
+
+func TestFitCamera(t *testing.T) {
+	tests := []struct {
+		name   string
+		min    go3mf.Point3D
+		max    go3mf.Point3D
+		aspect float32
+	}{
+		{"base", go3mf.Point3D{0, 0, 0}, go3mf.Point3D{10, 20, 30}, 1},
+		{"wide", go3mf.Point3D{-5, -5, -5}, go3mf.Point3D{5, 5, 5}, 2},
+		{"tall", go3mf.Point3D{-5, -5, -5}, go3mf.Point3D{5, 5, 5}, 0.5},
+		{"point", go3mf.Point3D{1, 1, 1}, go3mf.Point3D{1, 1, 1}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := FitCamera(tt.min, tt.max, DefaultViewDirection, 0, tt.aspect)
+			width, height := int(100*tt.aspect), 100
+			v := newView(c, width, height)
+			for _, p := range []go3mf.Point3D{
+				tt.min, tt.max, {tt.min[0], tt.max[1], tt.min[2]}, {tt.max[0], tt.min[1], tt.max[2]},
+			} {
+				s, ok := v.project(p)
+				if !ok || s[0] < 0 || s[0] > float32(width) || s[1] < 0 || s[1] > float32(height) {
+					t.Errorf("FitCamera() point %v projected out of view to %v", p, s)
+				}
+			}
+			center, _ := v.project(c.Target)
+			if math.Abs(float64(center[0])-float64(width)/2) > 1e-3 || math.Abs(float64(center[1])-float64(height)/2) > 1e-3 {
+				t.Errorf("FitCamera() target projected to %v", center)
+			}
+		})
+	}
+}
+
+func TestCamera_project(t *testing.T) {
+	c := Camera{Eye: go3mf.Point3D{0, 0, 10}, Target: go3mf.Point3D{}, Up: go3mf.Point3D{0, 1, 0}, FOV: 90}
+	v := newView(c, 100, 100)
+	if _, ok := v.project(go3mf.Point3D{0, 0, 20}); ok {
+		t.Error("view.project() behind the eye want false")
+	}
+	got, ok := v.project(go3mf.Point3D{10, 10, 0})
+	if !ok || math.Abs(float64(got[0]-100)) > 1e-3 || math.Abs(float64(got[1])) > 1e-3 || math.Abs(float64(got[2]-10)) > 1e-3 {
+		t.Errorf("view.project() = %v, %v", got, ok)
+	}
+}
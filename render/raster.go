@@ -0,0 +1,93 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// raster draws depth tested triangles into an image.
+type raster struct {
+	img   *image.RGBA
+	depth []float32
+}
+
+func edge(a, b go3mf.Point3D, x, y float32) float32 {
+	return (b[0]-a[0])*(y-a[1]) - (b[1]-a[1])*(x-a[0])
+}
+
+// fill rasterizes a triangle in screen coordinates,
+// where the third coordinate is the view depth.
+// If perspective is true the depth is interpolated
+// in a perspective correct way.
+func (r *raster) fill(t [3]go3mf.Point3D, colors [3]color.RGBA, perspective bool) {
+	area := edge(t[0], t[1], t[2][0], t[2][1])
+	if area == 0 {
+		return
+	}
+	bounds := r.img.Bounds()
+	minX := int(math.Floor(float64(min3(t[0][0], t[1][0], t[2][0]))))
+	maxX := int(math.Ceil(float64(max3(t[0][0], t[1][0], t[2][0]))))
+	minY := int(math.Floor(float64(min3(t[0][1], t[1][1], t[2][1]))))
+	maxY := int(math.Ceil(float64(max3(t[0][1], t[1][1], t[2][1]))))
+	if minX < bounds.Min.X {
+		minX = bounds.Min.X
+	}
+	if minY < bounds.Min.Y {
+		minY = bounds.Min.Y
+	}
+	if maxX > bounds.Max.X-1 {
+		maxX = bounds.Max.X - 1
+	}
+	if maxY > bounds.Max.Y-1 {
+		maxY = bounds.Max.Y - 1
+	}
+	width := bounds.Dx()
+	for y := minY; y <= maxY; y++ {
+		py := float32(y) + 0.5
+		for x := minX; x <= maxX; x++ {
+			px := float32(x) + 0.5
+			w0 := edge(t[1], t[2], px, py) / area
+			w1 := edge(t[2], t[0], px, py) / area
+			w2 := edge(t[0], t[1], px, py) / area
+			if w0 < 0 || w1 < 0 || w2 < 0 {
+				continue
+			}
+			var z float32
+			if perspective {
+				z = 1 / (w0/t[0][2] + w1/t[1][2] + w2/t[2][2])
+			} else {
+				z = w0*t[0][2] + w1*t[1][2] + w2*t[2][2]
+			}
+			i := (y-bounds.Min.Y)*width + (x - bounds.Min.X)
+			if z >= r.depth[i] {
+				continue
+			}
+			r.depth[i] = z
+			r.img.SetRGBA(x, y, color.RGBA{
+				R: lerp(colors[0].R, colors[1].R, colors[2].R, w0, w1, w2),
+				G: lerp(colors[0].G, colors[1].G, colors[2].G, w0, w1, w2),
+				B: lerp(colors[0].B, colors[1].B, colors[2].B, w0, w1, w2),
+				A: 255,
+			})
+		}
+	}
+}
+
+func lerp(c0, c1, c2 uint8, w0, w1, w2 float32) uint8 {
+	v := float32(c0)*w0 + float32(c1)*w1 + float32(c2)*w2 + 0.5
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
+
+func min3(a, b, c float32) float32 {
+	return float32(math.Min(float64(a), math.Min(float64(b), float64(c))))
+}
+
+func max3(a, b, c float32) float32 {
+	return float32(math.Max(float64(a), math.Max(float64(b), float64(c))))
+}
@@ -0,0 +1,238 @@
+// Package render implements a software rasteriser that renders
+// 3MF models into images, mainly intended to generate thumbnails.
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/materials"
+)
+
+// Shading defines how the triangles are lit.
+type Shading uint8
+
+// Supported shading modes.
+const (
+	// ShadingFlat uses one normal and one color per triangle.
+	ShadingFlat Shading = iota
+	// ShadingGouraud interpolates the colors lit at each vertex.
+	ShadingGouraud
+)
+
+// A Renderer renders models into RGBA images.
+//
+// If Camera is nil the renderer fits a camera looking at the
+// rendered geometry from DefaultViewDirection.
+// Triangles use the color of their base material or color group property,
+// else DefaultColor.
+// The scene is lit by a light attached to the camera and an Ambient light,
+// whose intensity is in the range [0, 1].
+type Renderer struct {
+	Width        int
+	Height       int
+	Camera       *Camera
+	Shading      Shading
+	Background   color.RGBA
+	DefaultColor color.RGBA
+	Ambient      float32
+}
+
+// NewRenderer returns a new Gouraud renderer with a transparent background.
+func NewRenderer(width, height int) *Renderer {
+	return &Renderer{
+		Width:        width,
+		Height:       height,
+		Shading:      ShadingGouraud,
+		DefaultColor: color.RGBA{R: 180, G: 180, B: 180, A: 255},
+		Ambient:      0.3,
+	}
+}
+
+// RenderBuild renders all the items of the build.
+func (r *Renderer) RenderBuild(m *go3mf.Model) *image.RGBA {
+	s := newScene(m)
+	m.WalkBuild(s.addObject)
+	return r.render(s)
+}
+
+// RenderObject renders obj, which is defined in the model part path.
+// The root model path can be empty.
+func (r *Renderer) RenderObject(m *go3mf.Model, path string, obj *go3mf.Object) *image.RGBA {
+	s := newScene(m)
+	m.WalkComponents(path, obj, go3mf.Identity(), s.addObject)
+	return r.render(s)
+}
+
+type vertex struct {
+	pos      go3mf.Point3D
+	normal   go3mf.Point3D
+	color    color.RGBA
+	hasColor bool
+}
+
+type triangle struct {
+	v      [3]vertex
+	normal go3mf.Point3D
+}
+
+type scene struct {
+	m         *go3mf.Model
+	tris      []triangle
+	min, max  go3mf.Point3D
+	hasBounds bool
+}
+
+func newScene(m *go3mf.Model) *scene {
+	return &scene{m: m}
+}
+
+func (s *scene) addObject(path string, obj *go3mf.Object, transform go3mf.Matrix) error {
+	if obj.Mesh != nil {
+		s.addMesh(path, obj, transform)
+	}
+	return nil
+}
+
+func (s *scene) addMesh(path string, obj *go3mf.Object, transform go3mf.Matrix) {
+	mesh := obj.Mesh
+	pos := make([]go3mf.Point3D, len(mesh.Vertices))
+	for i, v := range mesh.Vertices {
+		pos[i] = transform.Mul3D(v)
+		s.extend(pos[i])
+	}
+	normals := make([]go3mf.Point3D, len(pos))
+	faceNormals := make([]go3mf.Point3D, len(mesh.Triangles))
+	for i, t := range mesh.Triangles {
+		i1, i2, i3 := t.Indices()
+		if int(i1) >= len(pos) || int(i2) >= len(pos) || int(i3) >= len(pos) {
+			continue
+		}
+		n := pos[i2].Sub(pos[i1]).Cross(pos[i3].Sub(pos[i1]))
+		faceNormals[i] = n
+		for _, idx := range [3]uint32{i1, i2, i3} {
+			normals[idx] = normals[idx].Add(n)
+		}
+	}
+	colors := materials.NewColorResolver(s.m, path)
+	for i, t := range mesh.Triangles {
+		i1, i2, i3 := t.Indices()
+		if int(i1) >= len(pos) || int(i2) >= len(pos) || int(i3) >= len(pos) || faceNormals[i].Len() == 0 {
+			continue
+		}
+		pid := t.PID()
+		p1, p2, p3 := t.PIndices()
+		if pid == 0 {
+			pid, p1, p2, p3 = obj.PID, obj.PIndex, obj.PIndex, obj.PIndex
+		}
+		tri := triangle{normal: faceNormals[i].Normalize()}
+		for j, idx := range [3]uint32{i1, i2, i3} {
+			tri.v[j] = vertex{pos: pos[idx], normal: normals[idx]}
+			tri.v[j].color, tri.v[j].hasColor = colors.Color(pid, [3]uint32{p1, p2, p3}[j])
+			if tri.v[j].normal.Len() == 0 {
+				tri.v[j].normal = tri.normal
+			} else {
+				tri.v[j].normal = tri.v[j].normal.Normalize()
+			}
+		}
+		s.tris = append(s.tris, tri)
+	}
+}
+
+func (s *scene) extend(p go3mf.Point3D) {
+	if !s.hasBounds {
+		s.min, s.max, s.hasBounds = p, p, true
+		return
+	}
+	for i := range p {
+		if p[i] < s.min[i] {
+			s.min[i] = p[i]
+		}
+		if p[i] > s.max[i] {
+			s.max[i] = p[i]
+		}
+	}
+}
+
+func (r *Renderer) render(s *scene) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, r.Width, r.Height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: r.Background}, image.Point{}, draw.Src)
+	if len(s.tris) == 0 || r.Width <= 0 || r.Height <= 0 {
+		return img
+	}
+	var cam Camera
+	if r.Camera != nil {
+		cam = *r.Camera
+	} else {
+		cam = FitCamera(s.min, s.max, DefaultViewDirection, DefaultFOV, float32(r.Width)/float32(r.Height))
+	}
+	v := newView(cam, r.Width, r.Height)
+	light := v.up.Sub(v.right)
+	light = go3mf.Point3D{light[0]*0.4 - v.f[0], light[1]*0.4 - v.f[1], light[2]*0.4 - v.f[2]}.Normalize()
+	rs := &raster{
+		img:   img,
+		depth: make([]float32, r.Width*r.Height),
+	}
+	for i := range rs.depth {
+		rs.depth[i] = float32(math.Inf(1))
+	}
+	for _, t := range s.tris {
+		var (
+			screen [3]go3mf.Point3D
+			colors [3]color.RGBA
+			ok     = true
+		)
+		for i := range t.v {
+			if screen[i], ok = v.project(t.v[i].pos); !ok {
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		if r.Shading == ShadingGouraud {
+			for i := range t.v {
+				colors[i] = r.shade(r.vertexColor(t.v[i]), t.v[i].normal, light)
+			}
+		} else {
+			c := r.shade(r.flatColor(t), t.normal, light)
+			colors = [3]color.RGBA{c, c, c}
+		}
+		rs.fill(screen, colors, !v.orthographic)
+	}
+	return img
+}
+
+func (r *Renderer) vertexColor(v vertex) color.RGBA {
+	if !v.hasColor {
+		return r.DefaultColor
+	}
+	return v.color
+}
+
+func (r *Renderer) flatColor(t triangle) color.RGBA {
+	var sum [3]uint32
+	for _, v := range t.v {
+		c := r.vertexColor(v)
+		sum[0] += uint32(c.R)
+		sum[1] += uint32(c.G)
+		sum[2] += uint32(c.B)
+	}
+	return color.RGBA{R: uint8(sum[0] / 3), G: uint8(sum[1] / 3), B: uint8(sum[2] / 3), A: 255}
+}
+
+// shade applies a two sided diffuse lighting.
+func (r *Renderer) shade(c color.RGBA, normal, light go3mf.Point3D) color.RGBA {
+	diffuse := dot(normal, light)
+	if diffuse < 0 {
+		diffuse = -diffuse
+	}
+	k := r.Ambient + (1-r.Ambient)*diffuse
+	if k > 1 {
+		k = 1
+	}
+	return color.RGBA{R: uint8(float32(c.R) * k), G: uint8(float32(c.G) * k), B: uint8(float32(c.B) * k), A: 255}
+}
@@ -0,0 +1,108 @@
+package render
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/materials"
+)
+
+func cubeMesh() *go3mf.Mesh {
+	return &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{
+			{0, 0, 0}, {10, 0, 0}, {10, 10, 0}, {0, 10, 0},
+			{0, 0, 10}, {10, 0, 10}, {10, 10, 10}, {0, 10, 10},
+		},
+		Triangles: []go3mf.Triangle{
+			go3mf.NewTriangle(3, 2, 1), go3mf.NewTriangle(1, 0, 3),
+			go3mf.NewTriangle(4, 5, 6), go3mf.NewTriangle(6, 7, 4),
+			go3mf.NewTriangle(0, 1, 5), go3mf.NewTriangle(5, 4, 0),
+			go3mf.NewTriangle(1, 2, 6), go3mf.NewTriangle(6, 5, 1),
+			go3mf.NewTriangle(2, 3, 7), go3mf.NewTriangle(7, 6, 2),
+			go3mf.NewTriangle(3, 0, 4), go3mf.NewTriangle(4, 7, 3),
+		},
+	}
+}
+
+func isGray(c color.RGBA) bool {
+	return c.A == 255 && c.R == c.G && c.G == c.B && c.R > 0
+}
+
+func isRed(c color.RGBA) bool {
+	return c.A == 255 && c.R > 0 && c.G == 0 && c.B == 0
+}
+
+func isBlue(c color.RGBA) bool {
+	return c.A == 255 && c.B > 0 && c.R == 0 && c.G == 0
+}
+
+func TestRenderer_RenderBuild(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	tests := []struct {
+		name    string
+		shading Shading
+		model   *go3mf.Model
+		check   func(color.RGBA) bool
+	}{
+		{"empty", ShadingGouraud, new(go3mf.Model), func(c color.RGBA) bool { return c == color.RGBA{} }},
+		{"default", ShadingGouraud, &go3mf.Model{
+			Resources: go3mf.Resources{Objects: []*go3mf.Object{{ID: 1, Mesh: cubeMesh()}}},
+			Build:     go3mf.Build{Items: []*go3mf.Item{{ObjectID: 1}}},
+		}, isGray},
+		{"base", ShadingFlat, &go3mf.Model{
+			Resources: go3mf.Resources{
+				Assets:  []go3mf.Asset{&go3mf.BaseMaterials{ID: 1, Materials: []go3mf.Base{{Color: blue}, {Color: red}}}},
+				Objects: []*go3mf.Object{{ID: 2, PID: 1, PIndex: 1, Mesh: cubeMesh()}},
+			},
+			Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 2}}},
+		}, isRed},
+		{"colorgroup", ShadingGouraud, &go3mf.Model{
+			Resources: go3mf.Resources{
+				Assets: []go3mf.Asset{&materials.ColorGroup{ID: 1, Colors: []color.RGBA{blue}}},
+				Objects: []*go3mf.Object{
+					{ID: 2, PID: 1, Mesh: cubeMesh()},
+					{ID: 3, Components: []*go3mf.Component{{ObjectID: 2, Transform: go3mf.Identity().Translate(-5, -5, -5)}}},
+				},
+			},
+			Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 3}}},
+		}, isBlue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRenderer(64, 48)
+			r.Shading = tt.shading
+			img := r.RenderBuild(tt.model)
+			if got := img.Bounds().Size(); got.X != 64 || got.Y != 48 {
+				t.Errorf("Renderer.RenderBuild() size = %v", got)
+			}
+			if got := img.RGBAAt(32, 24); !tt.check(got) {
+				t.Errorf("Renderer.RenderBuild() center = %v", got)
+			}
+			if got := img.RGBAAt(0, 0); got != r.Background {
+				t.Errorf("Renderer.RenderBuild() corner = %v, want %v", got, r.Background)
+			}
+		})
+	}
+}
+
+func TestRenderer_RenderObject(t *testing.T) {
+	obj := &go3mf.Object{ID: 1, Mesh: cubeMesh()}
+	m := &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{obj}}}
+	r := NewRenderer(32, 32)
+	r.Background = color.RGBA{G: 255, A: 255}
+	r.Camera = &Camera{Eye: go3mf.Point3D{5, 5, 100}, Target: go3mf.Point3D{5, 5, 5}, Up: go3mf.Point3D{0, 1, 0}, FOV: 10, Orthographic: true}
+	img := r.RenderObject(m, "", obj)
+	if got := img.RGBAAt(16, 16); !isGray(got) {
+		t.Errorf("Renderer.RenderObject() center = %v", got)
+	}
+	if got := img.RGBAAt(0, 0); got != r.Background {
+		t.Errorf("Renderer.RenderObject() corner = %v, want %v", got, r.Background)
+	}
+	r.Camera = &Camera{Eye: go3mf.Point3D{5, 5, 20}, Target: go3mf.Point3D{5, 5, 40}}
+	img = r.RenderObject(m, "", obj)
+	if got := img.RGBAAt(16, 16); got != r.Background {
+		t.Errorf("Renderer.RenderObject() behind = %v, want %v", got, r.Background)
+	}
+}
@@ -0,0 +1,66 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// DefaultThumbnailPath is the recommended package thumbnail part name.
+const DefaultThumbnailPath = go3mf.DefaultMetadataDir + "thumbnail.png"
+
+const contentTypePNG = "image/png"
+
+// Attach encodes img as a PNG and stores it as an attachment with the target path,
+// replacing the attachment with the same path if it already exists.
+func Attach(m *go3mf.Model, path string, img image.Image) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	for i, a := range m.Attachments {
+		if strings.EqualFold(a.Path, path) {
+			m.Attachments[i].Stream = &buf
+			m.Attachments[i].ContentType = contentTypePNG
+			return nil
+		}
+	}
+	m.Attachments = append(m.Attachments, go3mf.Attachment{
+		Path:        path,
+		ContentType: contentTypePNG,
+		Stream:      &buf,
+	})
+	return nil
+}
+
+// SetModelThumbnail stores img at DefaultThumbnailPath and sets it as
+// the model thumbnail and as the package thumbnail.
+func SetModelThumbnail(m *go3mf.Model, img image.Image) error {
+	if err := Attach(m, DefaultThumbnailPath, img); err != nil {
+		return err
+	}
+	m.Thumbnail = DefaultThumbnailPath
+	for _, r := range m.RootRelationships {
+		if r.Type == go3mf.RelTypeThumbnail && strings.EqualFold(r.Path, DefaultThumbnailPath) {
+			return nil
+		}
+	}
+	m.RootRelationships = append(m.RootRelationships, go3mf.Relationship{
+		Path: DefaultThumbnailPath,
+		Type: go3mf.RelTypeThumbnail,
+	})
+	return nil
+}
+
+// SetObjectThumbnail stores img at path and sets it as the object thumbnail.
+// The encoder adds the thumbnail relationship to the model part that contains obj.
+func SetObjectThumbnail(m *go3mf.Model, obj *go3mf.Object, path string, img image.Image) error {
+	if err := Attach(m, path, img); err != nil {
+		return err
+	}
+	obj.Thumbnail = path
+	return nil
+}
@@ -0,0 +1,80 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+func TestSetModelThumbnail(t *testing.T) {
+	m := new(go3mf.Model)
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for i := 0; i < 2; i++ {
+		if err := SetModelThumbnail(m, img); err != nil {
+			t.Errorf("SetModelThumbnail() error = %v", err)
+			return
+		}
+	}
+	if m.Thumbnail != DefaultThumbnailPath {
+		t.Errorf("SetModelThumbnail() thumbnail = %v", m.Thumbnail)
+	}
+	want := []go3mf.Relationship{{Path: DefaultThumbnailPath, Type: go3mf.RelTypeThumbnail}}
+	if diff := deep.Equal(m.RootRelationships, want); diff != nil {
+		t.Errorf("SetModelThumbnail() = %v", diff)
+	}
+	if len(m.Attachments) != 1 || m.Attachments[0].ContentType != "image/png" {
+		t.Errorf("SetModelThumbnail() attachments = %v", m.Attachments)
+		return
+	}
+	got, err := png.Decode(m.Attachments[0].Stream)
+	if err != nil {
+		t.Errorf("SetModelThumbnail() malformed png = %v", err)
+		return
+	}
+	if got.Bounds() != img.Bounds() {
+		t.Errorf("SetModelThumbnail() bounds = %v", got.Bounds())
+	}
+	if err := m.Validate(); err != nil {
+		t.Errorf("SetModelThumbnail() validate = %v", err)
+	}
+}
+
+func TestSetObjectThumbnail(t *testing.T) {
+	obj := &go3mf.Object{ID: 1, Mesh: cubeMesh()}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{obj}},
+		Build:     go3mf.Build{Items: []*go3mf.Item{{ObjectID: 1}}},
+	}
+	path := "/3D/Metadata/object1.png"
+	if err := SetObjectThumbnail(m, obj, path, NewRenderer(16, 16).RenderObject(m, "", obj)); err != nil {
+		t.Errorf("SetObjectThumbnail() error = %v", err)
+		return
+	}
+	buff := new(bytes.Buffer)
+	if err := go3mf.NewEncoder(buff).Encode(m); err != nil {
+		t.Errorf("SetObjectThumbnail() encode error = %v", err)
+		return
+	}
+	newModel := new(go3mf.Model)
+	if err := go3mf.NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len())).Decode(newModel); err != nil {
+		t.Errorf("SetObjectThumbnail() decode error = %v", err)
+		return
+	}
+	if newModel.Resources.Objects[0].Thumbnail != path {
+		t.Errorf("SetObjectThumbnail() thumbnail = %v", newModel.Resources.Objects[0].Thumbnail)
+	}
+	want := []go3mf.Relationship{{Path: path, Type: go3mf.RelTypeThumbnail}}
+	for i := range newModel.Relationships {
+		newModel.Relationships[i].ID = ""
+	}
+	if diff := deep.Equal(newModel.Relationships, want); diff != nil {
+		t.Errorf("SetObjectThumbnail() = %v", diff)
+	}
+	if len(newModel.Attachments) != 1 || newModel.Attachments[0].Path != path {
+		t.Errorf("SetObjectThumbnail() attachments = %v", newModel.Attachments)
+	}
+}
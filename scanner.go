@@ -39,6 +39,36 @@ type Scanner struct {
 	Err              specerr.List
 	extensionDecoder map[string]SpecDecoder
 	contex           []xml.Name
+	preserve         bool
+}
+
+func (s *Scanner) isUnknownSpace(space string) bool {
+	if space == Namespace {
+		return false
+	}
+	_, ok := s.extensionDecoder[space]
+	return !ok
+}
+
+// addUnknownAttr keeps a copy of the attribute
+// if it does not belong to any registered extension.
+func (s *Scanner) addUnknownAttr(anyAttr *AttrMarshalers, a xml.Attr) {
+	if !s.preserve || a.Name.Space == "" || a.Name.Space == nsXML {
+		return
+	}
+	space := a.Name.Space
+	if space == attrXmlns {
+		space = a.Value
+	}
+	if !s.isUnknownSpace(space) {
+		return
+	}
+	var attrs *UnknownAttrs
+	if !anyAttr.Get(&attrs) {
+		attrs = new(UnknownAttrs)
+		*anyAttr = append(*anyAttr, attrs)
+	}
+	*attrs = append(*attrs, a)
 }
 
 func (s *Scanner) namespace(local string) (string, bool) {
@@ -0,0 +1,182 @@
+package go3mf
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// PartDecrypter is the interface implemented by objects that
+// can decrypt the parts of a package while decoding it.
+//
+// Setup is called once before extracting any part. It receives the package root relationships and
+// a function to open the raw content of a part, and returns the parts it owns, such as keystores,
+// which are hidden from the decoded model.
+// Decrypt returns the plain content of the part, which is r itself when the part is not encrypted.
+type PartDecrypter interface {
+	Setup(rels []Relationship, open func(name string) (io.ReadCloser, error)) ([]string, error)
+	Decrypt(name string, r io.Reader) (io.Reader, error)
+}
+
+// PartEncrypter is the interface implemented by objects that
+// can encrypt the parts of a package while encoding it.
+//
+// Encrypt returns a writer that encrypts the content of the part into w,
+// or w itself when the part must not be encrypted. The writer is closed once the part is complete.
+// Finish is called once all the parts have been written, so the encrypter
+// can create its own plain parts and root relationships, such as keystores.
+type PartEncrypter interface {
+	Encrypt(name string, w io.Writer) (io.WriteCloser, error)
+	Finish(create func(name, contentType string) (io.Writer, error), addRel func(Relationship)) error
+}
+
+type decryptedFile struct {
+	packageFile
+	p *decryptedPackage
+}
+
+func (f *decryptedFile) Open() (io.ReadCloser, error) {
+	rc, err := f.packageFile.Open()
+	if err != nil {
+		return nil, err
+	}
+	r, err := f.p.dec.Decrypt(f.Name(), rc)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{r, rc}, nil
+}
+
+func (f *decryptedFile) FindFileFromName(name string) (packageFile, bool) {
+	return f.p.wrap(f.packageFile.FindFileFromName(name))
+}
+
+func (f *decryptedFile) Relationships() []Relationship {
+	return f.p.filter(f.packageFile.Relationships())
+}
+
+// decryptedPackage decrypts the parts of a package
+// and hides the parts owned by the decrypter.
+type decryptedPackage struct {
+	packageReader
+	dec   PartDecrypter
+	owned []string
+}
+
+func newDecryptedPackage(p packageReader, dec PartDecrypter) (*decryptedPackage, error) {
+	open := func(name string) (io.ReadCloser, error) {
+		file, ok := p.FindFileFromName(name)
+		if !ok {
+			return nil, errors.New("part does not exist: " + name)
+		}
+		return file.Open()
+	}
+	owned, err := dec.Setup(p.Relationships(), open)
+	if err != nil {
+		return nil, err
+	}
+	return &decryptedPackage{packageReader: p, dec: dec, owned: owned}, nil
+}
+
+func (p *decryptedPackage) isOwned(name string) bool {
+	for _, o := range p.owned {
+		if strings.EqualFold(o, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *decryptedPackage) wrap(file packageFile, ok bool) (packageFile, bool) {
+	if !ok || p.isOwned(file.Name()) {
+		return nil, false
+	}
+	return &decryptedFile{packageFile: file, p: p}, true
+}
+
+func (p *decryptedPackage) filter(rels []Relationship) []Relationship {
+	filtered := make([]Relationship, 0, len(rels))
+	for _, r := range rels {
+		if !p.isOwned(r.Path) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+func (p *decryptedPackage) FindFileFromName(name string) (packageFile, bool) {
+	return p.wrap(p.packageReader.FindFileFromName(name))
+}
+
+func (p *decryptedPackage) Relationships() []Relationship {
+	return p.filter(p.packageReader.Relationships())
+}
+
+func (p *decryptedPackage) Files() []packageFile {
+	files := p.packageReader.Files()
+	wrapped := make([]packageFile, 0, len(files))
+	for _, f := range files {
+		if f, ok := p.wrap(f, true); ok {
+			wrapped = append(wrapped, f)
+		}
+	}
+	return wrapped
+}
+
+type encryptedPart struct {
+	io.WriteCloser
+	part packagePart
+}
+
+func (p *encryptedPart) AddRelationship(r Relationship) {
+	p.part.AddRelationship(r)
+}
+
+// encryptedWriter encrypts the parts written to a package.
+type encryptedWriter struct {
+	packageWriter
+	enc     PartEncrypter
+	current io.Closer
+}
+
+func (w *encryptedWriter) closeCurrent() error {
+	if w.current == nil {
+		return nil
+	}
+	err := w.current.Close()
+	w.current = nil
+	return err
+}
+
+func (w *encryptedWriter) Create(name, contentType string) (packagePart, error) {
+	if err := w.closeCurrent(); err != nil {
+		return nil, err
+	}
+	part, err := w.packageWriter.Create(name, contentType)
+	if err != nil {
+		return nil, err
+	}
+	wc, err := w.enc.Encrypt(name, part)
+	if err != nil {
+		return nil, err
+	}
+	w.current = wc
+	return &encryptedPart{WriteCloser: wc, part: part}, nil
+}
+
+func (w *encryptedWriter) Close() error {
+	if err := w.closeCurrent(); err != nil {
+		return err
+	}
+	create := func(name, contentType string) (io.Writer, error) {
+		return w.packageWriter.Create(name, contentType)
+	}
+	if err := w.enc.Finish(create, w.packageWriter.AddRelationship); err != nil {
+		return err
+	}
+	return w.packageWriter.Close()
+}
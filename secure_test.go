@@ -0,0 +1,145 @@
+package go3mf
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+const fakeSecretPath = "/Secure/secret.bin"
+
+// fakeCipher reverses the bytes of the encrypted parts and owns fakeSecretPath.
+type fakeCipher struct {
+	parts    []string
+	setupErr error
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+func (f *fakeCipher) isEncrypted(name string) bool {
+	for _, p := range f.parts {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeCipher) Setup(rels []Relationship, open func(string) (io.ReadCloser, error)) ([]string, error) {
+	if f.setupErr != nil {
+		return nil, f.setupErr
+	}
+	for _, r := range rels {
+		if r.Type == "fake_secret" {
+			rc, err := open(r.Path)
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			b, _ := ioutil.ReadAll(rc)
+			f.parts = []string{string(b)}
+			return []string{r.Path}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeCipher) Decrypt(name string, r io.Reader) (io.Reader, error) {
+	if !f.isEncrypted(name) {
+		return r, nil
+	}
+	b, err := ioutil.ReadAll(r)
+	return bytes.NewReader(reverse(b)), err
+}
+
+type fakeCipherWriter struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+func (f *fakeCipherWriter) Write(b []byte) (int, error) { return f.buf.Write(b) }
+
+func (f *fakeCipherWriter) Close() error {
+	_, err := f.w.Write(reverse(f.buf.Bytes()))
+	return err
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+func (f *fakeCipher) Encrypt(name string, w io.Writer) (io.WriteCloser, error) {
+	if f.isEncrypted(name) {
+		return &fakeCipherWriter{w: w}, nil
+	}
+	return nopWriteCloser{w}, nil
+}
+
+func (f *fakeCipher) Finish(create func(string, string) (io.Writer, error), addRel func(Relationship)) error {
+	w, err := create(fakeSecretPath, "application/octet-stream")
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write([]byte(f.parts[0])); err != nil {
+		return err
+	}
+	addRel(Relationship{Path: fakeSecretPath, Type: "fake_secret"})
+	return nil
+}
+
+func TestEncoder_Encode_Encrypter(t *testing.T) {
+	m := &Model{
+		Path:      DefaultModelPath,
+		Resources: Resources{Objects: []*Object{{ID: 1, Name: "secret object", Mesh: new(Mesh)}}},
+		Build:     Build{Items: []*Item{{ObjectID: 1}}},
+	}
+	buff := new(bytes.Buffer)
+	e := NewEncoder(buff)
+	e.Encrypter = &fakeCipher{parts: []string{DefaultModelPath}}
+	if err := e.Encode(m); err != nil {
+		t.Errorf("Encoder.Encode() error = %v", err)
+		return
+	}
+	if bytes.Contains(buff.Bytes(), []byte("secret object")) {
+		t.Error("Encoder.Encode() root model not encrypted")
+	}
+	if err := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len())).Decode(new(Model)); err == nil {
+		t.Error("Decoder.Decode() without decrypter want error")
+	}
+	errSetup := errors.New("")
+	d := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+	d.Decrypter = &fakeCipher{setupErr: errSetup}
+	if err := d.Decode(new(Model)); err != errSetup {
+		t.Errorf("Decoder.Decode() error = %v, want %v", err, errSetup)
+	}
+	got := new(Model)
+	d = NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+	d.Decrypter = new(fakeCipher)
+	d.PreserveUnreferenced = true
+	if err := d.Decode(got); err != nil {
+		t.Errorf("Decoder.Decode() error = %v", err)
+		return
+	}
+	if len(got.RootRelationships) != 0 || len(got.Attachments) != 0 {
+		t.Errorf("Decoder.Decode() owned parts not hidden = %v, %v", got.RootRelationships, got.Attachments)
+	}
+	if len(got.Resources.Objects) != 1 || got.Resources.Objects[0].Name != "secret object" {
+		t.Errorf("Decoder.Decode() = %v", got.Resources.Objects)
+	}
+	got = new(Model)
+	if err := d.Decode(got); err != nil {
+		t.Errorf("Decoder.Decode() reused error = %v", err)
+		return
+	}
+	if len(got.Resources.Objects) != 1 || got.Resources.Objects[0].Name != "secret object" {
+		t.Errorf("Decoder.Decode() reused = %v", got.Resources.Objects)
+	}
+}
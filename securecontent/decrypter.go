@@ -0,0 +1,185 @@
+package securecontent
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/xml"
+	"errors"
+	"hash"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// Decryption errors.
+var (
+	ErrNoConsumerKey        = errors.New("securecontent: no consumer key can unwrap the content key")
+	ErrUnsupportedAlgorithm = errors.New("securecontent: unsupported algorithm")
+)
+
+var _ go3mf.PartDecrypter = new(Decrypter)
+
+// A KeyProvider returns the private key of a consumer.
+// It returns a nil key if the consumer is unknown.
+type KeyProvider interface {
+	ConsumerKey(Consumer) (crypto.Decrypter, error)
+}
+
+// The KeyProviderFunc type is an adapter to allow the use of
+// ordinary functions as key providers.
+type KeyProviderFunc func(Consumer) (crypto.Decrypter, error)
+
+// ConsumerKey calls f(c).
+func (f KeyProviderFunc) ConsumerKey(c Consumer) (crypto.Decrypter, error) {
+	return f(c)
+}
+
+// A Decrypter decrypts the parts of a package using the keys
+// returned by KeyProvider. It implements go3mf.PartDecrypter.
+//
+// KeyStore is filled with the package keystore once the decoding starts.
+type Decrypter struct {
+	KeyProvider KeyProvider
+	KeyStore    *KeyStore
+	ceks        map[int][]byte
+}
+
+// NewDecrypter returns a new decrypter that uses kp.
+func NewDecrypter(kp KeyProvider) *Decrypter {
+	return &Decrypter{KeyProvider: kp}
+}
+
+// Setup reads the package keystore, if any.
+func (d *Decrypter) Setup(rels []go3mf.Relationship, open func(string) (io.ReadCloser, error)) ([]string, error) {
+	d.KeyStore, d.ceks = nil, make(map[int][]byte)
+	for _, r := range rels {
+		if r.Type != RelTypeKeyStore {
+			continue
+		}
+		f, err := open(r.Path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		ks := new(KeyStore)
+		if err := xml.NewDecoder(f).Decode(ks); err != nil {
+			return nil, err
+		}
+		d.KeyStore = ks
+		return []string{r.Path}, nil
+	}
+	return nil, nil
+}
+
+// Decrypt returns the plain content of the part.
+func (d *Decrypter) Decrypt(name string, r io.Reader) (io.Reader, error) {
+	if d.KeyStore == nil {
+		return r, nil
+	}
+	group, rd, ok := d.KeyStore.FindResourceData(name)
+	if !ok {
+		return r, nil
+	}
+	if rd.CEKParams.EncryptionAlgorithm != AlgorithmAES256GCM {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	cek, err := d.contentKey(group)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCMWithNonceSize(block, len(rd.CEKParams.IV))
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := aead.Open(nil, rd.CEKParams.IV, append(ciphertext, rd.CEKParams.Tag...), rd.CEKParams.AAD)
+	if err != nil {
+		return nil, err
+	}
+	switch rd.CEKParams.Compression {
+	case "", CompressionNone:
+		return bytes.NewReader(plain), nil
+	case CompressionDeflate:
+		return flate.NewReader(bytes.NewReader(plain)), nil
+	}
+	return nil, ErrUnsupportedAlgorithm
+}
+
+// contentKey unwraps the content key of the group with the
+// first access right whose consumer key is available.
+func (d *Decrypter) contentKey(group int) ([]byte, error) {
+	if cek, ok := d.ceks[group]; ok {
+		return cek, nil
+	}
+	if d.KeyProvider == nil {
+		return nil, ErrNoConsumerKey
+	}
+	for _, ar := range d.KeyStore.ResourceDataGroups[group].AccessRights {
+		if ar.ConsumerIndex < 0 || ar.ConsumerIndex >= len(d.KeyStore.Consumers) {
+			continue
+		}
+		key, err := d.KeyProvider.ConsumerKey(d.KeyStore.Consumers[ar.ConsumerIndex])
+		if err != nil {
+			return nil, err
+		}
+		if key == nil {
+			continue
+		}
+		h, err := oaepHash(ar.KEKParams)
+		if err != nil {
+			return nil, err
+		}
+		cek, err := key.Decrypt(rand.Reader, ar.CipherData.CipherValue, &rsa.OAEPOptions{Hash: h})
+		if err != nil {
+			return nil, err
+		}
+		d.ceks[group] = cek
+		return cek, nil
+	}
+	return nil, ErrNoConsumerKey
+}
+
+func oaepHash(params KEKParams) (crypto.Hash, error) {
+	switch params.WrappingAlgorithm {
+	case AlgorithmRSAOAEP:
+		if params.DigestMethod == "" || params.DigestMethod == DigestSHA1 {
+			return crypto.SHA1, nil
+		}
+	case AlgorithmRSAOAEP11:
+		// The same function is used for the digest and the mask generation.
+		switch {
+		case (params.DigestMethod == "" || params.DigestMethod == DigestSHA1) && (params.MGFAlgorithm == "" || params.MGFAlgorithm == MGF1SHA1):
+			return crypto.SHA1, nil
+		case params.DigestMethod == DigestSHA256 && params.MGFAlgorithm == MGF1SHA256:
+			return crypto.SHA256, nil
+		}
+	}
+	return 0, ErrUnsupportedAlgorithm
+}
+
+func newHash(h crypto.Hash) hash.Hash {
+	if h == crypto.SHA256 {
+		return sha256.New()
+	}
+	return sha1.New()
+}
+
+func equalPath(p1, p2 string) bool {
+	return strings.EqualFold(p1, p2)
+}
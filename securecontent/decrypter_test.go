@@ -0,0 +1,79 @@
+package securecontent
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+)
+
+func TestDecrypter_Decrypt_Fail(t *testing.T) {
+	e := NewEncrypter()
+	if err := e.AddConsumer(Consumer{ID: "printer"}, &testKey(t, 0).PublicKey); err != nil {
+		t.Fatal(err)
+	}
+	e.EncryptPart(go3mf.DefaultModelPath)
+	b := encode(t, e)
+	errProvider := errors.New("provider")
+	tests := []struct {
+		name string
+		d    go3mf.PartDecrypter
+		want error
+	}{
+		{"noDecrypter", nil, nil},
+		{"noProvider", NewDecrypter(nil), ErrNoConsumerKey},
+		{"unknownConsumer", NewDecrypter(KeyProviderFunc(func(Consumer) (crypto.Decrypter, error) {
+			return nil, nil
+		})), ErrNoConsumerKey},
+		{"providerError", NewDecrypter(KeyProviderFunc(func(Consumer) (crypto.Decrypter, error) {
+			return nil, errProvider
+		})), errProvider},
+		{"wrongKey", NewDecrypter(KeyProviderFunc(func(Consumer) (crypto.Decrypter, error) {
+			return testKey(t, 1), nil
+		})), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := go3mf.NewDecoder(bytes.NewReader(b), int64(len(b)))
+			dec.Decrypter = tt.d
+			err := dec.Decode(new(go3mf.Model))
+			if err == nil {
+				t.Error("Decoder.Decode() want error")
+				return
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Decoder.Decode() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_oaepHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  KEKParams
+		want    crypto.Hash
+		wantErr bool
+	}{
+		{"mgf1p", KEKParams{WrappingAlgorithm: AlgorithmRSAOAEP}, crypto.SHA1, false},
+		{"mgf1pSHA256", KEKParams{WrappingAlgorithm: AlgorithmRSAOAEP, DigestMethod: DigestSHA256}, 0, true},
+		{"oaep11", KEKParams{WrappingAlgorithm: AlgorithmRSAOAEP11, DigestMethod: DigestSHA1, MGFAlgorithm: MGF1SHA1}, crypto.SHA1, false},
+		{"oaep11SHA256", KEKParams{WrappingAlgorithm: AlgorithmRSAOAEP11, DigestMethod: DigestSHA256, MGFAlgorithm: MGF1SHA256}, crypto.SHA256, false},
+		{"oaep11Mixed", KEKParams{WrappingAlgorithm: AlgorithmRSAOAEP11, DigestMethod: DigestSHA256, MGFAlgorithm: MGF1SHA1}, 0, true},
+		{"unknown", KEKParams{WrappingAlgorithm: "other"}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := oaepHash(tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("oaepHash() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("oaepHash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
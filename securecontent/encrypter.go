@@ -0,0 +1,193 @@
+package securecontent
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"encoding/xml"
+	"io"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/production"
+)
+
+const (
+	cekSize   = 32
+	ivSize    = 12
+	tagSize   = 16
+	pemPublic = "PUBLIC KEY"
+)
+
+var _ go3mf.PartEncrypter = new(Encrypter)
+
+// An Encrypter encrypts the selected parts of a package for a set of consumers
+// and writes the resulting keystore. It implements go3mf.PartEncrypter.
+//
+// All the parts are encrypted using the same content key,
+// which is wrapped for each consumer using RSA-OAEP with SHA1.
+// If KeyStorePath is empty DefaultKeyStorePath is used.
+type Encrypter struct {
+	KeyStorePath string
+	Compression  string
+	consumers    []Consumer
+	keys         []*rsa.PublicKey
+	parts        []string
+	cek          []byte
+	resources    []ResourceData
+}
+
+// NewEncrypter returns a new encrypter without compression.
+func NewEncrypter() *Encrypter {
+	return &Encrypter{
+		KeyStorePath: DefaultKeyStorePath,
+		Compression:  CompressionNone,
+	}
+}
+
+// AddConsumer adds a consumer that will be able to decrypt the parts.
+// If the consumer does not define a KeyValue, it is filled with the PEM encoded key.
+func (e *Encrypter) AddConsumer(c Consumer, key *rsa.PublicKey) error {
+	if c.KeyValue == "" {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			return err
+		}
+		c.KeyValue = string(pem.EncodeToMemory(&pem.Block{Type: pemPublic, Bytes: der}))
+	}
+	e.consumers = append(e.consumers, c)
+	e.keys = append(e.keys, key)
+	return nil
+}
+
+// EncryptPart marks the part with the target name to be encrypted.
+func (e *Encrypter) EncryptPart(name string) {
+	e.parts = append(e.parts, name)
+}
+
+// Encrypt returns a writer that encrypts the part if it has been marked to be encrypted.
+func (e *Encrypter) Encrypt(name string, w io.Writer) (io.WriteCloser, error) {
+	for _, p := range e.parts {
+		if equalPath(p, name) {
+			if e.cek == nil {
+				e.cek = make([]byte, cekSize)
+				if _, err := io.ReadFull(rand.Reader, e.cek); err != nil {
+					return nil, err
+				}
+			}
+			return &partWriter{e: e, name: name, w: w}, nil
+		}
+	}
+	return nopCloser{w}, nil
+}
+
+// Finish writes the keystore if any part has been encrypted.
+func (e *Encrypter) Finish(create func(string, string) (io.Writer, error), addRel func(go3mf.Relationship)) error {
+	if len(e.resources) == 0 {
+		return nil
+	}
+	group := ResourceDataGroup{KeyUUID: string(*production.NewUUID()), ResourceData: e.resources}
+	for i, key := range e.keys {
+		params := KEKParams{WrappingAlgorithm: AlgorithmRSAOAEP, MGFAlgorithm: MGF1SHA1, DigestMethod: DigestSHA1}
+		h, _ := oaepHash(params)
+		wrapped, err := rsa.EncryptOAEP(newHash(h), rand.Reader, key, e.cek, nil)
+		if err != nil {
+			return err
+		}
+		group.AccessRights = append(group.AccessRights, AccessRight{
+			ConsumerIndex: i,
+			KEKParams:     params,
+			CipherData:    CipherData{CipherValue: wrapped},
+		})
+	}
+	ks := &KeyStore{
+		UUID:               string(*production.NewUUID()),
+		Consumers:          e.consumers,
+		ResourceDataGroups: []ResourceDataGroup{group},
+	}
+	path := e.KeyStorePath
+	if path == "" {
+		path = DefaultKeyStorePath
+	}
+	w, err := create(path, ContentTypeKeyStore)
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write([]byte(xml.Header)); err != nil {
+		return err
+	}
+	if err = xml.NewEncoder(w).Encode(ks); err != nil {
+		return err
+	}
+	addRel(go3mf.Relationship{Path: path, Type: RelTypeKeyStore})
+	e.cek, e.resources = nil, nil
+	return nil
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// partWriter buffers the content of a part and
+// writes it encrypted when closed.
+type partWriter struct {
+	e    *Encrypter
+	name string
+	w    io.Writer
+	buf  bytes.Buffer
+}
+
+func (p *partWriter) Write(b []byte) (int, error) {
+	return p.buf.Write(b)
+}
+
+func (p *partWriter) Close() error {
+	plain := p.buf.Bytes()
+	if p.e.Compression == CompressionDeflate {
+		var compressed bytes.Buffer
+		fw, _ := flate.NewWriter(&compressed, flate.DefaultCompression)
+		if _, err := fw.Write(plain); err != nil {
+			return err
+		}
+		if err := fw.Close(); err != nil {
+			return err
+		}
+		plain = compressed.Bytes()
+	}
+	block, err := aes.NewCipher(p.e.cek)
+	if err != nil {
+		return err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	iv := make([]byte, ivSize)
+	if _, err = io.ReadFull(rand.Reader, iv); err != nil {
+		return err
+	}
+	sealed := aead.Seal(nil, iv, plain, nil)
+	if _, err = p.w.Write(sealed[:len(sealed)-tagSize]); err != nil {
+		return err
+	}
+	compression := p.e.Compression
+	if compression == "" {
+		compression = CompressionNone
+	}
+	p.e.resources = append(p.e.resources, ResourceData{
+		Path: p.name,
+		CEKParams: CEKParams{
+			EncryptionAlgorithm: AlgorithmAES256GCM,
+			Compression:         compression,
+			IV:                  iv,
+			Tag:                 sealed[len(sealed)-tagSize:],
+		},
+	})
+	return nil
+}
@@ -0,0 +1,127 @@
+package securecontent
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+var (
+	testKeyOnce sync.Once
+	testKeys    [2]*rsa.PrivateKey
+)
+
+func testKey(t *testing.T, i int) *rsa.PrivateKey {
+	testKeyOnce.Do(func() {
+		for j := range testKeys {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testKeys[j] = key
+		}
+	})
+	return testKeys[i]
+}
+
+func testModel() *go3mf.Model {
+	return &go3mf.Model{
+		Path: go3mf.DefaultModelPath,
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{{ID: 1, Mesh: &go3mf.Mesh{
+			Vertices:  []go3mf.Point3D{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}, {0, 0, 10}},
+			Triangles: []go3mf.Triangle{go3mf.NewTriangle(0, 2, 1), go3mf.NewTriangle(0, 1, 3), go3mf.NewTriangle(1, 2, 3), go3mf.NewTriangle(0, 3, 2)},
+		}}}},
+		Build:         go3mf.Build{Items: []*go3mf.Item{{ObjectID: 1}}},
+		Attachments:   []go3mf.Attachment{{Path: "/3D/Other/data.bin", ContentType: "application/octet-stream", Stream: bytes.NewBufferString("secret")}},
+		Relationships: []go3mf.Relationship{{ID: "1", Path: "/3D/Other/data.bin", Type: "fake_type"}},
+	}
+}
+
+func encode(t *testing.T, e *Encrypter) []byte {
+	buff := new(bytes.Buffer)
+	enc := go3mf.NewEncoder(buff)
+	enc.Encrypter = e
+	if err := enc.Encode(testModel()); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	return buff.Bytes()
+}
+
+func TestEncrypter_RoundTrip(t *testing.T) {
+	for _, compression := range []string{CompressionNone, CompressionDeflate} {
+		t.Run(compression, func(t *testing.T) {
+			e := NewEncrypter()
+			e.Compression = compression
+			if err := e.AddConsumer(Consumer{ID: "other"}, &testKey(t, 1).PublicKey); err != nil {
+				t.Fatal(err)
+			}
+			if err := e.AddConsumer(Consumer{ID: "printer", KeyID: "k1"}, &testKey(t, 0).PublicKey); err != nil {
+				t.Fatal(err)
+			}
+			e.EncryptPart(go3mf.DefaultModelPath)
+			e.EncryptPart("/3D/Other/data.bin")
+			b := encode(t, e)
+			if bytes.Contains(b, []byte("secret")) {
+				t.Error("Encrypter attachment not encrypted")
+			}
+
+			d := NewDecrypter(KeyProviderFunc(func(c Consumer) (crypto.Decrypter, error) {
+				if c.ID == "printer" {
+					return testKey(t, 0), nil
+				}
+				return nil, nil
+			}))
+			dec := go3mf.NewDecoder(bytes.NewReader(b), int64(len(b)))
+			dec.Decrypter = d
+			got := new(go3mf.Model)
+			if err := dec.Decode(got); err != nil {
+				t.Fatalf("Decoder.Decode() error = %v", err)
+			}
+			if d.KeyStore == nil || len(d.KeyStore.Consumers) != 2 || len(d.KeyStore.ResourceDataGroups[0].ResourceData) != 2 {
+				t.Errorf("Decrypter.KeyStore = %v", d.KeyStore)
+			}
+			if d.KeyStore.ResourceDataGroups[0].ResourceData[0].CEKParams.Compression != compression {
+				t.Errorf("Decrypter.KeyStore compression = %v", d.KeyStore.ResourceDataGroups[0].ResourceData[0].CEKParams.Compression)
+			}
+			if len(got.RootRelationships) != 0 {
+				t.Errorf("Decoder.Decode() keystore relationship not hidden = %v", got.RootRelationships)
+			}
+			if len(got.Attachments) != 1 {
+				t.Fatalf("Decoder.Decode() attachments = %v", got.Attachments)
+			}
+			content, _ := ioutil.ReadAll(got.Attachments[0].Stream)
+			if string(content) != "secret" {
+				t.Errorf("Decoder.Decode() attachment = %s", content)
+			}
+			want := testModel()
+			got.Attachments, want.Attachments = nil, nil
+			if diff := deep.Equal(got, want); diff != nil {
+				t.Errorf("Decoder.Decode() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestEncrypter_NoParts(t *testing.T) {
+	e := NewEncrypter()
+	if err := e.AddConsumer(Consumer{ID: "printer"}, &testKey(t, 0).PublicKey); err != nil {
+		t.Fatal(err)
+	}
+	b := encode(t, e)
+	dec := go3mf.NewDecoder(bytes.NewReader(b), int64(len(b)))
+	d := NewDecrypter(nil)
+	dec.Decrypter = d
+	if err := dec.Decode(new(go3mf.Model)); err != nil {
+		t.Errorf("Decoder.Decode() error = %v", err)
+	}
+	if d.KeyStore != nil {
+		t.Errorf("Decrypter.KeyStore = %v, want nil", d.KeyStore)
+	}
+}
@@ -0,0 +1,141 @@
+// Package securecontent implements the 3MF Secure Content extension,
+// which encrypts package parts for a set of consumers.
+//
+// Parts are encrypted using AES-GCM with a content key that is wrapped
+// for each consumer using RSA-OAEP. The wrapped keys and the encryption
+// parameters are stored in the keystore part.
+package securecontent
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+)
+
+const (
+	// Namespace is the canonical name of this extension.
+	Namespace = "http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/04"
+	// RelTypeKeyStore is the canonical keystore relationship type.
+	RelTypeKeyStore = "http://schemas.microsoft.com/3dmanufacturing/2019/04/keystore"
+	// ContentTypeKeyStore is the keystore content type.
+	ContentTypeKeyStore = "application/vnd.ms-package.3dmanufacturing-keystore+xml"
+	// DefaultKeyStorePath is the recommended keystore part name.
+	DefaultKeyStorePath = "/Secure/keystore.xml"
+)
+
+// Supported algorithms.
+const (
+	// AlgorithmAES256GCM is the content encryption algorithm.
+	AlgorithmAES256GCM = "http://www.w3.org/2009/xmlenc11#aes256-gcm"
+	// AlgorithmRSAOAEP is the key wrapping algorithm using SHA1 as digest and MGF1 function.
+	AlgorithmRSAOAEP = "http://www.w3.org/2001/04/xmlenc#rsa-oaep-mgf1p"
+	// AlgorithmRSAOAEP11 is the key wrapping algorithm with configurable digest and MGF1 function.
+	AlgorithmRSAOAEP11 = "http://www.w3.org/2009/xmlenc11#rsa-oaep"
+
+	// MGF1SHA1 is the MGF1 function using SHA1.
+	MGF1SHA1 = "http://www.w3.org/2009/xmlenc11#mgf1sha1"
+	// MGF1SHA256 is the MGF1 function using SHA256.
+	MGF1SHA256 = "http://www.w3.org/2009/xmlenc11#mgf1sha256"
+
+	// DigestSHA1 is the SHA1 digest method.
+	DigestSHA1 = "http://www.w3.org/2000/09/xmldsig#sha1"
+	// DigestSHA256 is the SHA256 digest method.
+	DigestSHA256 = "http://www.w3.org/2001/04/xmlenc#sha256"
+)
+
+// Supported compressions, applied before encrypting.
+const (
+	CompressionNone    = "none"
+	CompressionDeflate = "deflate"
+)
+
+// Base64 is a byte slice encoded as a base64 text.
+type Base64 []byte
+
+// MarshalText encodes the bytes as base64.
+func (b Base64) MarshalText() ([]byte, error) {
+	dst := make([]byte, base64.StdEncoding.EncodedLen(len(b)))
+	base64.StdEncoding.Encode(dst, b)
+	return dst, nil
+}
+
+// UnmarshalText decodes base64 text.
+func (b *Base64) UnmarshalText(text []byte) error {
+	dst := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+	n, err := base64.StdEncoding.Decode(dst, text)
+	if err != nil {
+		return err
+	}
+	*b = dst[:n]
+	return nil
+}
+
+// A KeyStore contains the consumers of a package and the
+// information needed to decrypt its encrypted parts.
+// It can be read and written using the encoding/xml package.
+type KeyStore struct {
+	XMLName            xml.Name            `xml:"http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/04 keystore"`
+	UUID               string              `xml:"UUID,attr"`
+	Consumers          []Consumer          `xml:"consumer"`
+	ResourceDataGroups []ResourceDataGroup `xml:"resourcedatagroup"`
+}
+
+// FindResourceData returns the group index and the resource data of the part with the target path.
+func (k *KeyStore) FindResourceData(path string) (int, *ResourceData, bool) {
+	for i := range k.ResourceDataGroups {
+		for j := range k.ResourceDataGroups[i].ResourceData {
+			if rd := &k.ResourceDataGroups[i].ResourceData[j]; equalPath(rd.Path, path) {
+				return i, rd, true
+			}
+		}
+	}
+	return 0, nil, false
+}
+
+// A Consumer is an entity that can decrypt the content of the package.
+// KeyValue is the PEM encoded public key of the consumer.
+type Consumer struct {
+	ID       string `xml:"consumerid,attr"`
+	KeyID    string `xml:"keyid,attr,omitempty"`
+	KeyValue string `xml:"keyvalue,omitempty"`
+}
+
+// A ResourceDataGroup groups the parts encrypted with the same content key.
+type ResourceDataGroup struct {
+	KeyUUID      string         `xml:"keyuuid,attr"`
+	AccessRights []AccessRight  `xml:"accessright"`
+	ResourceData []ResourceData `xml:"resourcedata"`
+}
+
+// An AccessRight contains the content key wrapped for a consumer.
+type AccessRight struct {
+	ConsumerIndex int        `xml:"consumerindex,attr"`
+	KEKParams     KEKParams  `xml:"kekparams"`
+	CipherData    CipherData `xml:"cipherdata"`
+}
+
+// KEKParams defines how the content key is wrapped.
+type KEKParams struct {
+	WrappingAlgorithm string `xml:"wrappingalgorithm,attr"`
+	MGFAlgorithm      string `xml:"mgfalgorithm,attr,omitempty"`
+	DigestMethod      string `xml:"digestmethod,attr,omitempty"`
+}
+
+// CipherData contains an encrypted value.
+type CipherData struct {
+	CipherValue Base64 `xml:"http://www.w3.org/2001/04/xmlenc# CipherValue"`
+}
+
+// ResourceData defines an encrypted part.
+type ResourceData struct {
+	Path      string    `xml:"path,attr"`
+	CEKParams CEKParams `xml:"cekparams"`
+}
+
+// CEKParams defines how a part is encrypted.
+type CEKParams struct {
+	EncryptionAlgorithm string `xml:"encryptionalgorithm,attr"`
+	Compression         string `xml:"compression,attr,omitempty"`
+	IV                  Base64 `xml:"iv"`
+	Tag                 Base64 `xml:"tag"`
+	AAD                 Base64 `xml:"aad,omitempty"`
+}
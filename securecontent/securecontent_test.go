@@ -0,0 +1,94 @@
+package securecontent
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+const testKeyStore = `<?xml version="1.0" encoding="UTF-8"?>
+<keystore xmlns="http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/04" xmlns:xenc="http://www.w3.org/2001/04/xmlenc#" UUID="b7aa9c6d-e6d8-4b1c-9e8b-5bc7a3ad0ab2">
+	<consumer consumerid="printer" keyid="k1"><keyvalue>key</keyvalue></consumer>
+	<resourcedatagroup keyuuid="c9d0a5b0-1c8e-4a8b-b9b2-3f4b8e2f0a11">
+		<accessright consumerindex="0">
+			<kekparams wrappingalgorithm="http://www.w3.org/2001/04/xmlenc#rsa-oaep-mgf1p" mgfalgorithm="http://www.w3.org/2009/xmlenc11#mgf1sha1" digestmethod="http://www.w3.org/2000/09/xmldsig#sha1"/>
+			<cipherdata><xenc:CipherValue>AQID</xenc:CipherValue></cipherdata>
+		</accessright>
+		<resourcedata path="/3D/3dmodel.model">
+			<cekparams encryptionalgorithm="http://www.w3.org/2009/xmlenc11#aes256-gcm" compression="deflate">
+				<iv>BAUG</iv>
+				<tag>BwgJ</tag>
+				<aad>Cg==</aad>
+			</cekparams>
+		</resourcedata>
+	</resourcedatagroup>
+</keystore>`
+
+func TestKeyStore_XML(t *testing.T) {
+	want := &KeyStore{
+		XMLName:   xml.Name{Space: Namespace, Local: "keystore"},
+		UUID:      "b7aa9c6d-e6d8-4b1c-9e8b-5bc7a3ad0ab2",
+		Consumers: []Consumer{{ID: "printer", KeyID: "k1", KeyValue: "key"}},
+		ResourceDataGroups: []ResourceDataGroup{{
+			KeyUUID: "c9d0a5b0-1c8e-4a8b-b9b2-3f4b8e2f0a11",
+			AccessRights: []AccessRight{{
+				KEKParams:  KEKParams{WrappingAlgorithm: AlgorithmRSAOAEP, MGFAlgorithm: MGF1SHA1, DigestMethod: DigestSHA1},
+				CipherData: CipherData{CipherValue: Base64{1, 2, 3}},
+			}},
+			ResourceData: []ResourceData{{
+				Path: "/3D/3dmodel.model",
+				CEKParams: CEKParams{
+					EncryptionAlgorithm: AlgorithmAES256GCM, Compression: CompressionDeflate,
+					IV: Base64{4, 5, 6}, Tag: Base64{7, 8, 9}, AAD: Base64{10},
+				},
+			}},
+		}},
+	}
+	got := new(KeyStore)
+	if err := xml.Unmarshal([]byte(testKeyStore), got); err != nil {
+		t.Errorf("KeyStore unmarshal error = %v", err)
+		return
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("KeyStore unmarshal = %v", diff)
+		return
+	}
+	b, err := xml.Marshal(want)
+	if err != nil {
+		t.Errorf("KeyStore marshal error = %v", err)
+		return
+	}
+	got = new(KeyStore)
+	if err := xml.Unmarshal(b, got); err != nil {
+		t.Errorf("KeyStore marshal malformed = %v", err)
+		return
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("KeyStore marshal = %v", diff)
+	}
+}
+
+func TestKeyStore_FindResourceData(t *testing.T) {
+	ks := &KeyStore{ResourceDataGroups: []ResourceDataGroup{
+		{ResourceData: []ResourceData{{Path: "/a.model"}}},
+		{ResourceData: []ResourceData{{Path: "/b.model"}, {Path: "/c.png"}}},
+	}}
+	tests := []struct {
+		path  string
+		group int
+		ok    bool
+	}{
+		{"/a.model", 0, true},
+		{"/C.PNG", 1, true},
+		{"/d.png", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			group, rd, ok := ks.FindResourceData(tt.path)
+			if ok != tt.ok || group != tt.group || (ok && !equalPath(rd.Path, tt.path)) {
+				t.Errorf("KeyStore.FindResourceData() = %v, %v, %v", group, rd, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,444 @@
+package go3mf
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/qmuntal/opc"
+)
+
+const (
+	// RelTypeSignatureOrigin is the canonical digital signature origin relationship type.
+	RelTypeSignatureOrigin = "http://schemas.openxmlformats.org/package/2006/relationships/digital-signature/origin"
+	// RelTypeSignature is the canonical XML digital signature relationship type.
+	RelTypeSignature = "http://schemas.openxmlformats.org/package/2006/relationships/digital-signature/signature"
+
+	// DefaultSignatureOriginName is the recommended signature origin part name.
+	DefaultSignatureOriginName = "/package/services/digital-signature/origin.psdsor"
+	// DefaultSignatureDir is the recommended directory for XML signature parts.
+	DefaultSignatureDir = "/package/services/digital-signature/xml-signature/"
+
+	// ContentTypeSignatureOrigin is the digital signature origin content type.
+	ContentTypeSignatureOrigin = "application/vnd.openxmlformats-package.digital-signature-origin"
+	// ContentTypeSignature is the XML digital signature content type.
+	ContentTypeSignature = "application/vnd.openxmlformats-package.digital-signature-xmlsignature+xml"
+)
+
+const (
+	nsXMLDSig               = "http://www.w3.org/2000/09/xmldsig#"
+	nsDigitalSignature      = "http://schemas.openxmlformats.org/package/2006/digital-signature"
+	nsRelationships         = "http://schemas.openxmlformats.org/package/2006/relationships"
+	contentTypeRelationship = "application/vnd.openxmlformats-package.relationships+xml"
+
+	algC14N                  = "http://www.w3.org/TR/2001/REC-xml-c14n-20010315"
+	algRelationshipTransform = "http://schemas.openxmlformats.org/package/2006/RelationshipTransform"
+	algSHA1                  = "http://www.w3.org/2000/09/xmldsig#sha1"
+	algSHA256                = "http://www.w3.org/2001/04/xmlenc#sha256"
+	algSHA512                = "http://www.w3.org/2001/04/xmlenc#sha512"
+	algRSASHA1               = "http://www.w3.org/2000/09/xmldsig#rsa-sha1"
+	algRSASHA256             = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	algRSASHA512             = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	algECDSASHA256           = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+	algECDSASHA512           = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha512"
+
+	packageObjectID     = "idPackageObject"
+	signatureTimeFormat = "YYYY-MM-DDThh:mm:ssTZD"
+)
+
+// A Signature is the result of verifying an OPC digital signature.
+//
+// Valid reports whether the signature value matches the SignedInfo
+// and the embedded certificate and whether the signed package object is intact.
+// The certificate chain is not validated.
+// References are the parts and relationships covered by the signature.
+type Signature struct {
+	Path        string
+	Certificate *x509.Certificate
+	SigningTime time.Time
+	Valid       bool
+	References  []SignedReference
+}
+
+// Verified reports whether the signature and all the digests of its references are valid.
+func (s *Signature) Verified() bool {
+	if !s.Valid {
+		return false
+	}
+	for _, r := range s.References {
+		if !r.Valid {
+			return false
+		}
+	}
+	return true
+}
+
+// A SignedReference is a part covered by a signature.
+//
+// If the part is a relationships part, Relationships contains
+// the identifiers of the signed relationships.
+// Valid reports whether the part exists, has the signed content type and its digest matches.
+type SignedReference struct {
+	Path          string
+	ContentType   string
+	Relationships []string
+	Valid         bool
+}
+
+type xmlAlgorithm struct {
+	Algorithm string `xml:"Algorithm,attr"`
+}
+
+type xmlSourceID struct {
+	SourceID string `xml:"SourceId,attr"`
+}
+
+type xmlSourceType struct {
+	SourceType string `xml:"SourceType,attr"`
+}
+
+type xmlTransform struct {
+	Algorithm   string          `xml:"Algorithm,attr"`
+	SourceIDs   []xmlSourceID   `xml:"http://schemas.openxmlformats.org/package/2006/digital-signature RelationshipReference"`
+	SourceTypes []xmlSourceType `xml:"http://schemas.openxmlformats.org/package/2006/digital-signature RelationshipsGroupReference"`
+}
+
+type xmlReference struct {
+	URI          string         `xml:"URI,attr"`
+	Transforms   []xmlTransform `xml:"Transforms>Transform"`
+	DigestMethod xmlAlgorithm
+	DigestValue  string
+}
+
+func (r *xmlReference) relationshipTransform() (*xmlTransform, bool) {
+	for i := range r.Transforms {
+		if r.Transforms[i].Algorithm == algRelationshipTransform {
+			return &r.Transforms[i], true
+		}
+	}
+	return nil, false
+}
+
+// partURI returns the part name and the content type of a package reference.
+func (r *xmlReference) partURI() (string, string) {
+	name, contentType := r.URI, ""
+	if i := strings.IndexByte(name, '?'); i >= 0 {
+		name, contentType = name[:i], strings.TrimPrefix(name[i+1:], "ContentType=")
+	}
+	if n, err := url.PathUnescape(name); err == nil {
+		name = n
+	}
+	if c, err := url.PathUnescape(contentType); err == nil {
+		contentType = c
+	}
+	return name, contentType
+}
+
+type xmlObject struct {
+	ID         string         `xml:"Id,attr"`
+	References []xmlReference `xml:"Manifest>Reference"`
+	Times      []string       `xml:"SignatureProperties>SignatureProperty>SignatureTime>Value"`
+}
+
+type xmlSignedInfo struct {
+	SignatureMethod xmlAlgorithm
+	References      []xmlReference `xml:"Reference"`
+}
+
+// xmlSignature only reads the direct children in the xmldsig namespace,
+// which are the ones that canonicalize verifies.
+type xmlSignature struct {
+	XMLName        xml.Name        `xml:"http://www.w3.org/2000/09/xmldsig# Signature"`
+	SignedInfo     []xmlSignedInfo `xml:"http://www.w3.org/2000/09/xmldsig# SignedInfo"`
+	SignatureValue string          `xml:"http://www.w3.org/2000/09/xmldsig# SignatureValue"`
+	Certificates   []string        `xml:"KeyInfo>X509Data>X509Certificate"`
+	Objects        []xmlObject     `xml:"http://www.w3.org/2000/09/xmldsig# Object"`
+}
+
+func (s *xmlSignature) packageObject() (*xmlObject, bool) {
+	for i := range s.Objects {
+		if s.Objects[i].ID == packageObjectID {
+			return &s.Objects[i], true
+		}
+	}
+	return nil, false
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
+
+func newDigestHash(alg string) (crypto.Hash, bool) {
+	switch alg {
+	case algSHA1:
+		return crypto.SHA1, true
+	case algSHA256:
+		return crypto.SHA256, true
+	case algSHA512:
+		return crypto.SHA512, true
+	}
+	return 0, false
+}
+
+func digest(h crypto.Hash, data []byte) []byte {
+	switch h {
+	case crypto.SHA1:
+		d := sha1.Sum(data)
+		return d[:]
+	case crypto.SHA512:
+		d := sha512.Sum512(data)
+		return d[:]
+	}
+	d := sha256.Sum256(data)
+	return d[:]
+}
+
+func matchDigest(ref *xmlReference, data []byte) bool {
+	h, ok := newDigestHash(ref.DigestMethod.Algorithm)
+	if !ok {
+		return false
+	}
+	value, err := decodeBase64(ref.DigestValue)
+	return err == nil && bytes.Equal(value, digest(h, data))
+}
+
+// relationshipsSource returns the source part name of a relationships part,
+// which is "/" for the package relationships.
+func relationshipsSource(name string) (string, bool) {
+	dir, base := path.Split(name)
+	if !strings.HasSuffix(dir, "/_rels/") || !strings.HasSuffix(strings.ToLower(base), ".rels") {
+		return "", false
+	}
+	return path.Join(strings.TrimSuffix(dir, "_rels/"), base[:len(base)-len(".rels")]), true
+}
+
+// relationshipsName returns the name of the relationships part of source.
+func relationshipsName(source string) string {
+	dir, base := path.Split(source)
+	return dir + "_rels/" + base + ".rels"
+}
+
+// VerifySignatures verifies the OPC digital signatures of the package.
+// It does not require the package to be decoded.
+//
+// The signatures are verified against the raw content of the package,
+// before decrypting any part.
+func (d *Decoder) VerifySignatures() ([]Signature, error) {
+	if d.ra == nil {
+		return nil, errors.New("signatures can only be verified on OPC packages")
+	}
+	r, err := opc.NewReader(d.ra, d.size)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(d.ra, d.size)
+	if err != nil {
+		return nil, err
+	}
+	v := &signatureVerifier{r: r, zr: zr}
+	var sigs []Signature
+	for _, rel := range r.Relationships {
+		if rel.Type != RelTypeSignatureOrigin {
+			continue
+		}
+		origin, ok := v.file(rel.TargetURI)
+		if !ok {
+			continue
+		}
+		for _, srel := range origin.Relationships {
+			if srel.Type != RelTypeSignature {
+				continue
+			}
+			name := opc.ResolveRelationship(origin.Name, srel.TargetURI)
+			data, err := v.read(name)
+			if err != nil {
+				sigs = append(sigs, Signature{Path: name})
+				continue
+			}
+			sigs = append(sigs, v.verify(name, data))
+		}
+	}
+	return sigs, nil
+}
+
+type signatureVerifier struct {
+	r  *opc.Reader
+	zr *zip.Reader
+}
+
+func (v *signatureVerifier) file(name string) (*opc.File, bool) {
+	for _, f := range v.r.Files {
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+func (v *signatureVerifier) read(name string) ([]byte, error) {
+	name = strings.TrimPrefix(name, "/")
+	for _, f := range v.zr.File {
+		if strings.EqualFold(f.Name, name) {
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return ioutil.ReadAll(rc)
+		}
+	}
+	return nil, errors.New("part does not exist: " + name)
+}
+
+func (v *signatureVerifier) verify(name string, data []byte) Signature {
+	sig := Signature{Path: name}
+	var xsig xmlSignature
+	if err := xml.Unmarshal(data, &xsig); err != nil {
+		return sig
+	}
+	if len(xsig.Certificates) > 0 {
+		if der, err := decodeBase64(xsig.Certificates[0]); err == nil {
+			sig.Certificate, _ = x509.ParseCertificate(der)
+		}
+	}
+	if obj, ok := xsig.packageObject(); ok {
+		if len(obj.Times) > 0 {
+			sig.SigningTime, _ = time.Parse(time.RFC3339, strings.TrimSpace(obj.Times[0]))
+		}
+		for i := range obj.References {
+			sig.References = append(sig.References, v.verifyReference(&obj.References[i]))
+		}
+	}
+	sig.Valid = sig.Certificate != nil && verifySignedInfo(data, &xsig, sig.Certificate)
+	return sig
+}
+
+func (v *signatureVerifier) verifyReference(ref *xmlReference) SignedReference {
+	name, contentType := ref.partURI()
+	sref := SignedReference{Path: name, ContentType: contentType}
+	data, err := v.read(name)
+	if err != nil {
+		return sref
+	}
+	if t, ok := ref.relationshipTransform(); ok {
+		var rels xmlRelationships
+		if _, isRels := relationshipsSource(name); !isRels || xml.Unmarshal(data, &rels) != nil {
+			return sref
+		}
+		ids, types := t.selection()
+		sref.Relationships = selectedRelationships(rels.Relationships, ids, types)
+		sref.Valid = strings.EqualFold(contentType, contentTypeRelationship) &&
+			matchDigest(ref, transformRelationships(rels.Relationships, ids, types))
+		return sref
+	}
+	actual := contentTypeRelationship
+	if _, isRels := relationshipsSource(name); !isRels {
+		f, ok := v.file(name)
+		if !ok {
+			return sref
+		}
+		actual = f.ContentType
+	}
+	sref.Valid = strings.EqualFold(contentType, actual) && matchDigest(ref, data)
+	return sref
+}
+
+func (t *xmlTransform) selection() ([]string, []string) {
+	ids := make([]string, len(t.SourceIDs))
+	for i, s := range t.SourceIDs {
+		ids[i] = s.SourceID
+	}
+	types := make([]string, len(t.SourceTypes))
+	for i, s := range t.SourceTypes {
+		types[i] = s.SourceType
+	}
+	return ids, types
+}
+
+func selectedRelationships(rels []xmlRelationship, ids, types []string) []string {
+	var selected []string
+	for _, r := range rels {
+		if containsString(ids, r.ID) || containsString(types, r.Type) {
+			selected = append(selected, r.ID)
+		}
+	}
+	return selected
+}
+
+// verifySignedInfo checks the signature value and the signed objects,
+// which must include the package object.
+func verifySignedInfo(data []byte, xsig *xmlSignature, cert *x509.Certificate) bool {
+	if len(xsig.SignedInfo) != 1 {
+		return false
+	}
+	si := &xsig.SignedInfo[0]
+	var packageSigned bool
+	for i := range si.References {
+		ref := &si.References[i]
+		if !strings.HasPrefix(ref.URI, "#") {
+			return false
+		}
+		obj, err := canonicalize(data, "Object", ref.URI[1:])
+		if err != nil || !matchDigest(ref, obj) {
+			return false
+		}
+		packageSigned = packageSigned || ref.URI[1:] == packageObjectID
+	}
+	if !packageSigned {
+		return false
+	}
+	signedInfo, err := canonicalize(data, "SignedInfo", "")
+	if err != nil {
+		return false
+	}
+	value, err := decodeBase64(xsig.SignatureValue)
+	if err != nil {
+		return false
+	}
+	return verifySignatureValue(si.SignatureMethod.Algorithm, cert.PublicKey, signedInfo, value)
+}
+
+func verifySignatureValue(alg string, pub crypto.PublicKey, signedInfo, value []byte) bool {
+	var h crypto.Hash
+	switch alg {
+	case algRSASHA1:
+		h = crypto.SHA1
+	case algRSASHA256, algECDSASHA256:
+		h = crypto.SHA256
+	case algRSASHA512, algECDSASHA512:
+		h = crypto.SHA512
+	default:
+		return false
+	}
+	hashed := digest(h, signedInfo)
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		if alg == algECDSASHA256 || alg == algECDSASHA512 {
+			return false
+		}
+		return rsa.VerifyPKCS1v15(pub, h, hashed, value) == nil
+	case *ecdsa.PublicKey:
+		if alg != algECDSASHA256 && alg != algECDSASHA512 || len(value)%2 != 0 {
+			return false
+		}
+		r := new(big.Int).SetBytes(value[:len(value)/2])
+		s := new(big.Int).SetBytes(value[len(value)/2:])
+		return ecdsa.Verify(pub, hashed, r, s)
+	}
+	return false
+}
@@ -0,0 +1,345 @@
+package go3mf
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestCertificate(t *testing.T, signer crypto.Signer) *x509.Certificate {
+	t.Helper()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "go3mf"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, signer.Public(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func newSignedTestModel() *Model {
+	return &Model{
+		Path:      DefaultModelPath,
+		Resources: Resources{Objects: []*Object{{ID: 1, Mesh: &Mesh{Vertices: []Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}}, Triangles: []Triangle{NewTriangle(0, 1, 2)}}}}},
+		Build:     Build{Items: []*Item{{ObjectID: 1}}},
+		Attachments: []Attachment{
+			{Path: "/3D/Other/data.txt", ContentType: "text/plain", Stream: bytes.NewBufferString("data")},
+		},
+		Relationships: []Relationship{{ID: "1", Path: "/3D/Other/data.txt", Type: "http://www.example.com/data"}},
+	}
+}
+
+func encodeSigned(t *testing.T, m *Model, signer crypto.Signer) []byte {
+	t.Helper()
+	buff := new(bytes.Buffer)
+	enc := NewEncoder(buff)
+	if signer != nil {
+		enc.Signer = signer
+		enc.Certificate = newTestCertificate(t, signer)
+	}
+	if err := enc.Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	return buff.Bytes()
+}
+
+func verifySigned(t *testing.T, data []byte) ([]Signature, *Model) {
+	t.Helper()
+	d := NewDecoder(bytes.NewReader(data), int64(len(data)))
+	m := new(Model)
+	if err := d.Decode(m); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	sigs, err := d.VerifySignatures()
+	if err != nil {
+		t.Fatalf("Decoder.VerifySignatures() error = %v", err)
+	}
+	return sigs, m
+}
+
+func findReference(sig Signature, name string) (SignedReference, bool) {
+	for _, r := range sig.References {
+		if r.Path == name {
+			return r, true
+		}
+	}
+	return SignedReference{}, false
+}
+
+func TestEncoder_Encode_Signer(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		signer crypto.Signer
+	}{
+		{"rsa", rsaKey},
+		{"ecdsa", ecKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sigs, m := verifySigned(t, encodeSigned(t, newSignedTestModel(), tt.signer))
+			if len(sigs) != 1 {
+				t.Fatalf("Decoder.VerifySignatures() = %d signatures, want 1", len(sigs))
+			}
+			sig := sigs[0]
+			if !sig.Verified() {
+				t.Errorf("Decoder.VerifySignatures() not verified = %+v", sig)
+			}
+			if sig.Certificate == nil || sig.Certificate.Subject.CommonName != "go3mf" {
+				t.Errorf("Decoder.VerifySignatures() certificate = %v", sig.Certificate)
+			}
+			if sig.SigningTime.IsZero() {
+				t.Error("Decoder.VerifySignatures() missing signing time")
+			}
+			for _, name := range []string{DefaultModelPath, "/3D/Other/data.txt", "/_rels/.rels", "/3D/_rels/3dmodel.model.rels"} {
+				if _, ok := findReference(sig, name); !ok {
+					t.Errorf("Decoder.VerifySignatures() missing reference %s", name)
+				}
+			}
+			if r, _ := findReference(sig, "/_rels/.rels"); len(r.Relationships) != 1 {
+				t.Errorf("Decoder.VerifySignatures() package relationships = %v", r.Relationships)
+			}
+			for _, r := range m.RootRelationships {
+				if r.Type == RelTypeSignatureOrigin {
+					return
+				}
+			}
+			t.Error("Decoder.Decode() signature origin not preserved")
+		})
+	}
+}
+
+func TestEncoder_Encode_SignerNoCertificate(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := NewEncoder(new(bytes.Buffer))
+	enc.Signer = key
+	if err := enc.Encode(newSignedTestModel()); err == nil {
+		t.Error("Encoder.Encode() expected error")
+	}
+}
+
+func TestEncoder_Encode_PreservedSignatures(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, m := verifySigned(t, encodeSigned(t, newSignedTestModel(), key))
+	sigs, m := verifySigned(t, encodeSigned(t, m, nil))
+	if len(sigs) != 1 || !sigs[0].Verified() {
+		t.Fatalf("Encoder.Encode() unchanged signatures = %+v", sigs)
+	}
+
+	sigs, m = verifySigned(t, encodeSigned(t, m, key))
+	if len(sigs) != 2 || !sigs[0].Verified() || !sigs[1].Verified() {
+		t.Fatalf("Encoder.Encode() resigned signatures = %+v", sigs)
+	}
+	if sigs[0].Path == sigs[1].Path {
+		t.Errorf("Encoder.Encode() duplicated signature part %s", sigs[0].Path)
+	}
+
+	m.Resources.Objects[0].Mesh.Vertices[0] = Point3D{0, 0, 1}
+	sigs, m = verifySigned(t, encodeSigned(t, m, nil))
+	if len(sigs) != 0 {
+		t.Errorf("Encoder.Encode() changed content signatures = %+v", sigs)
+	}
+	for _, a := range m.Attachments {
+		if a.ContentType == ContentTypeSignature || a.ContentType == ContentTypeSignatureOrigin {
+			t.Errorf("Encoder.Encode() kept signature part %s", a.Path)
+		}
+	}
+}
+
+// rewritePackage returns a copy of the package data whose parts are replaced by fn.
+func rewritePackage(t *testing.T, data []byte, fn func(name string, content []byte) []byte) []byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buff := new(bytes.Buffer)
+	zw := zip.NewWriter(buff)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := zw.Create(f.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write(fn(f.Name, content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buff.Bytes()
+}
+
+func TestDecoder_VerifySignatures_Tampered(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := rewritePackage(t, encodeSigned(t, newSignedTestModel(), key), func(name string, content []byte) []byte {
+		if name == "3D/Other/data.txt" {
+			return []byte("tampered")
+		}
+		return content
+	})
+	sigs, _ := verifySigned(t, data)
+	if len(sigs) != 1 {
+		t.Fatalf("Decoder.VerifySignatures() = %d signatures, want 1", len(sigs))
+	}
+	if !sigs[0].Valid || sigs[0].Verified() {
+		t.Errorf("Decoder.VerifySignatures() tampered signature = %+v", sigs[0])
+	}
+	for _, r := range sigs[0].References {
+		if want := r.Path != "/3D/Other/data.txt"; r.Valid != want {
+			t.Errorf("Decoder.VerifySignatures() reference %s valid = %v, want %v", r.Path, r.Valid, want)
+		}
+	}
+}
+
+func TestDecoder_VerifySignatures_Wrapped(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := encodeSigned(t, newSignedTestModel(), key)
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{"sameID", packageObjectID},
+		{"renamedID", "idMovedObject"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Move the signed object into KeyInfo and add a direct child object
+			// that does not reference the tampered part.
+			tampered := rewritePackage(t, data, func(name string, content []byte) []byte {
+				if name == "3D/Other/data.txt" {
+					return []byte("tampered")
+				}
+				if !strings.HasPrefix(name, strings.TrimPrefix(DefaultSignatureDir, "/")) {
+					return content
+				}
+				s := string(content)
+				obj := s[strings.Index(s, "<Object") : strings.Index(s, "</Object>")+len("</Object>")]
+				start := strings.Index(obj, `<Reference URI="/3D/Other/data.txt`)
+				end := start + strings.Index(obj[start:], "</Reference>") + len("</Reference>")
+				moved := strings.Replace(obj, `Id="`+packageObjectID+`"`, `Id="`+tt.id+`"`, 1)
+				s = strings.Replace(s, obj, obj[:start]+obj[end:], 1)
+				return []byte(strings.Replace(s, "</KeyInfo>", moved+"</KeyInfo>", 1))
+			})
+			sigs, _ := verifySigned(t, tampered)
+			if len(sigs) != 1 {
+				t.Fatalf("Decoder.VerifySignatures() = %d signatures, want 1", len(sigs))
+			}
+			if sigs[0].Valid || sigs[0].Verified() {
+				t.Errorf("Decoder.VerifySignatures() wrapped signature = %+v", sigs[0])
+			}
+		})
+	}
+}
+
+func TestDecoder_VerifySignatures_Unsigned(t *testing.T) {
+	sigs, _ := verifySigned(t, encodeSigned(t, newSignedTestModel(), nil))
+	if len(sigs) != 0 {
+		t.Errorf("Decoder.VerifySignatures() = %v, want none", sigs)
+	}
+}
+
+func Test_canonicalize(t *testing.T) {
+	sig := func(content string) string {
+		return `<Signature xmlns="` + nsXMLDSig + `">` + content + `</Signature>`
+	}
+	tests := []struct {
+		name    string
+		data    string
+		local   string
+		id      string
+		want    string
+		wantErr bool
+	}{
+		{"notFound", sig(``), "Object", "", "", true},
+		{"empty", sig(`<Object/>`), "Object", "", `<Object xmlns="` + nsXMLDSig + `"></Object>`, false},
+		{"inheritNs", `<Signature xmlns="` + nsXMLDSig + `" xmlns:p="pns"><Object z="1" p:y="2" a="&lt;"/></Signature>`, "Object", "",
+			`<Object xmlns="` + nsXMLDSig + `" xmlns:p="pns" a="&lt;" z="1" p:y="2"></Object>`, false},
+		{"nestedNs", sig(`<Object><c xmlns="` + nsXMLDSig + `">x &gt; y<d xmlns=""/></c></Object>`), "Object", "",
+			`<Object xmlns="` + nsXMLDSig + `"><c>x &gt; y<d xmlns=""></d></c></Object>`, false},
+		{"byID", sig(`<Object Id="1">1</Object><Object Id="2"><!-- c -->2</Object>`), "Object", "2", `<Object xmlns="` + nsXMLDSig + `" Id="2">2</Object>`, false},
+		{"attrEscape", sig("<Object b='\"&#9;'/>"), "Object", "", `<Object xmlns="` + nsXMLDSig + `" b="&quot;&#x9;"></Object>`, false},
+		{"notSignature", `<a xmlns="` + nsXMLDSig + `"><Object/></a>`, "Object", "", "", true},
+		{"nested", sig(`<KeyInfo><Object/></KeyInfo>`), "Object", "", "", true},
+		{"otherNamespace", sig(`<Object xmlns="ns"/>`), "Object", "", "", true},
+		{"duplicatedElement", sig(`<SignedInfo/><SignedInfo/>`), "SignedInfo", "", "", true},
+		{"duplicatedID", sig(`<Object Id="1"/><KeyInfo><a Id="1"/></KeyInfo>`), "Object", "1", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := canonicalize([]byte(tt.data), tt.local, tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("canonicalize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("canonicalize() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_transformRelationships(t *testing.T) {
+	rels := []xmlRelationship{
+		{ID: "b", Type: "t1", Target: "/b"},
+		{ID: "a", Type: "t2", Target: "/a&", TargetMode: "External"},
+		{ID: "c", Type: "t3", Target: "/c"},
+	}
+	got := string(transformRelationships(rels, []string{"b"}, []string{"t2"}))
+	want := `<Relationships xmlns="` + nsRelationships + `">` +
+		`<Relationship Id="a" Target="/a&amp;" TargetMode="External" Type="t2"></Relationship>` +
+		`<Relationship Id="b" Target="/b" TargetMode="Internal" Type="t1"></Relationship></Relationships>`
+	if got != want {
+		t.Errorf("transformRelationships() = %s, want %s", got, want)
+	}
+	if !strings.Contains(string(transformRelationships(newXMLRelationships([]Relationship{{ID: "1", Path: "a"}}), []string{"1"}, nil)), `Target="/a"`) {
+		t.Error("newXMLRelationships() target not normalized")
+	}
+}
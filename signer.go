@@ -0,0 +1,429 @@
+package go3mf
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"hash"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type signedPart struct {
+	name        string
+	contentType string
+	hashes      map[crypto.Hash]hash.Hash
+	rels        []Relationship
+}
+
+func (p *signedPart) addRelationship(r Relationship) {
+	for _, ro := range p.rels {
+		if ro.Type == r.Type && ro.Path == r.Path {
+			return
+		}
+	}
+	p.rels = append(p.rels, r)
+}
+
+// assignIDs sets a stable identifier to the relationships without one,
+// so the signed relationships match the written ones.
+func (p *signedPart) assignIDs() {
+	for i, r := range p.rels {
+		if r.ID != "" {
+			continue
+		}
+		sum := sha1.Sum([]byte(r.Type + r.Path))
+		id := "R" + strings.ToUpper(hex.EncodeToString(sum[:8]))
+		for n := 1; p.hasID(id); n++ {
+			id = "R" + strings.ToUpper(hex.EncodeToString(sum[:8])) + strconv.Itoa(n)
+		}
+		p.rels[i].ID = id
+	}
+}
+
+func (p *signedPart) hasID(id string) bool {
+	for _, r := range p.rels {
+		if r.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+type signedPartWriter struct {
+	io.Writer
+	p *signedPart
+}
+
+func (w *signedPartWriter) AddRelationship(r Relationship) {
+	w.p.addRelationship(r)
+}
+
+// signedWriter records the digests and the relationships of the written parts
+// to sign the package and to keep the preserved signatures that are still valid.
+type signedWriter struct {
+	packageWriter
+	signer    crypto.Signer
+	cert      *x509.Certificate
+	origin    *Attachment
+	preserved []Attachment
+	xsigs     []xmlSignature
+	hashes    []crypto.Hash
+	root      signedPart
+	parts     []*signedPart
+	current   packagePart
+}
+
+// newSignedWriter returns a signedWriter. The signature attachments contain
+// the signature origin and the signature parts of a previously signed package.
+func newSignedWriter(w packageWriter, signer crypto.Signer, cert *x509.Certificate, signatures []Attachment) (*signedWriter, error) {
+	if signer != nil && cert == nil {
+		return nil, errors.New("package signer requires a certificate")
+	}
+	sw := &signedWriter{packageWriter: w, signer: signer, cert: cert, root: signedPart{name: "/"}}
+	if signer != nil {
+		sw.hashes = append(sw.hashes, crypto.SHA256)
+	}
+	for i, a := range signatures {
+		if a.ContentType == ContentTypeSignatureOrigin {
+			sw.origin = &signatures[i]
+			continue
+		}
+		data, err := ioutil.ReadAll(a.Stream)
+		if err != nil {
+			return nil, err
+		}
+		var xsig xmlSignature
+		if xml.Unmarshal(data, &xsig) != nil {
+			continue
+		}
+		obj, ok := xsig.packageObject()
+		if !ok {
+			continue
+		}
+		for _, ref := range obj.References {
+			if h, ok := newDigestHash(ref.DigestMethod.Algorithm); ok && !sw.hasHash(h) {
+				sw.hashes = append(sw.hashes, h)
+			}
+		}
+		a.Stream = bytes.NewReader(data)
+		sw.preserved = append(sw.preserved, a)
+		sw.xsigs = append(sw.xsigs, xsig)
+	}
+	return sw, nil
+}
+
+func (w *signedWriter) hasHash(h crypto.Hash) bool {
+	for _, e := range w.hashes {
+		if e == h {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *signedWriter) flushCurrent() {
+	if w.current == nil {
+		return
+	}
+	p := w.parts[len(w.parts)-1]
+	p.assignIDs()
+	for _, r := range p.rels {
+		w.current.AddRelationship(r)
+	}
+	w.current = nil
+}
+
+func (w *signedWriter) Create(name, contentType string) (packagePart, error) {
+	w.flushCurrent()
+	part, err := w.packageWriter.Create(name, contentType)
+	if err != nil {
+		return nil, err
+	}
+	p := &signedPart{name: name, contentType: contentType, hashes: make(map[crypto.Hash]hash.Hash)}
+	writers := []io.Writer{part}
+	for _, h := range w.hashes {
+		p.hashes[h] = h.New()
+		writers = append(writers, p.hashes[h])
+	}
+	w.parts = append(w.parts, p)
+	w.current = part
+	return &signedPartWriter{Writer: io.MultiWriter(writers...), p: p}, nil
+}
+
+func (w *signedWriter) AddRelationship(r Relationship) {
+	w.root.addRelationship(r)
+}
+
+func (w *signedWriter) Close() error {
+	w.flushCurrent()
+	w.root.assignIDs()
+	for _, r := range w.root.rels {
+		w.packageWriter.AddRelationship(r)
+	}
+	var signatures []Attachment
+	for i, a := range w.preserved {
+		if w.matches(&w.xsigs[i]) {
+			signatures = append(signatures, a)
+		}
+	}
+	if w.signer != nil {
+		data, err := w.sign(time.Now())
+		if err != nil {
+			return err
+		}
+		signatures = append(signatures, Attachment{
+			Path:        w.newSignatureName(signatures),
+			ContentType: ContentTypeSignature,
+			Stream:      bytes.NewReader(data),
+		})
+	}
+	if len(signatures) > 0 {
+		if err := w.writeSignatures(signatures); err != nil {
+			return err
+		}
+	}
+	return w.packageWriter.Close()
+}
+
+func (w *signedWriter) newSignatureName(signatures []Attachment) string {
+	for i := 1; ; i++ {
+		name := DefaultSignatureDir + "sig" + strconv.Itoa(i) + ".psdsxs"
+		used := false
+		for _, a := range signatures {
+			if strings.EqualFold(a.Path, name) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return name
+		}
+	}
+}
+
+func (w *signedWriter) writeSignatures(signatures []Attachment) error {
+	originName := DefaultSignatureOriginName
+	var originRels []Relationship
+	if w.origin != nil {
+		originName = w.origin.Path
+		originRels = w.origin.Relationships
+	}
+	origin, err := w.packageWriter.Create(originName, ContentTypeSignatureOrigin)
+	if err != nil {
+		return err
+	}
+	var rels signedPart
+	for _, a := range signatures {
+		rel := Relationship{Type: RelTypeSignature, Path: a.Path}
+		for _, r := range originRels {
+			if r.Type == RelTypeSignature && strings.EqualFold(r.Path, a.Path) {
+				rel.ID = r.ID
+			}
+		}
+		rels.addRelationship(rel)
+	}
+	rels.assignIDs()
+	for _, r := range rels.rels {
+		origin.AddRelationship(r)
+	}
+	for _, a := range signatures {
+		part, err := w.packageWriter.Create(a.Path, a.ContentType)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, a.Stream); err != nil {
+			return err
+		}
+		for _, r := range a.Relationships {
+			part.AddRelationship(r)
+		}
+	}
+	w.packageWriter.AddRelationship(Relationship{Type: RelTypeSignatureOrigin, Path: originName})
+	return nil
+}
+
+func (w *signedWriter) findPart(name string) (*signedPart, bool) {
+	if name == "/" {
+		return &w.root, true
+	}
+	for _, p := range w.parts {
+		if strings.EqualFold(p.name, name) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// matches reports whether all the references of a preserved signature
+// match the written content.
+func (w *signedWriter) matches(xsig *xmlSignature) bool {
+	obj, _ := xsig.packageObject()
+	for i := range obj.References {
+		if !w.matchReference(&obj.References[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *signedWriter) matchReference(ref *xmlReference) bool {
+	name, contentType := ref.partURI()
+	if t, ok := ref.relationshipTransform(); ok {
+		source, isRels := relationshipsSource(name)
+		p, found := w.findPart(source)
+		if !isRels || !found || !strings.EqualFold(contentType, contentTypeRelationship) {
+			return false
+		}
+		ids, types := t.selection()
+		return matchDigest(ref, transformRelationships(newXMLRelationships(p.rels), ids, types))
+	}
+	h, ok := newDigestHash(ref.DigestMethod.Algorithm)
+	p, found := w.findPart(name)
+	if !ok || !found || p.hashes[h] == nil || !strings.EqualFold(contentType, p.contentType) {
+		return false
+	}
+	value, err := decodeBase64(ref.DigestValue)
+	return err == nil && bytes.Equal(value, p.hashes[h].Sum(nil))
+}
+
+// sign returns a signature of all the written parts and relationships.
+func (w *signedWriter) sign(t time.Time) ([]byte, error) {
+	alg, h, err := signatureMethod(w.signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	var obj bytes.Buffer
+	obj.WriteString(`<Object Id="` + packageObjectID + `"><Manifest>`)
+	for _, p := range append(w.parts, &w.root) {
+		if p.hashes != nil {
+			writeReference(&obj, p.name+"?ContentType="+p.contentType, nil, p.hashes[crypto.SHA256].Sum(nil))
+		}
+		if len(p.rels) == 0 {
+			continue
+		}
+		ids := make([]string, len(p.rels))
+		for i, r := range p.rels {
+			ids[i] = r.ID
+		}
+		d := digest(crypto.SHA256, transformRelationships(newXMLRelationships(p.rels), ids, nil))
+		writeReference(&obj, relationshipsName(p.name)+"?ContentType="+contentTypeRelationship, ids, d)
+	}
+	obj.WriteString(`</Manifest><SignatureProperties><SignatureProperty Id="idSignatureTime" Target="#idPackageSignature">`)
+	obj.WriteString(`<mdssi:SignatureTime xmlns:mdssi="` + nsDigitalSignature + `">`)
+	obj.WriteString(`<mdssi:Format>` + signatureTimeFormat + `</mdssi:Format>`)
+	obj.WriteString(`<mdssi:Value>` + t.UTC().Format(time.RFC3339) + `</mdssi:Value>`)
+	obj.WriteString(`</mdssi:SignatureTime></SignatureProperty></SignatureProperties></Object>`)
+
+	cobj, err := canonicalize(wrapSignature("", obj.String()), "Object", packageObjectID)
+	if err != nil {
+		return nil, err
+	}
+	var signedInfo bytes.Buffer
+	signedInfo.WriteString(`<SignedInfo><CanonicalizationMethod Algorithm="` + algC14N + `"></CanonicalizationMethod>`)
+	signedInfo.WriteString(`<SignatureMethod Algorithm="` + alg + `"></SignatureMethod>`)
+	signedInfo.WriteString(`<Reference Type="http://www.w3.org/2000/09/xmldsig#Object" URI="#` + packageObjectID + `">`)
+	signedInfo.WriteString(`<Transforms><Transform Algorithm="` + algC14N + `"></Transform></Transforms>`)
+	writeDigest(&signedInfo, digest(crypto.SHA256, cobj))
+	signedInfo.WriteString(`</Reference></SignedInfo>`)
+
+	csi, err := canonicalize(wrapSignature(signedInfo.String(), ""), "SignedInfo", "")
+	if err != nil {
+		return nil, err
+	}
+	value, err := w.signer.Sign(rand.Reader, digest(h, csi), h)
+	if err != nil {
+		return nil, err
+	}
+	if pub, ok := w.signer.Public().(*ecdsa.PublicKey); ok {
+		if value, err = rawECDSASignature(pub, value); err != nil {
+			return nil, err
+		}
+	}
+	var sig bytes.Buffer
+	sig.WriteString(signedInfo.String())
+	sig.WriteString(`<SignatureValue>` + base64.StdEncoding.EncodeToString(value) + `</SignatureValue>`)
+	sig.WriteString(`<KeyInfo><X509Data><X509Certificate>` + base64.StdEncoding.EncodeToString(w.cert.Raw))
+	sig.WriteString(`</X509Certificate></X509Data></KeyInfo>`)
+	sig.WriteString(obj.String())
+	return wrapSignature(sig.String(), ""), nil
+}
+
+func wrapSignature(content, object string) []byte {
+	return []byte(xml.Header + `<Signature xmlns="` + nsXMLDSig + `" Id="idPackageSignature">` + content + object + `</Signature>`)
+}
+
+func writeReference(buf *bytes.Buffer, uri string, ids []string, d []byte) {
+	buf.WriteString(`<Reference URI="`)
+	xml.EscapeText(buf, []byte(uri))
+	buf.WriteString(`">`)
+	if len(ids) > 0 {
+		buf.WriteString(`<Transforms><Transform Algorithm="` + algRelationshipTransform + `">`)
+		for _, id := range ids {
+			buf.WriteString(`<mdssi:RelationshipReference xmlns:mdssi="` + nsDigitalSignature + `" SourceId="`)
+			xml.EscapeText(buf, []byte(id))
+			buf.WriteString(`"></mdssi:RelationshipReference>`)
+		}
+		buf.WriteString(`</Transform><Transform Algorithm="` + algC14N + `"></Transform></Transforms>`)
+	}
+	writeDigest(buf, d)
+	buf.WriteString(`</Reference>`)
+}
+
+func writeDigest(buf *bytes.Buffer, d []byte) {
+	buf.WriteString(`<DigestMethod Algorithm="` + algSHA256 + `"></DigestMethod>`)
+	buf.WriteString(`<DigestValue>` + base64.StdEncoding.EncodeToString(d) + `</DigestValue>`)
+}
+
+func signatureMethod(pub crypto.PublicKey) (string, crypto.Hash, error) {
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		return algRSASHA256, crypto.SHA256, nil
+	case *ecdsa.PublicKey:
+		return algECDSASHA256, crypto.SHA256, nil
+	}
+	return "", 0, errors.New("unsupported package signer key type")
+}
+
+// rawECDSASignature converts an ASN.1 ECDSA signature
+// into the concatenation of r and s used by XML signatures.
+func rawECDSASignature(pub *ecdsa.PublicKey, der []byte) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, err
+	}
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	raw := make([]byte, 2*size)
+	r, s := sig.R.Bytes(), sig.S.Bytes()
+	copy(raw[size-len(r):size], r)
+	copy(raw[2*size-len(s):], s)
+	return raw, nil
+}
+
+// splitSignatures separates the signature origin and signature parts from the other attachments.
+func splitSignatures(attachments []Attachment) ([]Attachment, []Attachment) {
+	var signatures, others []Attachment
+	for _, a := range attachments {
+		if a.ContentType == ContentTypeSignatureOrigin || a.ContentType == ContentTypeSignature {
+			signatures = append(signatures, a)
+		} else {
+			others = append(others, a)
+		}
+	}
+	return signatures, others
+}
@@ -0,0 +1,301 @@
+package slices
+
+import (
+	"math"
+	"sort"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// Union returns a slice with the region covered by a or b.
+//
+// The boolean operations interpret the slices using the even-odd rule,
+// and open polygons are implicitly closed.
+// The result has the TopZ of a, compact vertices and closed polygons
+// that do not cross each other, with counter-clockwise outer contours
+// and clockwise holes. Segment properties are not preserved.
+func Union(a, b *Slice) *Slice {
+	return boolean(a, b, func(inA, inB bool) bool { return inA || inB })
+}
+
+// Difference returns a slice with the region covered by a and not by b.
+// See Union for more details.
+func Difference(a, b *Slice) *Slice {
+	return boolean(a, b, func(inA, inB bool) bool { return inA && !inB })
+}
+
+// Intersection returns a slice with the region covered by a and b.
+// See Union for more details.
+func Intersection(a, b *Slice) *Slice {
+	return boolean(a, b, func(inA, inB bool) bool { return inA && inB })
+}
+
+func boolean(a, b *Slice, op func(inA, inB bool) bool) *Slice {
+	la, lb := contours(a), contours(b)
+	loops := resolve(append(append([][]go3mf.Point2D(nil), la...), lb...), func(p [2]float64) bool {
+		return op(evenOdd(la, p), evenOdd(lb, p))
+	})
+	return newSlice(a.TopZ, loops)
+}
+
+// contours returns the polygons of s as closed loops without the repeated last point.
+func contours(s *Slice) [][]go3mf.Point2D {
+	var loops [][]go3mf.Point2D
+	for _, p := range s.Polygons {
+		loop, ok := s.PolygonPoints(p)
+		if !ok {
+			continue
+		}
+		if len(loop) > 1 && loop[0] == loop[len(loop)-1] {
+			loop = loop[:len(loop)-1]
+		}
+		if len(loop) > 1 {
+			loops = append(loops, loop)
+		}
+	}
+	return loops
+}
+
+// newSlice returns a slice with compact vertices and a closed polygon per loop.
+func newSlice(topZ float32, loops [][]go3mf.Point2D) *Slice {
+	b := newSliceBuilder(topZ)
+	for _, loop := range loops {
+		b.addPolygon(append(loop, loop[0]))
+	}
+	return b.s
+}
+
+// sliceBuilder builds slices with compact vertices.
+type sliceBuilder struct {
+	s       *Slice
+	indices map[go3mf.Point2D]uint32
+}
+
+func newSliceBuilder(topZ float32) *sliceBuilder {
+	return &sliceBuilder{s: &Slice{TopZ: topZ}, indices: make(map[go3mf.Point2D]uint32)}
+}
+
+func (b *sliceBuilder) vertex(v go3mf.Point2D) uint32 {
+	if i, ok := b.indices[v]; ok {
+		return i
+	}
+	i := uint32(len(b.s.Vertices))
+	b.indices[v] = i
+	b.s.Vertices = append(b.s.Vertices, v)
+	return i
+}
+
+// addPolygon adds a polygon that joins the points,
+// which is closed if the first and last points are equal.
+func (b *sliceBuilder) addPolygon(points []go3mf.Point2D) {
+	p := Polygon{StartV: b.vertex(points[0])}
+	for _, v := range points[1:] {
+		p.Segments = append(p.Segments, Segment{V2: b.vertex(v)})
+	}
+	b.s.Polygons = append(b.s.Polygons, p)
+}
+
+// resolve returns the boundary of the region where inside is true,
+// as loops with the region on their left.
+//
+// The loop edges are split at their intersections, and each piece is kept if the region
+// is only on one of its sides, which is sampled at a small distance from the piece midpoint.
+func resolve(loops [][]go3mf.Point2D, inside func(p [2]float64) bool) [][]go3mf.Point2D {
+	var edges []edge
+	for k, loop := range loops {
+		for i, p1 := range loop {
+			if p2 := loop[(i+1)%len(loop)]; p1 != p2 {
+				edges = append(edges, newEdge(p1, p2, k))
+			}
+		}
+	}
+	splits := make(map[*edge][]go3mf.Point2D)
+	overlappingPairs(edges, func(e1, e2 *edge) {
+		// The crossing point is computed once so both edges are split at the same point.
+		if p, ok := crossingPoint(e1, e2); ok {
+			splits[e1] = append(splits[e1], p)
+			splits[e2] = append(splits[e2], p)
+			return
+		}
+		splits[e1] = append(splits[e1], touchPoints(e1, e2)...)
+		splits[e2] = append(splits[e2], touchPoints(e2, e1)...)
+	})
+	var kept [][2]go3mf.Point2D
+	seen := make(map[[2]go3mf.Point2D]struct{})
+	for i := range edges {
+		e := &edges[i]
+		points := append([]go3mf.Point2D{e.p1, e.p2}, splits[e]...)
+		sort.Slice(points, func(i, j int) bool {
+			return distance2(e.p1, points[i]) < distance2(e.p1, points[j])
+		})
+		for j := 1; j < len(points); j++ {
+			p1, p2 := points[j-1], points[j]
+			if p1 == p2 {
+				continue
+			}
+			left, right := sides(p1, p2)
+			inLeft, inRight := inside(left), inside(right)
+			if inLeft == inRight {
+				continue
+			}
+			if inRight {
+				p1, p2 = p2, p1
+			}
+			key := [2]go3mf.Point2D{p1, p2}
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				kept = append(kept, key)
+			}
+		}
+	}
+	return link(kept)
+}
+
+// crossingPoint returns the point where the interiors of e1 and e2 cross.
+func crossingPoint(e1, e2 *edge) (go3mf.Point2D, bool) {
+	o1 := orientation(e1.p1, e1.p2, e2.p1)
+	o2 := orientation(e1.p1, e1.p2, e2.p2)
+	o3 := orientation(e2.p1, e2.p2, e1.p1)
+	o4 := orientation(e2.p1, e2.p2, e1.p2)
+	if o1*o2 >= 0 || o3*o4 >= 0 {
+		return go3mf.Point2D{}, false
+	}
+	t := o3 / (o3 - o4)
+	return go3mf.Point2D{
+		float32(float64(e1.p1.X()) + t*(float64(e1.p2.X())-float64(e1.p1.X()))),
+		float32(float64(e1.p1.Y()) + t*(float64(e1.p2.Y())-float64(e1.p1.Y()))),
+	}, true
+}
+
+// touchPoints returns the ends of e2 that lie in the interior of e1.
+func touchPoints(e1, e2 *edge) []go3mf.Point2D {
+	var points []go3mf.Point2D
+	for _, p := range [2]go3mf.Point2D{e2.p1, e2.p2} {
+		if orientation(e1.p1, e1.p2, p) == 0 && p != e1.p1 && p != e1.p2 &&
+			p.X() >= e1.min.X() && p.X() <= e1.max.X() && p.Y() >= e1.min.Y() && p.Y() <= e1.max.Y() {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+// sides returns two points at a small distance from the midpoint of p1-p2,
+// at its left and at its right.
+func sides(p1, p2 go3mf.Point2D) ([2]float64, [2]float64) {
+	x1, y1, x2, y2 := float64(p1.X()), float64(p1.Y()), float64(p2.X()), float64(p2.Y())
+	dx, dy := x2-x1, y2-y1
+	length := math.Hypot(dx, dy)
+	scale := math.Max(math.Max(math.Abs(x1), math.Abs(y1)), math.Max(math.Abs(x2), math.Abs(y2)))
+	eps := math.Max(length*1e-3, scale*1e-6) / length
+	mx, my := (x1+x2)/2, (y1+y2)/2
+	return [2]float64{mx - dy*eps, my + dx*eps}, [2]float64{mx + dy*eps, my - dx*eps}
+}
+
+// evenOdd reports whether p is inside the loops using the even-odd rule.
+func evenOdd(loops [][]go3mf.Point2D, p [2]float64) bool {
+	var in bool
+	for _, loop := range loops {
+		for i, v1 := range loop {
+			v2 := loop[(i+1)%len(loop)]
+			x1, y1, x2, y2 := float64(v1.X()), float64(v1.Y()), float64(v2.X()), float64(v2.Y())
+			if (y1 > p[1]) != (y2 > p[1]) && p[0] < x1+(p[1]-y1)*(x2-x1)/(y2-y1) {
+				in = !in
+			}
+		}
+	}
+	return in
+}
+
+// winding returns the winding number of the loops around p.
+func winding(loops [][]go3mf.Point2D, p [2]float64) int {
+	var w int
+	for _, loop := range loops {
+		for i, v1 := range loop {
+			v2 := loop[(i+1)%len(loop)]
+			x1, y1, x2, y2 := float64(v1.X()), float64(v1.Y()), float64(v2.X()), float64(v2.Y())
+			isLeft := (x2-x1)*(p[1]-y1) - (p[0]-x1)*(y2-y1)
+			if y1 <= p[1] {
+				if y2 > p[1] && isLeft > 0 {
+					w++
+				}
+			} else if y2 <= p[1] && isLeft < 0 {
+				w--
+			}
+		}
+	}
+	return w
+}
+
+// link joins the directed edges into closed loops.
+// When a vertex has several outgoing edges the leftmost turn is taken,
+// so loops that touch at a vertex are kept separated.
+func link(edges [][2]go3mf.Point2D) [][]go3mf.Point2D {
+	outgoing := make(map[go3mf.Point2D][]int)
+	for i, e := range edges {
+		outgoing[e[0]] = append(outgoing[e[0]], i)
+	}
+	used := make([]bool, len(edges))
+	var loops [][]go3mf.Point2D
+	for i := range edges {
+		if used[i] {
+			continue
+		}
+		start := edges[i][0]
+		loop := []go3mf.Point2D{start}
+		cur := i
+		for {
+			used[cur] = true
+			from, to := edges[cur][0], edges[cur][1]
+			if to == start {
+				break
+			}
+			loop = append(loop, to)
+			next, best := -1, math.Inf(-1)
+			for _, j := range outgoing[to] {
+				if used[j] {
+					continue
+				}
+				if a := turn(from, to, edges[j][1]); a > best {
+					next, best = j, a
+				}
+			}
+			if next < 0 {
+				loop = nil
+				break
+			}
+			cur = next
+		}
+		if loop = removeCollinear(loop); len(loop) > 2 {
+			loops = append(loops, loop)
+		}
+	}
+	return loops
+}
+
+// turn returns the signed angle between p1-p2 and p2-p3.
+func turn(p1, p2, p3 go3mf.Point2D) float64 {
+	ux, uy := float64(p2.X())-float64(p1.X()), float64(p2.Y())-float64(p1.Y())
+	vx, vy := float64(p3.X())-float64(p2.X()), float64(p3.Y())-float64(p2.Y())
+	return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+}
+
+// removeCollinear removes the loop vertices that are in the middle of a straight line.
+func removeCollinear(loop []go3mf.Point2D) []go3mf.Point2D {
+	for changed := true; changed && len(loop) > 2; {
+		changed = false
+		for i := 0; i < len(loop) && len(loop) > 2; i++ {
+			prev, next := loop[(i+len(loop)-1)%len(loop)], loop[(i+1)%len(loop)]
+			if orientation(prev, loop[i], next) == 0 && turn(prev, loop[i], next) == 0 {
+				loop = append(loop[:i], loop[i+1:]...)
+				changed = true
+				i--
+			}
+		}
+	}
+	return loop
+}
+
+func distance2(p1, p2 go3mf.Point2D) float64 {
+	dx, dy := float64(p2.X())-float64(p1.X()), float64(p2.Y())-float64(p1.Y())
+	return dx*dx + dy*dy
+}
@@ -0,0 +1,91 @@
+package slices
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+func rect(minX, minY, maxX, maxY float32) []go3mf.Point2D {
+	return []go3mf.Point2D{{minX, minY}, {maxX, minY}, {maxX, maxY}, {minX, maxY}}
+}
+
+func sliceOf(loops ...[]go3mf.Point2D) *Slice {
+	b := newSliceBuilder(1)
+	for _, loop := range loops {
+		b.addPolygon(append(append([]go3mf.Point2D(nil), loop...), loop[0]))
+	}
+	return b.s
+}
+
+// areas returns the signed area of each polygon of s.
+func areas(s *Slice) []float64 {
+	var result []float64
+	for _, loop := range contours(s) {
+		var a float64
+		for i, v1 := range loop {
+			v2 := loop[(i+1)%len(loop)]
+			a += float64(v1.X())*float64(v2.Y()) - float64(v2.X())*float64(v1.Y())
+		}
+		result = append(result, a/2)
+	}
+	return result
+}
+
+func TestBoolean(t *testing.T) {
+	reversed := []go3mf.Point2D{{1, 1}, {1, 3}, {3, 3}, {3, 1}}
+	tests := []struct {
+		name     string
+		fn       func(a, b *Slice) *Slice
+		a, b     *Slice
+		areas    []float64
+		vertices int
+	}{
+		{"union", Union, sliceOf(rect(0, 0, 2, 2)), sliceOf(rect(1, 1, 3, 3)), []float64{7}, 8},
+		{"unionReversed", Union, sliceOf(rect(0, 0, 2, 2)), sliceOf(reversed), []float64{7}, 8},
+		{"unionTouching", Union, sliceOf(rect(0, 0, 1, 1)), sliceOf(rect(1, 0, 2, 1)), []float64{2}, 4},
+		{"unionDisjoint", Union, sliceOf(rect(0, 0, 1, 1)), sliceOf(rect(2, 0, 3, 1)), []float64{1, 1}, 8},
+		{"unionSame", Union, sliceOf(rect(0, 0, 1, 1)), sliceOf(rect(0, 0, 1, 1)), []float64{1}, 4},
+		{"intersection", Intersection, sliceOf(rect(0, 0, 2, 2)), sliceOf(rect(1, 1, 3, 3)), []float64{1}, 4},
+		{"intersectionDisjoint", Intersection, sliceOf(rect(0, 0, 1, 1)), sliceOf(rect(2, 0, 3, 1)), nil, 0},
+		{"difference", Difference, sliceOf(rect(0, 0, 2, 2)), sliceOf(rect(1, 1, 3, 3)), []float64{3}, 6},
+		{"differenceHole", Difference, sliceOf(rect(0, 0, 4, 4)), sliceOf(rect(1, 1, 3, 3)), []float64{16, -4}, 8},
+		{"differenceEvenOdd", Difference, sliceOf(rect(0, 0, 4, 4), rect(1, 1, 3, 3)), sliceOf(rect(0, 0, 2, 4)), []float64{6}, 8},
+		{"crossing", Union, sliceOf(rect(0, 1, 3, 2)), sliceOf(rect(1, 0, 2, 3)), []float64{5}, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.fn(tt.a, tt.b)
+			deep.FloatPrecision = 6
+			if diff := deep.Equal(areas(got), tt.areas); diff != nil {
+				t.Errorf("boolean() areas = %v", diff)
+			}
+			if len(got.Vertices) != tt.vertices {
+				t.Errorf("boolean() vertices = %d, want %d", len(got.Vertices), tt.vertices)
+			}
+			if got.TopZ != tt.a.TopZ {
+				t.Errorf("boolean() TopZ = %v, want %v", got.TopZ, tt.a.TopZ)
+			}
+			if err := got.ValidateGeometry(); err != nil {
+				t.Errorf("boolean() ValidateGeometry() = %v", err)
+			}
+		})
+	}
+}
+
+func TestBoolean_roundedCrossing(t *testing.T) {
+	// The crossing point of the first edges of a and b rounds to different float32 values
+	// depending on the edge used to compute it, so both edges must be split at the same point.
+	a := sliceOf([]go3mf.Point2D{{1796, 2803}, {288, 947}, {288, 2803}})
+	b := sliceOf([]go3mf.Point2D{{765, 2386}, {1162, 354}, {1500, 2386}})
+	for _, fn := range []func(a, b *Slice) *Slice{Union, Intersection, Difference} {
+		got := fn(a, b)
+		if len(got.Polygons) != 1 {
+			t.Errorf("boolean() polygons = %d, want 1", len(got.Polygons))
+		}
+		if err := got.ValidateGeometry(); err != nil {
+			t.Errorf("boolean() ValidateGeometry() = %v", err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Package cli reads and writes slice stacks in the Common Layer Interface (CLI) format,
+// both in its ASCII and binary flavours.
+//
+// CLI coordinates are multiplied by the header units to obtain millimeters,
+// which are the units of the decoded slices and the expected units of the encoded ones.
+// Polylines are mapped to slice polygons and hatches are ignored.
+package cli
+
+import (
+	"errors"
+)
+
+// Errors returned by the Decoder.
+var (
+	ErrHeader  = errors.New("cli: missing or invalid header")
+	ErrCommand = errors.New("cli: invalid geometry command")
+)
+
+// Polyline directions.
+const (
+	dirClockwise        = 0 // Internal contour.
+	dirCounterClockwise = 1 // External contour.
+	dirOpen             = 2
+)
+
+// Binary command identifiers.
+const (
+	cmdLayerLong     = 127
+	cmdLayerShort    = 128
+	cmdPolylineShort = 129
+	cmdPolylineLong  = 130
+	cmdHatchesShort  = 131
+	cmdHatchesLong   = 132
+)
+
+// Header commands.
+const (
+	headerStart   = "HEADERSTART"
+	headerEnd     = "HEADEREND"
+	headerBinary  = "BINARY"
+	headerASCII   = "ASCII"
+	headerUnits   = "UNITS"
+	headerVersion = "VERSION"
+	headerLabel   = "LABEL"
+	headerDate    = "DATE"
+	headerDim     = "DIMENSION"
+	headerLayers  = "LAYERS"
+	headerAlign   = "ALIGN"
+	geometryStart = "GEOMETRYSTART"
+	geometryEnd   = "GEOMETRYEND"
+	geometryLayer = "LAYER"
+	geometryPoly  = "POLYLINE"
+)
+
+// Header contains the CLI header information.
+// Units is the length in millimeters of a CLI coordinate unit
+// and Dimension is the bounding box of the part, as x1,y1,z1,x2,y2,z2, in CLI units.
+type Header struct {
+	Binary    bool
+	Align     bool
+	Units     float64
+	Version   int
+	Label     string
+	Date      string
+	Dimension [6]float32
+	Layers    int
+}
@@ -0,0 +1,376 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/slices"
+)
+
+// A Decoder reads a CLI file into a slice stack.
+//
+// If Path is not empty the slices are stored in a slice stack of the child model
+// with that path, which is referenced from a slice stack of the root model,
+// else they are stored directly in the root model.
+type Decoder struct {
+	Path string
+	r    io.Reader
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		r: r,
+	}
+}
+
+// Decode reads the CLI file into a slice stack and adds it to the m resources.
+func (d *Decoder) Decode(m *go3mf.Model) error {
+	st, _, err := d.DecodeStack()
+	if err != nil {
+		return err
+	}
+	if d.Path == "" {
+		st.ID = m.Resources.UnusedID()
+		m.Resources.Assets = append(m.Resources.Assets, st)
+		return nil
+	}
+	if m.Childs == nil {
+		m.Childs = make(map[string]*go3mf.ChildModel)
+	}
+	child, ok := m.Childs[d.Path]
+	if !ok {
+		child = new(go3mf.ChildModel)
+		m.Childs[d.Path] = child
+	}
+	st.ID = child.Resources.UnusedID()
+	child.Resources.Assets = append(child.Resources.Assets, st)
+	m.Resources.Assets = append(m.Resources.Assets, &slices.SliceStack{
+		ID:      m.Resources.UnusedID(),
+		BottomZ: st.BottomZ,
+		Refs:    []slices.SliceRef{{SliceStackID: st.ID, Path: d.Path}},
+	})
+	return nil
+}
+
+// DecodeStack reads the CLI file into a slice stack without ID.
+// The stack BottomZ is the lower Z of the header dimension.
+func (d *Decoder) DecodeStack() (*slices.SliceStack, *Header, error) {
+	r := bufio.NewReader(d.r)
+	header, err := decodeHeader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	b := &stackBuilder{units: header.Units, st: &slices.SliceStack{BottomZ: float32(float64(header.Dimension[2]) * header.Units)}}
+	if header.Binary {
+		err = decodeBinary(r, header, b)
+	} else {
+		err = decodeASCII(r, b)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.st, header, nil
+}
+
+func decodeHeader(r *bufio.Reader) (*Header, error) {
+	var buf bytes.Buffer
+	for !bytes.HasSuffix(buf.Bytes(), []byte("$$"+headerEnd)) {
+		c, err := r.ReadByte()
+		if err == io.EOF {
+			return nil, ErrHeader
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteByte(c)
+	}
+	header := &Header{Units: 1}
+	var started bool
+	for _, cmd := range strings.Split(buf.String(), "$$") {
+		name, params := splitCommand(cmd)
+		switch name {
+		case headerStart:
+			started = true
+		case headerBinary:
+			header.Binary = true
+		case headerASCII:
+			header.Binary = false
+		case headerAlign:
+			header.Align = true
+		case headerUnits:
+			units, err := strconv.ParseFloat(params, 64)
+			if err != nil || units <= 0 {
+				return nil, ErrHeader
+			}
+			header.Units = units
+		case headerVersion:
+			header.Version, _ = strconv.Atoi(params)
+		case headerLabel:
+			if header.Label == "" {
+				if i := strings.IndexByte(params, ','); i >= 0 {
+					header.Label = strings.TrimSpace(params[i+1:])
+				}
+			}
+		case headerDate:
+			header.Date = params
+		case headerDim:
+			values, err := parseFloats(params)
+			if err != nil || len(values) != len(header.Dimension) {
+				return nil, ErrHeader
+			}
+			for i, v := range values {
+				header.Dimension[i] = float32(v)
+			}
+		case headerLayers:
+			header.Layers, _ = strconv.Atoi(params)
+		}
+	}
+	if !started {
+		return nil, ErrHeader
+	}
+	return header, nil
+}
+
+func splitCommand(cmd string) (string, string) {
+	cmd = strings.TrimSpace(cmd)
+	if i := strings.IndexByte(cmd, '/'); i >= 0 {
+		return strings.TrimSpace(cmd[:i]), strings.TrimSpace(cmd[i+1:])
+	}
+	return cmd, ""
+}
+
+func decodeASCII(r io.Reader, b *stackBuilder) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	for _, cmd := range strings.Split(stripComments(string(data)), "$$") {
+		name, params := splitCommand(cmd)
+		switch name {
+		case geometryEnd:
+			return nil
+		case geometryLayer:
+			z, err := strconv.ParseFloat(params, 64)
+			if err != nil {
+				return ErrCommand
+			}
+			b.layer(z)
+		case geometryPoly:
+			values, err := parseFloats(params)
+			if err != nil || len(values) < 3 || values[2] < 0 || len(values) != 3+2*int(values[2]) {
+				return ErrCommand
+			}
+			if err := b.polyline(values[3:]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// stripComments removes the text enclosed by "//" pairs.
+func stripComments(s string) string {
+	var sb strings.Builder
+	for {
+		i := strings.Index(s, "//")
+		if i < 0 {
+			sb.WriteString(s)
+			return sb.String()
+		}
+		sb.WriteString(s[:i])
+		j := strings.Index(s[i+2:], "//")
+		if j < 0 {
+			return sb.String()
+		}
+		s = s[i+2+j+2:]
+	}
+}
+
+func parseFloats(s string) ([]float64, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\r' || r == '\n'
+	})
+	values := make([]float64, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func decodeBinary(r io.Reader, header *Header, b *stackBuilder) error {
+	read := func(data interface{}) error {
+		err := binary.Read(r, binary.LittleEndian, data)
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	for {
+		var cmd uint16
+		if err := binary.Read(r, binary.LittleEndian, &cmd); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if header.Align {
+			var pad uint16
+			if err := read(&pad); err != nil {
+				return err
+			}
+		}
+		var err error
+		switch cmd {
+		case cmdLayerLong:
+			var z float32
+			if err = read(&z); err == nil {
+				b.layer(float64(z))
+			}
+		case cmdLayerShort:
+			var z uint16
+			if err = read(&z); err == nil {
+				b.layer(float64(z))
+			}
+		case cmdPolylineLong:
+			var params [3]int32
+			if err = read(&params); err == nil {
+				if params[2] < 0 {
+					return ErrCommand
+				}
+				var values []float64
+				if values, err = readLong(read, 2*int64(params[2]), true); err == nil {
+					err = b.polyline(values)
+				}
+			}
+		case cmdPolylineShort:
+			var params [3]uint16
+			if err = read(&params); err == nil {
+				var values []float64
+				if values, err = readShort(read, 2*int64(params[2]), true); err == nil {
+					err = b.polyline(values)
+				}
+			}
+		case cmdHatchesLong:
+			var params [2]int32
+			if err = read(&params); err == nil {
+				if params[1] < 0 {
+					return ErrCommand
+				}
+				_, err = readLong(read, 4*int64(params[1]), false)
+			}
+		case cmdHatchesShort:
+			var params [2]uint16
+			if err = read(&params); err == nil {
+				_, err = readShort(read, 4*int64(params[1]), false)
+			}
+		default:
+			return ErrCommand
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// chunkSize is the number of values read at once, so the memory
+// used by malformed counts is bounded by the actual input size.
+const chunkSize = 1024
+
+// readLong reads n long values, which are returned if keep is true.
+func readLong(read func(interface{}) error, n int64, keep bool) ([]float64, error) {
+	var values []float64
+	chunk := make([]float32, chunkSize)
+	for n > 0 {
+		c := chunk
+		if n < chunkSize {
+			c = chunk[:n]
+		}
+		if err := read(c); err != nil {
+			return nil, err
+		}
+		if keep {
+			for _, v := range c {
+				values = append(values, float64(v))
+			}
+		}
+		n -= int64(len(c))
+	}
+	return values, nil
+}
+
+// readShort reads n short values, which are returned if keep is true.
+func readShort(read func(interface{}) error, n int64, keep bool) ([]float64, error) {
+	var values []float64
+	chunk := make([]uint16, chunkSize)
+	for n > 0 {
+		c := chunk
+		if n < chunkSize {
+			c = chunk[:n]
+		}
+		if err := read(c); err != nil {
+			return nil, err
+		}
+		if keep {
+			for _, v := range c {
+				values = append(values, float64(v))
+			}
+		}
+		n -= int64(len(c))
+	}
+	return values, nil
+}
+
+type stackBuilder struct {
+	units   float64
+	st      *slices.SliceStack
+	indices map[go3mf.Point2D]uint32
+}
+
+func (b *stackBuilder) layer(z float64) {
+	b.st.Slices = append(b.st.Slices, &slices.Slice{TopZ: float32(z * b.units)})
+	b.indices = make(map[go3mf.Point2D]uint32)
+}
+
+// polyline adds a polygon with the xy pairs of values to the current slice.
+// The polygon is closed when the first and the last points are equal.
+func (b *stackBuilder) polyline(values []float64) error {
+	if len(b.st.Slices) == 0 {
+		return ErrCommand
+	}
+	s := b.st.Slices[len(b.st.Slices)-1]
+	if len(values) < 4 {
+		return nil
+	}
+	vertex := func(i int) uint32 {
+		v := go3mf.Point2D{float32(values[2*i] * b.units), float32(values[2*i+1] * b.units)}
+		if idx, ok := b.indices[v]; ok {
+			return idx
+		}
+		idx := uint32(len(s.Vertices))
+		b.indices[v] = idx
+		s.Vertices = append(s.Vertices, v)
+		return idx
+	}
+	p := slices.Polygon{StartV: vertex(0)}
+	prev := p.StartV
+	for i := 1; i < len(values)/2; i++ {
+		if v := vertex(i); v != prev {
+			p.Segments = append(p.Segments, slices.Segment{V2: v})
+			prev = v
+		}
+	}
+	if len(p.Segments) > 0 {
+		s.Polygons = append(s.Polygons, p)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/slices"
+)
+
+const asciiCLI = `$$HEADERSTART
+// a comment //
+$$ASCII
+$$UNITS/00000000.010000
+$$VERSION/200
+$$LABEL/1,part1
+$$DATE/070920
+$$LAYERS/000002
+$$HEADEREND
+$$GEOMETRYSTART
+$$LAYER/10.0
+$$POLYLINE/1,1,5,0,0,100,0,
+100,100,0,100,0,0
+$$HATCHES/1,1,0,0,100,100
+$$LAYER/20.0
+$$POLYLINE/1,2,3,0,0,0,0,50,50
+$$GEOMETRYEND
+`
+
+func TestDecoder_Decode(t *testing.T) {
+	squareSlice := &slices.Slice{TopZ: 0.1, Vertices: []go3mf.Point2D{{0, 0}, {1, 0}, {1, 1}, {0, 1}}, Polygons: []slices.Polygon{
+		{StartV: 0, Segments: []slices.Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}},
+	}}
+	openSlice := &slices.Slice{TopZ: 0.2, Vertices: []go3mf.Point2D{{0, 0}, {0.5, 0.5}}, Polygons: []slices.Polygon{
+		{StartV: 0, Segments: []slices.Segment{{V2: 1}}},
+	}}
+	m := &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{{ID: 1}}}}
+	if err := NewDecoder(strings.NewReader(asciiCLI)).Decode(m); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	d := NewDecoder(strings.NewReader(asciiCLI))
+	d.Path = "/3D/slices.model"
+	if err := d.Decode(m); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	want := &go3mf.Model{
+		Resources: go3mf.Resources{
+			Objects: []*go3mf.Object{{ID: 1}},
+			Assets: []go3mf.Asset{
+				&slices.SliceStack{ID: 2, Slices: []*slices.Slice{squareSlice, openSlice}},
+				&slices.SliceStack{ID: 3, Refs: []slices.SliceRef{{SliceStackID: 1, Path: "/3D/slices.model"}}},
+			},
+		},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/slices.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&slices.SliceStack{ID: 1, Slices: []*slices.Slice{squareSlice, openSlice}},
+			}}},
+		},
+	}
+	if diff := deep.Equal(m, want); diff != nil {
+		t.Errorf("Decoder.Decode() = %v", diff)
+	}
+}
+
+func TestDecoder_DecodeStack_binary(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("$$HEADERSTART\n$$BINARY\n$$UNITS/0.5\n$$ALIGN\n$$HEADEREND")
+	for _, data := range []interface{}{
+		[2]uint16{cmdLayerShort, 0}, uint16(4),
+		[2]uint16{cmdHatchesShort, 0}, [2]uint16{1, 1}, [4]uint16{0, 0, 2, 2},
+		[2]uint16{cmdHatchesLong, 0}, [2]int32{1, 1}, [4]float32{0, 0, 2, 2},
+		[2]uint16{cmdPolylineShort, 0}, [3]uint16{1, 1, 4}, [8]uint16{0, 0, 2, 0, 2, 2, 0, 0},
+	} {
+		binary.Write(&buf, binary.LittleEndian, data)
+	}
+	got, header, err := NewDecoder(&buf).DecodeStack()
+	if err != nil {
+		t.Fatalf("Decoder.DecodeStack() error = %v", err)
+	}
+	if diff := deep.Equal(header, &Header{Binary: true, Align: true, Units: 0.5}); diff != nil {
+		t.Errorf("Decoder.DecodeStack() header = %v", diff)
+	}
+	want := &slices.SliceStack{Slices: []*slices.Slice{
+		{TopZ: 2, Vertices: []go3mf.Point2D{{0, 0}, {1, 0}, {1, 1}}, Polygons: []slices.Polygon{
+			{StartV: 0, Segments: []slices.Segment{{V2: 1}, {V2: 2}, {V2: 0}}},
+		}},
+	}}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Decoder.DecodeStack() = %v", diff)
+	}
+}
+
+func TestDecoder_DecodeStack_error(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{"empty", "", ErrHeader},
+		{"noHeaderStart", "$$ASCII$$HEADEREND", ErrHeader},
+		{"units", "$$HEADERSTART$$UNITS/a$$HEADEREND", ErrHeader},
+		{"dimension", "$$HEADERSTART$$DIMENSION/1,2$$HEADEREND", ErrHeader},
+		{"layer", "$$HEADERSTART$$HEADEREND$$LAYER/a", ErrCommand},
+		{"polylineWithoutLayer", "$$HEADERSTART$$HEADEREND$$POLYLINE/1,1,2,0,0,1,1", ErrCommand},
+		{"polylineLength", "$$HEADERSTART$$HEADEREND$$LAYER/1$$POLYLINE/1,1,3,0,0,1,1", ErrCommand},
+		{"binaryCommand", "$$HEADERSTART$$BINARY$$HEADEREND\x01\x00", ErrCommand},
+		{"binaryEOF", "$$HEADERSTART$$BINARY$$HEADEREND\x7f\x00", io.ErrUnexpectedEOF},
+		{"binaryPolylineCount", "$$HEADERSTART$$BINARY$$HEADEREND\x7f\x00\x00\x00\x80\x3f\x82\x00\x01\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x40" + strings.Repeat("\x00", 16), io.ErrUnexpectedEOF},
+		{"binaryHatchesCount", "$$HEADERSTART$$BINARY$$HEADEREND\x7f\x00\x00\x00\x80\x3f\x84\x00\x01\x00\x00\x00\x00\x00\x00\x20" + strings.Repeat("\x00", 20), io.ErrUnexpectedEOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := NewDecoder(strings.NewReader(tt.data)).DecodeStack(); err != tt.want {
+				t.Errorf("Decoder.DecodeStack() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/slices"
+)
+
+// An Encoder writes slice stacks into CLI files.
+//
+// Binary files are written using the long commands.
+// The coordinates are divided by Units, which defaults to 1.
+type Encoder struct {
+	Binary bool
+	Units  float64
+	Label  string
+	w      io.Writer
+}
+
+// NewEncoder returns a new ASCII encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		Units: 1,
+		Label: "part",
+		w:     w,
+	}
+}
+
+// Encode writes the slices of st, which is defined in the model part path.
+// The slice references are followed into the child model parts of m,
+// which can be nil if st does not contain references.
+func (e *Encoder) Encode(m *go3mf.Model, path string, st *slices.SliceStack) error {
+	layers, err := st.ResolveSlices(m, path)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(e.w)
+	e.writeHeader(w, st.BottomZ, layers)
+	if e.Binary {
+		if err := e.writeBinary(w, layers); err != nil {
+			return err
+		}
+	} else {
+		e.writeASCII(w, layers)
+	}
+	return w.Flush()
+}
+
+func (e *Encoder) units() float64 {
+	if e.Units <= 0 {
+		return 1
+	}
+	return e.Units
+}
+
+func (e *Encoder) writeHeader(w *bufio.Writer, bottomZ float32, layers []*slices.Slice) {
+	w.WriteString("$$" + headerStart + "\n")
+	if e.Binary {
+		w.WriteString("$$" + headerBinary + "\n")
+	} else {
+		w.WriteString("$$" + headerASCII + "\n")
+	}
+	w.WriteString("$$" + headerUnits + "/" + strconv.FormatFloat(e.units(), 'f', -1, 64) + "\n")
+	w.WriteString("$$" + headerVersion + "/200\n")
+	w.WriteString("$$" + headerLabel + "/1," + strings.NewReplacer("$$", "", "\n", " ").Replace(e.Label) + "\n")
+	if dim, ok := dimension(bottomZ, layers); ok {
+		values := make([]string, len(dim))
+		for i, v := range dim {
+			values[i] = e.scale(v)
+		}
+		w.WriteString("$$" + headerDim + "/" + strings.Join(values, ",") + "\n")
+	}
+	w.WriteString("$$" + headerLayers + "/" + strconv.Itoa(len(layers)) + "\n")
+	w.WriteString("$$" + headerEnd)
+	if !e.Binary {
+		w.WriteString("\n")
+	}
+}
+
+func (e *Encoder) writeASCII(w *bufio.Writer, layers []*slices.Slice) {
+	w.WriteString("$$" + geometryStart + "\n")
+	for _, s := range layers {
+		w.WriteString("$$" + geometryLayer + "/" + e.scale(s.TopZ) + "\n")
+		for _, p := range s.Polygons {
+			points, dir, ok := polyline(s, p)
+			if !ok {
+				continue
+			}
+			w.WriteString("$$" + geometryPoly + "/1," + strconv.Itoa(dir) + "," + strconv.Itoa(len(points)))
+			for _, v := range points {
+				w.WriteString("," + e.scale(v.X()) + "," + e.scale(v.Y()))
+			}
+			w.WriteString("\n")
+		}
+	}
+	w.WriteString("$$" + geometryEnd + "\n")
+}
+
+func (e *Encoder) writeBinary(w *bufio.Writer, layers []*slices.Slice) error {
+	write := func(data interface{}) error {
+		return binary.Write(w, binary.LittleEndian, data)
+	}
+	for _, s := range layers {
+		if err := write(struct {
+			Cmd uint16
+			Z   float32
+		}{cmdLayerLong, e.toUnits(s.TopZ)}); err != nil {
+			return err
+		}
+		for _, p := range s.Polygons {
+			points, dir, ok := polyline(s, p)
+			if !ok {
+				continue
+			}
+			values := make([]float32, 0, 2*len(points))
+			for _, v := range points {
+				values = append(values, e.toUnits(v.X()), e.toUnits(v.Y()))
+			}
+			if err := write(struct {
+				Cmd          uint16
+				ID, Dir, Len int32
+			}{cmdPolylineLong, 1, int32(dir), int32(len(points))}); err != nil {
+				return err
+			}
+			if err := write(values); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// polyline returns the points of p and its CLI direction.
+// Closed polygons repeat the first point at the end.
+func polyline(s *slices.Slice, p slices.Polygon) ([]go3mf.Point2D, int, bool) {
+	points, ok := s.PolygonPoints(p)
+	if !ok || len(points) < 2 {
+		return nil, 0, false
+	}
+	if points[0] != points[len(points)-1] {
+		return points, dirOpen, true
+	}
+	var area float32
+	for i := 0; i < len(points)-1; i++ {
+		area += points[i].X()*points[i+1].Y() - points[i+1].X()*points[i].Y()
+	}
+	if area < 0 {
+		return points, dirClockwise, true
+	}
+	return points, dirCounterClockwise, true
+}
+
+// dimension returns the bounding box of the layers.
+func dimension(bottomZ float32, layers []*slices.Slice) ([6]float32, bool) {
+	min := go3mf.Point2D{math.MaxFloat32, math.MaxFloat32}
+	max := go3mf.Point2D{-math.MaxFloat32, -math.MaxFloat32}
+	var ok bool
+	var topZ float32
+	for _, s := range layers {
+		topZ = s.TopZ
+		for _, v := range s.Vertices {
+			for i := range min {
+				min[i] = float32(math.Min(float64(min[i]), float64(v[i])))
+				max[i] = float32(math.Max(float64(max[i]), float64(v[i])))
+			}
+			ok = true
+		}
+	}
+	return [6]float32{min[0], min[1], bottomZ, max[0], max[1], topZ}, ok
+}
+
+// toUnits converts f from millimeters to CLI units.
+func (e *Encoder) toUnits(f float32) float32 {
+	return float32(float64(f) / e.units())
+}
+
+// scale formats f in CLI units.
+func (e *Encoder) scale(f float32) string {
+	return strconv.FormatFloat(float64(e.toUnits(f)), 'f', -1, 32)
+}
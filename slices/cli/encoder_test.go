@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/slices"
+)
+
+func sliceModel() (*go3mf.Model, *slices.SliceStack) {
+	st := &slices.SliceStack{ID: 1, BottomZ: 0.5, Refs: []slices.SliceRef{{SliceStackID: 2, Path: "/3D/other.model"}}}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{Assets: []go3mf.Asset{st}},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/other.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&slices.SliceStack{ID: 2, BottomZ: 0.5, Slices: []*slices.Slice{
+					{TopZ: 1, Vertices: []go3mf.Point2D{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {1, 3}}, Polygons: []slices.Polygon{
+						{StartV: 0, Segments: []slices.Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}},
+						{StartV: 0, Segments: []slices.Segment{{V2: 3}, {V2: 2}, {V2: 1}, {V2: 0}}},
+						{StartV: 3, Segments: []slices.Segment{{V2: 4}}},
+						{StartV: 3, Segments: []slices.Segment{{V2: 10}}},
+					}},
+					{TopZ: 1.5},
+				}},
+			}}},
+		},
+	}
+	return m, st
+}
+
+func TestEncoder_Encode(t *testing.T) {
+	m, st := sliceModel()
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	e.Units = 0.5
+	if err := e.Encode(m, "", st); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	want := `$$HEADERSTART
+$$ASCII
+$$UNITS/0.5
+$$VERSION/200
+$$LABEL/1,part
+$$DIMENSION/0,0,1,4,6,3
+$$LAYERS/2
+$$HEADEREND
+$$GEOMETRYSTART
+$$LAYER/2
+$$POLYLINE/1,1,5,0,0,4,0,4,4,0,4,0,0
+$$POLYLINE/1,0,5,0,0,0,4,4,4,4,0,0,0
+$$POLYLINE/1,2,2,0,4,2,6
+$$LAYER/3
+$$GEOMETRYEND
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Encoder.Encode() = %v, want %v", got, want)
+	}
+}
+
+func TestEncoder_Encode_binary(t *testing.T) {
+	m, st := sliceModel()
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	e.Binary = true
+	e.Label = "binary"
+	if err := e.Encode(m, "", st); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	got, header, err := NewDecoder(&buf).DecodeStack()
+	if err != nil {
+		t.Fatalf("Decoder.DecodeStack() error = %v", err)
+	}
+	if diff := deep.Equal(header, &Header{Binary: true, Units: 1, Version: 200, Label: "binary", Dimension: [6]float32{0, 0, 0.5, 2, 3, 1.5}, Layers: 2}); diff != nil {
+		t.Errorf("Encoder.Encode() header = %v", diff)
+	}
+	want := &slices.SliceStack{BottomZ: 0.5, Slices: []*slices.Slice{
+		{TopZ: 1, Vertices: []go3mf.Point2D{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {1, 3}}, Polygons: []slices.Polygon{
+			{StartV: 0, Segments: []slices.Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}},
+			{StartV: 0, Segments: []slices.Segment{{V2: 3}, {V2: 2}, {V2: 1}, {V2: 0}}},
+			{StartV: 3, Segments: []slices.Segment{{V2: 4}}},
+		}},
+		{TopZ: 1.5},
+	}}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Encoder.Encode() = %v", diff)
+	}
+}
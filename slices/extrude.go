@@ -0,0 +1,182 @@
+package slices
+
+import (
+	"math"
+	"sort"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// An Extruder reconstructs triangle meshes from slice stacks,
+// so they can be drawn by viewers that do not support the slice extension
+// or used as the missing mesh of objects that only have meaningful slices.
+//
+// Each slice is extruded as a prism from the TopZ of the previous slice,
+// or the stack BottomZ for the first one, to its own TopZ.
+// The slices are interpreted using the even-odd rule and open polygons are implicitly closed.
+//
+// If Stitch is false every layer is emitted as an independent closed shell,
+// so consecutive layers have coincident caps.
+// If Stitch is true the side walls of consecutive layers are joined
+// and only the exposed parts of the caps are emitted,
+// so the result is a single closed surface without internal faces.
+type Extruder struct {
+	Stitch bool
+}
+
+// NewExtruder returns a new Extruder.
+func NewExtruder(stitch bool) *Extruder {
+	return &Extruder{Stitch: stitch}
+}
+
+// Extrude returns the mesh of st, which is defined in the model part path.
+// The slice references are followed into the child model parts.
+func (e *Extruder) Extrude(m *go3mf.Model, path string, st *SliceStack) (*go3mf.Mesh, error) {
+	src, err := stackSlices(m, path, st)
+	if err != nil {
+		return nil, err
+	}
+	mesh := new(go3mf.Mesh)
+	regions := make([][][]go3mf.Point2D, len(src))
+	for i, s := range src {
+		loops := contours(s)
+		regions[i] = resolve(loops, func(p [2]float64) bool { return evenOdd(loops, p) })
+	}
+	var tris [][3]go3mf.Point3D
+	bottomZ := st.BottomZ
+	for i, s := range src {
+		if !e.Stitch {
+			tris = caps(tris[:0], regions[i], bottomZ, false)
+			tris = caps(tris, regions[i], s.TopZ, true)
+			tris = walls(tris, regions[i], bottomZ, s.TopZ)
+			addTriangles(go3mf.NewMeshBuilder(mesh), tris)
+		} else {
+			if i == 0 {
+				tris = caps(tris, regions[i], bottomZ, false)
+			} else {
+				below, above := newSlice(0, regions[i-1]), newSlice(0, regions[i])
+				tris = caps(tris, contours(Difference(below, above)), bottomZ, true)
+				tris = caps(tris, contours(Difference(above, below)), bottomZ, false)
+			}
+			tris = walls(tris, regions[i], bottomZ, s.TopZ)
+			if i == len(src)-1 {
+				tris = caps(tris, regions[i], s.TopZ, true)
+			}
+		}
+		bottomZ = s.TopZ
+	}
+	if e.Stitch {
+		addTriangles(go3mf.NewMeshBuilder(mesh), splitTJunctions(tris))
+	}
+	return mesh, nil
+}
+
+// AddMesh sets the mesh of obj to the extrusion of its slice stack,
+// which is defined in the model part path.
+// It returns false if obj does not reference a slice stack
+// or it already has components or a mesh with triangles,
+// and an error if the referenced slice stack does not exist.
+func (e *Extruder) AddMesh(m *go3mf.Model, path string, obj *go3mf.Object) (bool, error) {
+	if obj.Components != nil || (obj.Mesh != nil && len(obj.Mesh.Triangles) > 0) {
+		return false, nil
+	}
+	st, err := ObjectSliceStack(m, path, obj)
+	if err != nil || st == nil {
+		return false, err
+	}
+	mesh, err := e.Extrude(m, path, st)
+	if err != nil {
+		return false, err
+	}
+	if obj.Mesh != nil {
+		mesh.AnyAttr, mesh.Any = obj.Mesh.AnyAttr, obj.Mesh.Any
+	}
+	obj.Mesh = mesh
+	return true, nil
+}
+
+// caps appends the triangles that fill the region bounded by loops at z,
+// facing up or down.
+func caps(tris [][3]go3mf.Point3D, loops [][]go3mf.Point2D, z float32, up bool) [][3]go3mf.Point3D {
+	for _, t := range triangulate(loops) {
+		if !up {
+			t[1], t[2] = t[2], t[1]
+		}
+		tris = append(tris, [3]go3mf.Point3D{{t[0].X(), t[0].Y(), z}, {t[1].X(), t[1].Y(), z}, {t[2].X(), t[2].Y(), z}})
+	}
+	return tris
+}
+
+// walls appends the vertical quads that join each loop edge from z0 to z1,
+// facing the outside of the region.
+func walls(tris [][3]go3mf.Point3D, loops [][]go3mf.Point2D, z0, z1 float32) [][3]go3mf.Point3D {
+	for _, loop := range loops {
+		for i, p := range loop {
+			q := loop[(i+1)%len(loop)]
+			p0, q0 := go3mf.Point3D{p.X(), p.Y(), z0}, go3mf.Point3D{q.X(), q.Y(), z0}
+			p1, q1 := go3mf.Point3D{p.X(), p.Y(), z1}, go3mf.Point3D{q.X(), q.Y(), z1}
+			tris = append(tris, [3]go3mf.Point3D{p0, q0, q1}, [3]go3mf.Point3D{p0, q1, p1})
+		}
+	}
+	return tris
+}
+
+func addTriangles(mb *go3mf.MeshBuilder, tris [][3]go3mf.Point3D) {
+	for _, t := range tris {
+		mb.Mesh.Triangles = append(mb.Mesh.Triangles, go3mf.NewTriangle(mb.AddVertex(t[0]), mb.AddVertex(t[1]), mb.AddVertex(t[2])))
+	}
+}
+
+// splitTJunctions splits the triangles that have a vertex of another triangle
+// in the interior of one of their horizontal edges, so the mesh is watertight.
+func splitTJunctions(tris [][3]go3mf.Point3D) [][3]go3mf.Point3D {
+	seen := make(map[go3mf.Point3D]struct{})
+	levels := make(map[float32][]go3mf.Point2D)
+	for _, t := range tris {
+		for _, v := range t {
+			if _, ok := seen[v]; !ok {
+				seen[v] = struct{}{}
+				levels[v.Z()] = append(levels[v.Z()], go3mf.Point2D{v.X(), v.Y()})
+			}
+		}
+	}
+	for _, level := range levels {
+		sort.Slice(level, func(i, j int) bool { return level[i].X() < level[j].X() })
+	}
+	result := make([][3]go3mf.Point3D, 0, len(tris))
+	for len(tris) > 0 {
+		t := tris[len(tris)-1]
+		tris = tris[:len(tris)-1]
+		split := false
+		for i := 0; i < 3 && !split; i++ {
+			a, b, c := t[i], t[(i+1)%3], t[(i+2)%3]
+			if a.Z() != b.Z() {
+				continue
+			}
+			if p, ok := pointOnEdge(levels[a.Z()], go3mf.Point2D{a.X(), a.Y()}, go3mf.Point2D{b.X(), b.Y()}); ok {
+				v := go3mf.Point3D{p.X(), p.Y(), a.Z()}
+				tris = append(tris, [3]go3mf.Point3D{a, v, c}, [3]go3mf.Point3D{v, b, c})
+				split = true
+			}
+		}
+		if !split {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// pointOnEdge returns a point of level, which is sorted by X,
+// that lies in the interior of the segment a-b.
+func pointOnEdge(level []go3mf.Point2D, a, b go3mf.Point2D) (go3mf.Point2D, bool) {
+	scale := math.Max(math.Max(math.Abs(float64(a.X())), math.Abs(float64(a.Y()))), math.Max(math.Abs(float64(b.X())), math.Abs(float64(b.Y()))))
+	tol := 1e-6 * (1 + scale)
+	minX, maxX := math.Min(float64(a.X()), float64(b.X()))-tol, math.Max(float64(a.X()), float64(b.X()))+tol
+	for i := sort.Search(len(level), func(i int) bool { return float64(level[i].X()) >= minX }); i < len(level) && float64(level[i].X()) <= maxX; i++ {
+		p := level[i]
+		if distance2(p, a) > tol*tol && distance2(p, b) > tol*tol && segmentDistance(p, a, b) <= tol {
+			return p, true
+		}
+	}
+	return go3mf.Point2D{}, false
+}
@@ -0,0 +1,136 @@
+package slices
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+	specerr "github.com/qmuntal/go3mf/errors"
+)
+
+// closedVolume returns the volume enclosed by mesh and
+// false if there is any edge that is not shared by two opposite triangles.
+func closedVolume(mesh *go3mf.Mesh) (float64, bool) {
+	edges := make(map[[2]uint32]int)
+	var volume float64
+	for _, t := range mesh.Triangles {
+		v1, v2, v3 := t.Indices()
+		edges[[2]uint32{v1, v2}]++
+		edges[[2]uint32{v2, v3}]++
+		edges[[2]uint32{v3, v1}]++
+		a, b, c := mesh.Vertices[v1], mesh.Vertices[v2], mesh.Vertices[v3]
+		volume += (float64(a.X())*(float64(b.Y())*float64(c.Z())-float64(b.Z())*float64(c.Y())) -
+			float64(a.Y())*(float64(b.X())*float64(c.Z())-float64(b.Z())*float64(c.X())) +
+			float64(a.Z())*(float64(b.X())*float64(c.Y())-float64(b.Y())*float64(c.X()))) / 6
+	}
+	for e, n := range edges {
+		if n != 1 || edges[[2]uint32{e[1], e[0]}] != 1 {
+			return volume, false
+		}
+	}
+	return volume, true
+}
+
+func TestExtruder_Extrude(t *testing.T) {
+	layer := func(topZ float32, loops ...[]go3mf.Point2D) *Slice {
+		s := sliceOf(loops...)
+		s.TopZ = topZ
+		return s
+	}
+	frame := [][]go3mf.Point2D{rect(0, 0, 4, 4), rect(1, 1, 3, 3)}
+	tests := []struct {
+		name      string
+		st        *SliceStack
+		volume    float64
+		triangles int
+	}{
+		{"empty", &SliceStack{}, 0, 0},
+		{"single", &SliceStack{BottomZ: 1, Slices: []*Slice{layer(2, rect(0, 0, 2, 2))}}, 4, 12},
+		{"same", &SliceStack{Slices: []*Slice{layer(1, rect(0, 0, 2, 2)), layer(2, rect(0, 0, 2, 2))}}, 8, 0},
+		{"step", &SliceStack{Slices: []*Slice{layer(1, rect(0, 0, 2, 2)), layer(2, rect(0, 0, 1, 2))}}, 6, 0},
+		{"shifted", &SliceStack{Slices: []*Slice{layer(1, rect(0, 0, 2, 2)), layer(2, rect(1, 1, 3, 3))}}, 8, 0},
+		{"hole", &SliceStack{Slices: []*Slice{layer(1, frame...), layer(2, rect(0, 0, 4, 4)), layer(3, frame...)}}, 40, 0},
+		{"islands", &SliceStack{Slices: []*Slice{layer(1, rect(0, 0, 1, 1), rect(2, 0, 3, 1)), layer(2, rect(0, 0, 3, 1))}}, 5, 0},
+		{"rotated", &SliceStack{Slices: []*Slice{layer(1, rect(0, 0, 2, 2)), layer(2, []go3mf.Point2D{{1, -0.3}, {2.3, 1}, {1, 2.3}, {-0.3, 1}})}}, 4 + 2*1.3*1.3, 0},
+		{"clockwise", &SliceStack{Slices: []*Slice{layer(1, []go3mf.Point2D{{0, 0}, {0, 2}, {2, 2}, {2, 0}})}}, 4, 12},
+	}
+	for _, tt := range tests {
+		for _, stitch := range []bool{false, true} {
+			name := tt.name
+			if stitch {
+				name += "Stitch"
+			}
+			t.Run(name, func(t *testing.T) {
+				got, err := NewExtruder(stitch).Extrude(nil, "", tt.st)
+				if err != nil {
+					t.Fatalf("Extruder.Extrude() error = %v", err)
+				}
+				volume, ok := closedVolume(got)
+				if !ok {
+					t.Errorf("Extruder.Extrude() stitch %v is not closed", stitch)
+				}
+				if math.Abs(volume-tt.volume) > 1e-4 {
+					t.Errorf("Extruder.Extrude() stitch %v volume = %v, want %v", stitch, volume, tt.volume)
+				}
+				if tt.triangles > 0 && len(got.Triangles) != tt.triangles {
+					t.Errorf("Extruder.Extrude() stitch %v triangles = %d, want %d", stitch, len(got.Triangles), tt.triangles)
+				}
+			})
+		}
+	}
+}
+
+func TestExtruder_Extrude_stitch(t *testing.T) {
+	st := &SliceStack{Slices: []*Slice{sliceOf(rect(0, 0, 2, 2)), sliceOf(rect(0, 0, 2, 2))}}
+	st.Slices[1].TopZ = 2
+	got, err := NewExtruder(true).Extrude(nil, "", st)
+	if err != nil {
+		t.Fatalf("Extruder.Extrude() error = %v", err)
+	}
+	if len(got.Vertices) != 12 || len(got.Triangles) != 20 {
+		t.Errorf("Extruder.Extrude() = %d vertices and %d triangles, want 12 and 20", len(got.Vertices), len(got.Triangles))
+	}
+}
+
+func TestExtruder_AddMesh(t *testing.T) {
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{
+				&SliceStack{ID: 1, Refs: []SliceRef{{SliceStackID: 2, Path: "/3D/slices.model"}}},
+				&SliceStack{ID: 3, Refs: []SliceRef{{SliceStackID: 5, Path: "/3D/slices.model"}}},
+			},
+			Objects: []*go3mf.Object{
+				{ID: 10, Mesh: &go3mf.Mesh{AnyAttr: go3mf.AttrMarshalers{&go3mf.UnknownAttrs{}}}, AnyAttr: go3mf.AttrMarshalers{&SliceStackInfo{SliceStackID: 1, MeshResolution: ResolutionLow}}},
+				{ID: 11, Mesh: &go3mf.Mesh{Triangles: make([]go3mf.Triangle, 1)}, AnyAttr: go3mf.AttrMarshalers{&SliceStackInfo{SliceStackID: 1}}},
+				{ID: 12},
+				{ID: 13, AnyAttr: go3mf.AttrMarshalers{&SliceStackInfo{SliceStackID: 20}}},
+				{ID: 14, AnyAttr: go3mf.AttrMarshalers{&SliceStackInfo{SliceStackID: 3}}},
+			},
+		},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/slices.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&SliceStack{ID: 2, Slices: []*Slice{sliceOf(rect(0, 0, 2, 2))}},
+			}}},
+		},
+	}
+	e := NewExtruder(false)
+	obj := m.Resources.Objects[0]
+	if ok, err := e.AddMesh(m, "", obj); !ok || err != nil {
+		t.Fatalf("Extruder.AddMesh() = %v, %v, want true, nil", ok, err)
+	}
+	if len(obj.Mesh.Triangles) != 12 || len(obj.Mesh.AnyAttr) != 1 {
+		t.Errorf("Extruder.AddMesh() mesh = %d triangles and %d attributes, want 12 and 1", len(obj.Mesh.Triangles), len(obj.Mesh.AnyAttr))
+	}
+	for _, obj := range m.Resources.Objects[1:3] {
+		if ok, err := e.AddMesh(m, "", obj); ok || err != nil {
+			t.Errorf("Extruder.AddMesh() object %d = %v, %v, want false, nil", obj.ID, ok, err)
+		}
+	}
+	if ok, err := e.AddMesh(m, "", m.Resources.Objects[3]); ok || !errors.Is(err, specerr.ErrMissingResource) {
+		t.Errorf("Extruder.AddMesh() object 13 = %v, %v, want false, %v", ok, err, specerr.ErrMissingResource)
+	}
+	if _, err := e.AddMesh(m, "", m.Resources.Objects[4]); err == nil {
+		t.Error("Extruder.AddMesh() expected error")
+	}
+}
@@ -0,0 +1,294 @@
+package slices
+
+import (
+	"math"
+	"sort"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+// ValidateGeometry checks that the slices of r do not contain
+// duplicated vertices, zero length segments, self-intersecting polygons
+// nor polygons that cross or overlap other polygons.
+//
+// These checks are not required by the specification, so they are not
+// part of the model validation, but the slices that do not pass them
+// are usually rejected by the path planners.
+// Polygons are allowed to touch each other and themselves at isolated points
+// as long as they do not cross there, and a closed polygon nested inside another
+// is reported as an overlap if both have the same orientation.
+func (r *SliceStack) ValidateGeometry() error {
+	var errs error
+	for j, slice := range r.Slices {
+		errs = errors.Append(errs, errors.WrapIndex(slice.ValidateGeometry(), slice, j))
+	}
+	return errs
+}
+
+// ValidateGeometry checks the geometry of a single slice.
+// See SliceStack.ValidateGeometry for more details.
+func (s *Slice) ValidateGeometry() error {
+	var errs error
+	vertices := make(map[go3mf.Point2D]struct{}, len(s.Vertices))
+	for _, v := range s.Vertices {
+		if _, ok := vertices[v]; ok {
+			errs = errors.Append(errs, errors.ErrSliceDuplicatedVertex)
+			break
+		}
+		vertices[v] = struct{}{}
+	}
+	var segments []edge
+	points := make([][]go3mf.Point2D, len(s.Polygons))
+	for k, p := range s.Polygons {
+		points[k], _ = s.PolygonPoints(p)
+		v1 := p.StartV
+		var zeroLength bool
+		for _, seg := range p.Segments {
+			v2 := seg.V2
+			if int(v1) < len(s.Vertices) && int(v2) < len(s.Vertices) {
+				if s.Vertices[v1] == s.Vertices[v2] {
+					zeroLength = true
+				} else {
+					segments = append(segments, newEdge(s.Vertices[v1], s.Vertices[v2], k))
+				}
+			}
+			v1 = v2
+		}
+		if zeroLength {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrSliceZeroLengthSegment, p, k))
+		}
+	}
+	self := make(map[int]struct{})
+	crossed := make(map[int]struct{})
+	intersecting := make(map[int]struct{})
+	report := func(k1, k2 int) {
+		intersecting[k1], intersecting[k2] = struct{}{}, struct{}{}
+		if k1 == k2 {
+			self[k1] = struct{}{}
+		} else if k1 > k2 {
+			crossed[k1] = struct{}{}
+		} else {
+			crossed[k2] = struct{}{}
+		}
+	}
+	touches := make(map[go3mf.Point2D][]chain)
+	for k, pts := range points {
+		addVertexChains(touches, pts, k)
+	}
+	overlappingPairs(segments, func(e1, e2 *edge) {
+		if crosses(e1, e2) {
+			report(e1.polygon, e2.polygon)
+		}
+		// A vertex in the interior of the other segment is a touching point.
+		for _, e := range [2][2]*edge{{e1, e2}, {e2, e1}} {
+			for _, v := range [2]go3mf.Point2D{e[1].p1, e[1].p2} {
+				if v != e[0].p1 && v != e[0].p2 && onSegment(e[0], v) {
+					touches[v] = append(touches[v], chain{e[0].p1, e[0].p2, e[0].polygon})
+				}
+			}
+		}
+	})
+	for v, chains := range touches {
+		for i := range chains {
+			for j := i + 1; j < len(chains); j++ {
+				if interleave(v, &chains[i], &chains[j]) {
+					report(chains[i].polygon, chains[j].polygon)
+				}
+			}
+		}
+	}
+	for k := range sameOrientationNesting(points, intersecting) {
+		crossed[k] = struct{}{}
+	}
+	for k, p := range s.Polygons {
+		if _, ok := self[k]; ok {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrSliceSelfIntersection, p, k))
+		}
+		if _, ok := crossed[k]; ok {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrSlicePolygonIntersection, p, k))
+		}
+	}
+	return errs
+}
+
+// isClosed reports whether pts describe a closed polygon with a non-zero area.
+func isClosed(pts []go3mf.Point2D) bool {
+	return len(pts) > 3 && pts[0] == pts[len(pts)-1]
+}
+
+// A chain is the pair of directions, given by the points in and out,
+// in which a polygon leaves a touching point.
+type chain struct {
+	in, out go3mf.Point2D
+	polygon int
+}
+
+// addVertexChains adds to touches the chains of the polygon vertices.
+// The ends of the open polygons do not have chains, as they cannot cross.
+func addVertexChains(touches map[go3mf.Point2D][]chain, pts []go3mf.Point2D, polygon int) {
+	closed := isClosed(pts)
+	for i := 1; i < len(pts)-1; i++ {
+		touches[pts[i]] = append(touches[pts[i]], chain{pts[i-1], pts[i+1], polygon})
+	}
+	if closed {
+		touches[pts[0]] = append(touches[pts[0]], chain{pts[len(pts)-2], pts[1], polygon})
+	}
+}
+
+// interleave reports whether the chains c1 and c2, which touch at v, cross each other,
+// that is, whether exactly one of the directions of c2 is between the directions of c1.
+// Chains that share a direction overlap along a segment, which is reported by crosses.
+func interleave(v go3mf.Point2D, c1, c2 *chain) bool {
+	for _, d1 := range [2]go3mf.Point2D{c1.in, c1.out} {
+		for _, d2 := range [2]go3mf.Point2D{c2.in, c2.out} {
+			if sameDirection(v, d1, d2) {
+				return false
+			}
+		}
+	}
+	return between(v, c1.in, c1.out, c2.in) != between(v, c1.in, c1.out, c2.out)
+}
+
+// sameDirection reports whether a and b are in the same direction from v.
+func sameDirection(v, a, b go3mf.Point2D) bool {
+	dot := float64(a.X()-v.X())*float64(b.X()-v.X()) + float64(a.Y()-v.Y())*float64(b.Y()-v.Y())
+	return orientation(v, a, b) == 0 && dot > 0
+}
+
+// between reports whether the direction of x from v is strictly inside
+// the counter-clockwise arc that goes from the direction of a to the direction of b.
+func between(v, a, b, x go3mf.Point2D) bool {
+	oab := orientation(v, a, b)
+	switch {
+	case oab > 0:
+		return orientation(v, a, x) > 0 && orientation(v, x, b) > 0
+	case oab < 0:
+		return !(orientation(v, b, x) >= 0 && orientation(v, x, a) >= 0)
+	}
+	return orientation(v, a, x) > 0
+}
+
+// onSegment reports whether v lies on the segment e.
+func onSegment(e *edge, v go3mf.Point2D) bool {
+	return orientation(e.p1, e.p2, v) == 0 &&
+		e.min.X() <= v.X() && v.X() <= e.max.X() && e.min.Y() <= v.Y() && v.Y() <= e.max.Y()
+}
+
+// sameOrientationNesting returns the closed polygons that have the same orientation
+// as the smallest polygon that contains them.
+// The polygons in skip are ignored, as their nesting is not well defined.
+func sameOrientationNesting(points [][]go3mf.Point2D, skip map[int]struct{}) map[int]struct{} {
+	areas := make([]float64, len(points))
+	for k, pts := range points {
+		if _, ok := skip[k]; !ok && isClosed(pts) {
+			areas[k] = signedArea(pts)
+		}
+	}
+	nested := make(map[int]struct{})
+	for k, pts := range points {
+		if areas[k] == 0 {
+			continue
+		}
+		parent := -1
+		for k2, pts2 := range points {
+			if k2 == k || areas[k2] == 0 || math.Abs(areas[k2]) <= math.Abs(areas[k]) {
+				continue
+			}
+			if contains(pts2, pts) && (parent == -1 || math.Abs(areas[k2]) < math.Abs(areas[parent])) {
+				parent = k2
+			}
+		}
+		if parent != -1 && (areas[k] > 0) == (areas[parent] > 0) {
+			nested[k] = struct{}{}
+		}
+	}
+	return nested
+}
+
+// contains reports whether the closed polygon outer contains inner,
+// which is assumed to not cross it, by checking the first vertex of inner
+// that is not on the boundary of outer.
+func contains(outer, inner []go3mf.Point2D) bool {
+	for _, v := range inner[:len(inner)-1] {
+		var boundary bool
+		for i := 0; i < len(outer)-1 && !boundary; i++ {
+			e := newEdge(outer[i], outer[i+1], 0)
+			boundary = onSegment(&e, v)
+		}
+		if !boundary {
+			return evenOdd([][]go3mf.Point2D{outer}, [2]float64{float64(v.X()), float64(v.Y())})
+		}
+	}
+	return false
+}
+
+// An edge is a polygon segment with its bounding box.
+type edge struct {
+	p1, p2   go3mf.Point2D
+	min, max go3mf.Point2D
+	polygon  int
+}
+
+func newEdge(p1, p2 go3mf.Point2D, polygon int) edge {
+	e := edge{p1: p1, p2: p2, min: p1, max: p2, polygon: polygon}
+	for i := range e.min {
+		if e.min[i] > e.max[i] {
+			e.min[i], e.max[i] = e.max[i], e.min[i]
+		}
+	}
+	return e
+}
+
+// overlappingPairs calls fn for each pair of edges whose bounding boxes overlap,
+// using a sweep along the X axis.
+func overlappingPairs(edges []edge, fn func(e1, e2 *edge)) {
+	sort.Slice(edges, func(i, j int) bool { return edges[i].min.X() < edges[j].min.X() })
+	var active []*edge
+	for i := range edges {
+		e := &edges[i]
+		n := 0
+		for _, a := range active {
+			if a.max.X() >= e.min.X() {
+				active[n] = a
+				n++
+				if a.min.Y() <= e.max.Y() && e.min.Y() <= a.max.Y() {
+					fn(a, e)
+				}
+			}
+		}
+		active = append(active[:n], e)
+	}
+}
+
+// crosses reports whether the interiors of e1 and e2 intersect at a single point
+// or the segments overlap along a positive length.
+func crosses(e1, e2 *edge) bool {
+	o1 := orientation(e1.p1, e1.p2, e2.p1)
+	o2 := orientation(e1.p1, e1.p2, e2.p2)
+	o3 := orientation(e2.p1, e2.p2, e1.p1)
+	o4 := orientation(e2.p1, e2.p2, e1.p2)
+	if o1 == 0 && o2 == 0 {
+		// Collinear, compare the projections along the dominant axis.
+		axis := 0
+		if e1.max.X()-e1.min.X() < e1.max.Y()-e1.min.Y() {
+			axis = 1
+		}
+		lo, hi := e1.min[axis], e1.max[axis]
+		if e2.min[axis] > lo {
+			lo = e2.min[axis]
+		}
+		if e2.max[axis] < hi {
+			hi = e2.max[axis]
+		}
+		return lo < hi
+	}
+	return o1*o2 < 0 && o3*o4 < 0
+}
+
+// orientation returns a positive value if a, b and c are counter-clockwise,
+// a negative value if they are clockwise and zero if they are collinear.
+func orientation(a, b, c go3mf.Point2D) float64 {
+	ax, ay := float64(a.X()), float64(a.Y())
+	return (float64(b.X())-ax)*(float64(c.Y())-ay) - (float64(b.Y())-ay)*(float64(c.X())-ax)
+}
@@ -0,0 +1,77 @@
+package slices
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func TestSliceStack_ValidateGeometry(t *testing.T) {
+	closed := func(indices ...uint32) Polygon {
+		p := Polygon{StartV: indices[0]}
+		for _, i := range append(indices[1:], indices[0]) {
+			p.Segments = append(p.Segments, Segment{V2: i})
+		}
+		return p
+	}
+	square := []go3mf.Point2D{{0, 0}, {4, 0}, {4, 4}, {0, 4}}
+	st := &SliceStack{Slices: []*Slice{
+		{TopZ: 1, Vertices: append(square, go3mf.Point2D{1, 1}, go3mf.Point2D{1, 2}, go3mf.Point2D{2, 2}, go3mf.Point2D{8, 4}, go3mf.Point2D{8, 8}), Polygons: []Polygon{
+			closed(0, 1, 2, 3),
+			closed(4, 5, 6),
+			closed(2, 7, 8), // Touches the first polygon at a vertex.
+		}},
+		{TopZ: 2, Vertices: append(square, go3mf.Point2D{0, 0}), Polygons: []Polygon{
+			closed(0, 2, 1, 3),
+			{StartV: 0, Segments: []Segment{{V2: 4}, {V2: 1}, {V2: 0}}},
+			{StartV: 2, Segments: []Segment{{V2: 10}}},
+		}},
+		{TopZ: 3, Vertices: append(square, go3mf.Point2D{2, 2}, go3mf.Point2D{6, 2}, go3mf.Point2D{6, 6}, go3mf.Point2D{2, 4}, go3mf.Point2D{1, 4}), Polygons: []Polygon{
+			closed(0, 1, 2, 3),
+			closed(4, 5, 6),
+			closed(0, 1, 7),
+			closed(3, 8, 7),
+		}},
+		{TopZ: 4, Vertices: []go3mf.Point2D{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {3, 1}, {1, 1}}, Polygons: []Polygon{
+			closed(0, 1, 2, 3),
+			closed(4, 2, 5, 1), // Crosses the first polygon at two shared vertices.
+		}},
+		{TopZ: 5, Vertices: append(square, go3mf.Point2D{1, 1}, go3mf.Point2D{2, 1}, go3mf.Point2D{2, 2}, go3mf.Point2D{1, 2}), Polygons: []Polygon{
+			closed(0, 1, 2, 3),
+			closed(4, 5, 6, 7), // Nested with the same orientation.
+		}},
+	}}
+	want := []error{
+		fmt.Errorf("Slice#1: %v", errors.ErrSliceDuplicatedVertex),
+		fmt.Errorf("Slice#1@Polygon#1: %v", errors.ErrSliceZeroLengthSegment),
+		fmt.Errorf("Slice#1@Polygon#0: %v", errors.ErrSliceSelfIntersection),
+		fmt.Errorf("Slice#1@Polygon#1: %v", errors.ErrSliceSelfIntersection),
+		fmt.Errorf("Slice#2@Polygon#1: %v", errors.ErrSlicePolygonIntersection),
+		fmt.Errorf("Slice#2@Polygon#2: %v", errors.ErrSlicePolygonIntersection),
+		fmt.Errorf("Slice#2@Polygon#3: %v", errors.ErrSliceSelfIntersection),
+		fmt.Errorf("Slice#2@Polygon#3: %v", errors.ErrSlicePolygonIntersection),
+		fmt.Errorf("Slice#3@Polygon#1: %v", errors.ErrSlicePolygonIntersection),
+		fmt.Errorf("Slice#4@Polygon#1: %v", errors.ErrSlicePolygonIntersection),
+	}
+	err := st.ValidateGeometry()
+	if err == nil {
+		t.Fatal("SliceStack.ValidateGeometry() err = nil, want errors")
+	}
+	var got []string
+	for _, e := range err.(*errors.List).Errors {
+		got = append(got, e.Error())
+	}
+	var wantStr []string
+	for _, e := range want {
+		wantStr = append(wantStr, e.Error())
+	}
+	if diff := deep.Equal(got, wantStr); diff != nil {
+		t.Errorf("SliceStack.ValidateGeometry() = %v", diff)
+	}
+	if err := st.Slices[0].ValidateGeometry(); err != nil {
+		t.Errorf("Slice.ValidateGeometry() = %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package imagestack
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"image/png"
+	"io"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/slices"
+)
+
+// A Decoder reads an image-stack archive.
+type Decoder struct {
+	r    io.ReaderAt
+	size int64
+}
+
+// NewDecoder returns a new decoder that reads the archive from r,
+// which has the given size.
+func NewDecoder(r io.ReaderAt, size int64) *Decoder {
+	return &Decoder{
+		r:    r,
+		size: size,
+	}
+}
+
+// Decode reads the archive into a slice stack and adds it to the m resources.
+func (d *Decoder) Decode(m *go3mf.Model) error {
+	st, _, err := d.DecodeStack()
+	if err != nil {
+		return err
+	}
+	st.ID = m.Resources.UnusedID()
+	m.Resources.Assets = append(m.Resources.Assets, st)
+	return nil
+}
+
+// DecodeStack reads the archive into a slice stack without ID.
+// The slice polygons are the contours of the layer images.
+func (d *Decoder) DecodeStack() (*slices.SliceStack, *Manifest, error) {
+	z, err := zip.NewReader(d.r, d.size)
+	if err != nil {
+		return nil, nil, err
+	}
+	files := make(map[string]*zip.File, len(z.File))
+	for _, f := range z.File {
+		files[f.Name] = f
+	}
+	f, ok := files[ManifestName]
+	if !ok {
+		return nil, nil, ErrMissingManifest
+	}
+	manifest := new(Manifest)
+	if err = decodeFile(f, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(manifest)
+	}); err != nil {
+		return nil, nil, err
+	}
+	tracer := &slices.Rasterizer{PixelSize: manifest.PixelSize, Origin: manifest.Origin}
+	st := &slices.SliceStack{BottomZ: manifest.BottomZ}
+	for _, l := range manifest.Layers {
+		f, ok := files[l.File]
+		if !ok {
+			return nil, nil, ErrMissingLayer
+		}
+		var s *slices.Slice
+		if err = decodeFile(f, func(r io.Reader) error {
+			img, err := png.Decode(r)
+			if err == nil {
+				s = tracer.Trace(img)
+			}
+			return err
+		}); err != nil {
+			return nil, nil, err
+		}
+		s.TopZ = l.TopZ
+		st.Slices = append(st.Slices, s)
+	}
+	return st, manifest, nil
+}
+
+func decodeFile(f *zip.File, fn func(io.Reader) error) error {
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return fn(r)
+}
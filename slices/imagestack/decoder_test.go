@@ -0,0 +1,61 @@
+package imagestack
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/slices"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	var buf bytes.Buffer
+	r := &slices.Rasterizer{Width: 3, Height: 2, PixelSize: 0.5, Origin: go3mf.Point2D{-1, 0}}
+	if err := NewEncoder(&buf, r).Encode(slicedModel()); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	m := &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{{ID: 1}}}}
+	if err := NewDecoder(bytes.NewReader(buf.Bytes()), int64(buf.Len())).Decode(m); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	// The image clips the squares to x in [0, 0.5] and y in [0, 1].
+	clipped := func(topZ float32) *slices.Slice {
+		return &slices.Slice{
+			TopZ:     topZ,
+			Vertices: []go3mf.Point2D{{0, 0}, {0.5, 0}, {0.5, 1}, {0, 1}},
+			Polygons: []slices.Polygon{{StartV: 0, Segments: []slices.Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}}},
+		}
+	}
+	want := &slices.SliceStack{ID: 2, Slices: []*slices.Slice{clipped(1), clipped(1.5), clipped(2)}}
+	if diff := deep.Equal(m.Resources.Assets, []go3mf.Asset{want}); diff != nil {
+		t.Errorf("Decoder.Decode() = %v", diff)
+	}
+}
+
+func TestDecoder_Decode_error(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  error
+	}{
+		{"noManifest", map[string]string{"00001.png": ""}, ErrMissingManifest},
+		{"noLayer", map[string]string{ManifestName: `{"layers":[{"file":"00001.png","topZ":1}]}`}, ErrMissingLayer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			z := zip.NewWriter(&buf)
+			for name, content := range tt.files {
+				w, _ := z.Create(name)
+				w.Write([]byte(content))
+			}
+			z.Close()
+			err := NewDecoder(bytes.NewReader(buf.Bytes()), int64(buf.Len())).Decode(new(go3mf.Model))
+			if err != tt.want {
+				t.Errorf("Decoder.Decode() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
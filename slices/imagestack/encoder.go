@@ -0,0 +1,129 @@
+package imagestack
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"image"
+	"image/png"
+	"io"
+	"sort"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/slices"
+)
+
+// An Encoder writes the sliced build items of a model into an image-stack archive.
+type Encoder struct {
+	Rasterizer *slices.Rasterizer
+	Exposure   Exposure
+	w          io.Writer
+}
+
+// NewEncoder returns a new encoder that writes to w
+// and uses r to render the layers.
+func NewEncoder(w io.Writer, r *slices.Rasterizer) *Encoder {
+	return &Encoder{
+		Rasterizer: r,
+		w:          w,
+	}
+}
+
+type itemLayers struct {
+	bottomZ float32
+	layers  []slices.Layer
+}
+
+// Encode writes the slice stacks of the m build items,
+// applying the item transforms in the XY plane.
+// The layers of all the items are merged into a single stack whose
+// layer tops are the union of the item layer tops.
+// It returns ErrNoSlices if no build item references a slice stack.
+func (e *Encoder) Encode(m *go3mf.Model) error {
+	var items []itemLayers
+	tops := make(map[float32]struct{})
+	for _, item := range m.Build.Items {
+		path := item.ObjectPath()
+		obj, ok := m.FindObject(path, item.ObjectID)
+		if !ok {
+			continue
+		}
+		st, err := slices.ObjectSliceStack(m, path, obj)
+		if err != nil {
+			return err
+		}
+		if st == nil {
+			continue
+		}
+		layers, err := e.Rasterizer.RasterizeStack(m, path, st, item.Transform)
+		if err != nil {
+			return err
+		}
+		if len(layers) == 0 {
+			continue
+		}
+		for _, l := range layers {
+			tops[l.TopZ] = struct{}{}
+		}
+		items = append(items, itemLayers{bottomZ: st.BottomZ, layers: layers})
+	}
+	if len(items) == 0 {
+		return ErrNoSlices
+	}
+	zs := make([]float32, 0, len(tops))
+	for z := range tops {
+		zs = append(zs, z)
+	}
+	sort.Slice(zs, func(i, j int) bool { return zs[i] < zs[j] })
+	manifest := Manifest{
+		Width:     e.Rasterizer.Width,
+		Height:    e.Rasterizer.Height,
+		PixelSize: e.Rasterizer.PixelSize,
+		Origin:    e.Rasterizer.Origin,
+		BottomZ:   items[0].bottomZ,
+		Exposure:  e.Exposure,
+	}
+	for _, it := range items[1:] {
+		if it.bottomZ < manifest.BottomZ {
+			manifest.BottomZ = it.bottomZ
+		}
+	}
+	manifest.LayerHeight = zs[0] - manifest.BottomZ
+	z := zip.NewWriter(e.w)
+	for i, top := range zs {
+		manifest.Layers = append(manifest.Layers, ManifestLayer{File: layerName(i), TopZ: top})
+		w, err := z.Create(layerName(i))
+		if err != nil {
+			return err
+		}
+		if err = png.Encode(w, merge(e.Rasterizer, items, top)); err != nil {
+			return err
+		}
+	}
+	w, err := z.Create(ManifestName)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(&manifest); err != nil {
+		return err
+	}
+	return z.Close()
+}
+
+// merge returns the union of the item layers that contain top.
+func merge(r *slices.Rasterizer, items []itemLayers, top float32) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, r.Width, r.Height))
+	for _, it := range items {
+		i := sort.Search(len(it.layers), func(i int) bool { return it.layers[i].TopZ >= top })
+		if i == len(it.layers) || (i == 0 && top <= it.bottomZ) {
+			continue
+		}
+		for j, p := range it.layers[i].Image.Pix {
+			if p > img.Pix[j] {
+				img.Pix[j] = p
+			}
+		}
+	}
+	return img
+}
@@ -0,0 +1,108 @@
+package imagestack
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/slices"
+)
+
+func square(topZ, min, max float32) *slices.Slice {
+	return &slices.Slice{
+		TopZ:     topZ,
+		Vertices: []go3mf.Point2D{{min, min}, {max, min}, {max, max}, {min, max}},
+		Polygons: []slices.Polygon{{StartV: 0, Segments: []slices.Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}}},
+	}
+}
+
+func slicedModel() *go3mf.Model {
+	return &go3mf.Model{
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{
+				&slices.SliceStack{ID: 1, Slices: []*slices.Slice{square(1, 0, 1), square(2, 0, 2)}},
+				&slices.SliceStack{ID: 2, BottomZ: 1, Refs: []slices.SliceRef{{SliceStackID: 3, Path: "/3D/other.model"}}},
+			},
+			Objects: []*go3mf.Object{
+				{ID: 3, AnyAttr: go3mf.AttrMarshalers{&slices.SliceStackInfo{SliceStackID: 1}}},
+				{ID: 4, AnyAttr: go3mf.AttrMarshalers{&slices.SliceStackInfo{SliceStackID: 2}}},
+				{ID: 5},
+			},
+		},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/other.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&slices.SliceStack{ID: 3, BottomZ: 1, Slices: []*slices.Slice{square(1.5, 0, 1)}},
+			}}},
+		},
+		Build: go3mf.Build{Items: []*go3mf.Item{
+			{ObjectID: 3},
+			{ObjectID: 4, Transform: go3mf.Identity().Translate(2, 0, 0)},
+			{ObjectID: 5},
+		}},
+	}
+}
+
+func TestEncoder_Encode(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf, slices.NewRasterizer(3, 2, 1))
+	enc.Exposure = Exposure{Time: 2.5, BottomTime: 30, BottomLayers: 2}
+	if err := enc.Encode(slicedModel()); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	var names []string
+	for _, f := range z.File {
+		names = append(names, f.Name)
+	}
+	if diff := deep.Equal(names, []string{"00001.png", "00002.png", "00003.png", ManifestName}); diff != nil {
+		t.Errorf("Encoder.Encode() files = %v", diff)
+	}
+	r, _ := z.File[3].Open()
+	var got Manifest
+	if err := json.NewDecoder(r).Decode(&got); err != nil {
+		t.Fatalf("Encoder.Encode() manifest error = %v", err)
+	}
+	want := Manifest{
+		Width: 3, Height: 2, PixelSize: 1, LayerHeight: 1, Exposure: enc.Exposure,
+		Layers: []ManifestLayer{{"00001.png", 1}, {"00002.png", 1.5}, {"00003.png", 2}},
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Encoder.Encode() manifest = %v", diff)
+	}
+	wantPix := [][]uint8{
+		{0, 0, 0, 255, 0, 0},
+		{255, 255, 0, 255, 255, 255},
+		{255, 255, 0, 255, 255, 0},
+	}
+	for i, pix := range wantPix {
+		r, _ := z.File[i].Open()
+		img, err := png.Decode(r)
+		if err != nil {
+			t.Fatalf("Encoder.Encode() layer %d error = %v", i, err)
+		}
+		var got []uint8
+		for y := 0; y < 2; y++ {
+			for x := 0; x < 3; x++ {
+				c, _, _, _ := img.At(x, y).RGBA()
+				got = append(got, uint8(c>>8))
+			}
+		}
+		if diff := deep.Equal(got, pix); diff != nil {
+			t.Errorf("Encoder.Encode() layer %d = %v", i, diff)
+		}
+	}
+}
+
+func TestEncoder_Encode_noSlices(t *testing.T) {
+	m := &go3mf.Model{Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 1}}}}
+	if err := NewEncoder(new(bytes.Buffer), slices.NewRasterizer(1, 1, 1)).Encode(m); err != ErrNoSlices {
+		t.Errorf("Encoder.Encode() error = %v, want %v", err, ErrNoSlices)
+	}
+}
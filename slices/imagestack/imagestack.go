@@ -0,0 +1,61 @@
+// Package imagestack reads and writes the image-stack archives
+// used by masked stereolithography (resin) printers.
+//
+// An archive is a zip file that contains a PNG image per layer,
+// named after its one-based layer number, and a JSON manifest
+// that describes the image resolution, the layer heights and the exposure settings.
+package imagestack
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// ManifestName is the name of the archive manifest.
+const ManifestName = "manifest.json"
+
+// Errors returned by the Encoder and the Decoder.
+var (
+	ErrNoSlices        = errors.New("imagestack: model does not contain sliced build items")
+	ErrMissingManifest = errors.New("imagestack: archive does not contain a manifest")
+	ErrMissingLayer    = errors.New("imagestack: archive does not contain a manifest layer")
+)
+
+// Exposure defines the light exposure settings of the printer.
+// The bottom layers usually require longer exposures to adhere to the build plate.
+type Exposure struct {
+	Time          float32 `json:"time"`          // Exposure time of a layer, in seconds.
+	BottomTime    float32 `json:"bottomTime"`    // Exposure time of a bottom layer, in seconds.
+	BottomLayers  int     `json:"bottomLayers"`  // Number of bottom layers.
+	LightOffDelay float32 `json:"lightOffDelay"` // Delay between layers, in seconds.
+}
+
+// A ManifestLayer describes a layer image.
+type ManifestLayer struct {
+	File string  `json:"file"`
+	TopZ float32 `json:"topZ"`
+}
+
+// Manifest describes the content of an image-stack archive.
+//
+// Each pixel is a square of PixelSize model units, Origin is the model XY
+// coordinate of the bottom-left corner of the images, and the first image
+// row contains the greatest Y coordinates.
+// LayerHeight is the height of the first layer, which is the nominal
+// layer height when all the layers have the same height.
+type Manifest struct {
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	PixelSize   float32         `json:"pixelSize"`
+	Origin      go3mf.Point2D   `json:"origin"`
+	BottomZ     float32         `json:"bottomZ"`
+	LayerHeight float32         `json:"layerHeight"`
+	Exposure    Exposure        `json:"exposure"`
+	Layers      []ManifestLayer `json:"layers"`
+}
+
+func layerName(i int) string {
+	return fmt.Sprintf("%05d.png", i+1)
+}
@@ -0,0 +1,128 @@
+package slices
+
+import (
+	"math"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// JoinType defines how the offset contours are joined at the convex corners.
+type JoinType uint8
+
+// Supported join types.
+const (
+	// JoinMiter extends the offset edges until they meet,
+	// falling back to a bevel when the miter is longer than the miter limit.
+	JoinMiter JoinType = iota
+	// JoinRound joins the offset edges with a circular arc.
+	JoinRound
+)
+
+// DefaultArcSegments is the default number of segments used to approximate a full circle.
+const DefaultArcSegments = 32
+
+// An Offsetter grows or shrinks slices by a fixed distance.
+//
+// MiterLimit is the maximum distance of a miter join to its corner,
+// as a multiple of the offset distance, and Segments is the number of segments
+// of a full circle that round joins are approximated with.
+type Offsetter struct {
+	Join       JoinType
+	MiterLimit float64
+	Segments   int
+}
+
+// NewOffsetter returns a new Offsetter with a miter limit of 2.
+func NewOffsetter(join JoinType) *Offsetter {
+	return &Offsetter{
+		Join:       join,
+		MiterLimit: 2,
+		Segments:   DefaultArcSegments,
+	}
+}
+
+// Offset returns s grown by delta, or shrunk if delta is negative.
+// The regions are interpreted with the even-odd rule and the result
+// has the same properties than the result of a Union.
+func (o *Offsetter) Offset(s *Slice, delta float32) *Slice {
+	loops := contours(s)
+	loops = resolve(loops, func(p [2]float64) bool { return evenOdd(loops, p) })
+	if delta == 0 {
+		return newSlice(s.TopZ, loops)
+	}
+	raw := make([][]go3mf.Point2D, 0, len(loops))
+	for _, loop := range loops {
+		raw = append(raw, o.offsetLoop(loop, float64(delta)))
+	}
+	return newSlice(s.TopZ, resolve(raw, func(p [2]float64) bool { return winding(raw, p) > 0 }))
+}
+
+// offsetLoop returns the loop moved delta to the right of its edges.
+// The result can contain self-intersections that are solved with the positive winding rule.
+func (o *Offsetter) offsetLoop(loop []go3mf.Point2D, delta float64) []go3mf.Point2D {
+	var raw []go3mf.Point2D
+	add := func(x, y float64) {
+		p := go3mf.Point2D{float32(x), float32(y)}
+		if len(raw) == 0 || raw[len(raw)-1] != p {
+			raw = append(raw, p)
+		}
+	}
+	n := len(loop)
+	for i := range loop {
+		prev, p, next := loop[(i+n-1)%n], loop[i], loop[(i+1)%n]
+		u1x, u1y := unit(prev, p)
+		u2x, u2y := unit(p, next)
+		px, py := float64(p.X()), float64(p.Y())
+		// Right normals.
+		r1x, r1y := u1y, -u1x
+		r2x, r2y := u2y, -u2x
+		cross := u1x*u2y - u1y*u2x
+		dot := u1x*u2x + u1y*u2y
+		if cross*delta <= 0 {
+			// Concave or straight corner, the offset edges overlap.
+			add(px+r1x*delta, py+r1y*delta)
+			if cross != 0 || dot < 0 {
+				add(px, py)
+				add(px+r2x*delta, py+r2y*delta)
+			}
+			continue
+		}
+		switch o.Join {
+		case JoinRound:
+			segments := o.Segments
+			if segments < 3 {
+				segments = DefaultArcSegments
+			}
+			a1 := math.Atan2(r1y, r1x)
+			sweep := math.Atan2(cross, dot)
+			if delta < 0 {
+				a1 = math.Atan2(-r1y, -r1x)
+			}
+			steps := int(math.Ceil(math.Abs(sweep) / (2 * math.Pi) * float64(segments)))
+			r := math.Abs(delta)
+			for k := 0; k <= steps; k++ {
+				a := a1 + sweep*float64(k)/float64(steps)
+				add(px+r*math.Cos(a), py+r*math.Sin(a))
+			}
+		default:
+			// The miter distance is delta/cos(theta/2), being theta the turn angle.
+			k := 1 + r1x*r2x + r1y*r2y
+			if k > 0 && math.Sqrt(2/k) <= o.MiterLimit {
+				add(px+(r1x+r2x)*delta/k, py+(r1y+r2y)*delta/k)
+			} else {
+				add(px+r1x*delta, py+r1y*delta)
+				add(px+r2x*delta, py+r2y*delta)
+			}
+		}
+	}
+	if len(raw) > 1 && raw[0] == raw[len(raw)-1] {
+		raw = raw[:len(raw)-1]
+	}
+	return raw
+}
+
+func unit(p1, p2 go3mf.Point2D) (float64, float64) {
+	dx, dy := float64(p2.X())-float64(p1.X()), float64(p2.Y())-float64(p1.Y())
+	l := math.Hypot(dx, dy)
+	return dx / l, dy / l
+}
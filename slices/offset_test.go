@@ -0,0 +1,61 @@
+package slices
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+func TestOffsetter_Offset(t *testing.T) {
+	frame := sliceOf(rect(0, 0, 4, 4), rect(1, 1, 3, 3))
+	round := 4 + 8 + DefaultArcSegments/2*math.Sin(2*math.Pi/DefaultArcSegments)
+	tests := []struct {
+		name     string
+		join     JoinType
+		s        *Slice
+		delta    float32
+		areas    []float64
+		vertices int
+	}{
+		{"zero", JoinMiter, sliceOf(rect(0, 0, 2, 2)), 0, []float64{4}, 4},
+		{"grow", JoinMiter, sliceOf(rect(0, 0, 2, 2)), 1, []float64{16}, 4},
+		{"shrink", JoinMiter, sliceOf(rect(0, 0, 2, 2)), -0.5, []float64{1}, 4},
+		{"vanish", JoinMiter, sliceOf(rect(0, 0, 2, 2)), -1.5, nil, 0},
+		{"clockwise", JoinMiter, sliceOf([]go3mf.Point2D{{0, 0}, {0, 2}, {2, 2}, {2, 0}}), 1, []float64{16}, 4},
+		{"holeGrow", JoinMiter, frame, 0.5, []float64{25, -1}, 8},
+		{"holeClose", JoinMiter, frame, 1.5, []float64{49}, 4},
+		{"holeShrink", JoinMiter, frame, -0.25, []float64{12.25, -6.25}, 8},
+		{"merge", JoinMiter, sliceOf(rect(0, 0, 1, 1), rect(1.5, 0, 2.5, 1)), 0.5, []float64{7}, 4},
+		{"round", JoinRound, sliceOf(rect(0, 0, 2, 2)), 1, []float64{round}, 4 * (DefaultArcSegments/4 + 1)},
+		{"roundShrink", JoinRound, sliceOf(rect(0, 0, 2, 2)), -0.5, []float64{1}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewOffsetter(tt.join).Offset(tt.s, tt.delta)
+			deep.FloatPrecision = 4
+			if diff := deep.Equal(areas(got), tt.areas); diff != nil {
+				t.Errorf("Offsetter.Offset() areas = %v", diff)
+			}
+			if len(got.Vertices) != tt.vertices {
+				t.Errorf("Offsetter.Offset() vertices = %d, want %d", len(got.Vertices), tt.vertices)
+			}
+			if err := got.ValidateGeometry(); err != nil {
+				t.Errorf("Offsetter.Offset() ValidateGeometry() = %v", err)
+			}
+		})
+	}
+}
+
+func TestOffsetter_Offset_miterLimit(t *testing.T) {
+	triangle := sliceOf([]go3mf.Point2D{{0, 0}, {10, 0}, {0, 1}})
+	o := NewOffsetter(JoinMiter)
+	if got := o.Offset(triangle, 0.1); len(got.Vertices) != 4 {
+		t.Errorf("Offsetter.Offset() vertices = %d, want 4", len(got.Vertices))
+	}
+	o.MiterLimit = 100
+	if got := o.Offset(triangle, 0.1); len(got.Vertices) != 3 {
+		t.Errorf("Offsetter.Offset() vertices = %d, want 3", len(got.Vertices))
+	}
+}
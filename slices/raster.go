@@ -0,0 +1,210 @@
+package slices
+
+import (
+	"image"
+	"math"
+	"sort"
+
+	"github.com/qmuntal/go3mf"
+	specerr "github.com/qmuntal/go3mf/errors"
+)
+
+// FillRule defines how the interior of the slice polygons is determined.
+type FillRule uint8
+
+// Supported fill rules.
+const (
+	// FillEvenOdd fills the regions crossed an odd number of times.
+	FillEvenOdd FillRule = iota
+	// FillNonZero fills the regions with a non-zero winding number.
+	FillNonZero
+)
+
+// A Layer is a rasterized slice.
+type Layer struct {
+	TopZ  float32
+	Image *image.Gray
+}
+
+// A Rasterizer renders slices into greyscale images of Width x Height pixels.
+//
+// Each pixel is a square of PixelSize model units and Origin
+// is the model XY coordinate of the bottom-left corner of the image,
+// so the first image row contains the greatest Y coordinates.
+// Open polygons are implicitly closed.
+//
+// Samples defines the number of samples per pixel side used for anti-aliasing,
+// in which case the pixel value is proportional to the covered area.
+// Values lower than 2 disable anti-aliasing and produce monochrome images
+// where the filled pixels are white.
+type Rasterizer struct {
+	Width     int
+	Height    int
+	PixelSize float32
+	Origin    go3mf.Point2D
+	FillRule  FillRule
+	Samples   int
+}
+
+// NewRasterizer returns a new monochrome even-odd Rasterizer.
+func NewRasterizer(width, height int, pixelSize float32) *Rasterizer {
+	return &Rasterizer{
+		Width:     width,
+		Height:    height,
+		PixelSize: pixelSize,
+	}
+}
+
+// RasterizeSlice renders s applying transform in the XY plane.
+// A zero transform is treated as the identity.
+func (r *Rasterizer) RasterizeSlice(s *Slice, transform go3mf.Matrix) *image.Gray {
+	if transform == (go3mf.Matrix{}) {
+		transform = go3mf.Identity()
+	}
+	img := image.NewGray(image.Rect(0, 0, r.Width, r.Height))
+	edges := r.edges(s, transform)
+	if len(edges) == 0 {
+		return img
+	}
+	samples := r.Samples
+	if samples < 2 {
+		samples = 1
+	}
+	coverage := make([]int, r.Width)
+	var crossings []crossing
+	for y := 0; y < r.Height; y++ {
+		for i := range coverage {
+			coverage[i] = 0
+		}
+		for k := 0; k < samples; k++ {
+			sy := float64(y) + (float64(k)+0.5)/float64(samples)
+			crossings = crossings[:0]
+			for _, e := range edges {
+				if (e.y0 <= sy && sy < e.y1) || (e.y1 <= sy && sy < e.y0) {
+					x := e.x0 + (sy-e.y0)*(e.x1-e.x0)/(e.y1-e.y0)
+					crossings = append(crossings, crossing{x: x, winding: e.winding})
+				}
+			}
+			sort.Slice(crossings, func(i, j int) bool { return crossings[i].x < crossings[j].x })
+			r.fillSpans(coverage, crossings, samples)
+		}
+		total := samples * samples
+		row := img.Pix[y*img.Stride : y*img.Stride+r.Width]
+		for x, c := range coverage {
+			row[x] = uint8((c*255 + total/2) / total)
+		}
+	}
+	return img
+}
+
+// RasterizeStack renders the slices of st, which is defined in the model part path,
+// applying transform in the XY plane.
+// The slice references are followed into the child model parts.
+func (r *Rasterizer) RasterizeStack(m *go3mf.Model, path string, st *SliceStack, transform go3mf.Matrix) ([]Layer, error) {
+	src, err := st.ResolveSlices(m, path)
+	if err != nil {
+		return nil, err
+	}
+	layers := make([]Layer, len(src))
+	for i, s := range src {
+		layers[i] = Layer{TopZ: s.TopZ, Image: r.RasterizeSlice(s, transform)}
+	}
+	return layers, nil
+}
+
+// RasterizeItem renders the slice stack of the object referenced by item,
+// applying the item transform in the XY plane.
+// It returns no layers if the object does not reference a slice stack.
+func (r *Rasterizer) RasterizeItem(m *go3mf.Model, item *go3mf.Item) ([]Layer, error) {
+	path := item.ObjectPath()
+	obj, ok := m.FindObject(path, item.ObjectID)
+	if !ok {
+		return nil, specerr.Wrap(specerr.ErrMissingResource, item)
+	}
+	st, err := ObjectSliceStack(m, path, obj)
+	if err != nil || st == nil {
+		return nil, err
+	}
+	return r.RasterizeStack(m, path, st, item.Transform)
+}
+
+type rasterEdge struct {
+	x0, y0, x1, y1 float64
+	winding        int
+}
+
+type crossing struct {
+	x       float64
+	winding int
+}
+
+// edges returns the non horizontal polygon edges in pixel coordinates.
+func (r *Rasterizer) edges(s *Slice, transform go3mf.Matrix) []rasterEdge {
+	if r.PixelSize <= 0 {
+		return nil
+	}
+	toPixel := func(i uint32) (float64, float64, bool) {
+		if int(i) >= len(s.Vertices) {
+			return 0, 0, false
+		}
+		p := transform.Mul2D(s.Vertices[i])
+		x := float64(p.X()-r.Origin.X()) / float64(r.PixelSize)
+		y := float64(r.Height) - float64(p.Y()-r.Origin.Y())/float64(r.PixelSize)
+		return x, y, true
+	}
+	var edges []rasterEdge
+	addEdge := func(v1, v2 uint32) {
+		x0, y0, ok0 := toPixel(v1)
+		x1, y1, ok1 := toPixel(v2)
+		if !ok0 || !ok1 || y0 == y1 {
+			return
+		}
+		winding := 1
+		if y1 < y0 {
+			winding = -1
+		}
+		edges = append(edges, rasterEdge{x0: x0, y0: y0, x1: x1, y1: y1, winding: winding})
+	}
+	for _, p := range s.Polygons {
+		if len(p.Segments) == 0 {
+			continue
+		}
+		prev := p.StartV
+		for _, seg := range p.Segments {
+			addEdge(prev, seg.V2)
+			prev = seg.V2
+		}
+		if prev != p.StartV {
+			addEdge(prev, p.StartV)
+		}
+	}
+	return edges
+}
+
+// fillSpans increments the coverage of the pixels whose samples
+// are inside the spans defined by the sorted crossings.
+func (r *Rasterizer) fillSpans(coverage []int, crossings []crossing, samples int) {
+	var winding int
+	for i := 0; i < len(crossings)-1; i++ {
+		if r.FillRule == FillNonZero {
+			winding += crossings[i].winding
+			if winding == 0 {
+				continue
+			}
+		} else if i%2 == 1 {
+			continue
+		}
+		// Sample centers are at (n + 0.5) / samples.
+		first := int(math.Ceil(crossings[i].x*float64(samples) - 0.5))
+		last := int(math.Ceil(crossings[i+1].x*float64(samples)-0.5)) - 1
+		if first < 0 {
+			first = 0
+		}
+		if max := len(coverage)*samples - 1; last > max {
+			last = max
+		}
+		for n := first; n <= last; n++ {
+			coverage[n/samples]++
+		}
+	}
+}
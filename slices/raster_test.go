@@ -0,0 +1,146 @@
+package slices
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	specerr "github.com/qmuntal/go3mf/errors"
+)
+
+func square(min, max float32, ccw bool) ([]go3mf.Point2D, Polygon) {
+	vertices := []go3mf.Point2D{{min, min}, {max, min}, {max, max}, {min, max}}
+	p := Polygon{StartV: 0, Segments: []Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}}
+	if !ccw {
+		p.Segments = []Segment{{V2: 3}, {V2: 2}, {V2: 1}, {V2: 0}}
+	}
+	return vertices, p
+}
+
+func nested(innerCCW bool) *Slice {
+	outer, p1 := square(0, 4, true)
+	inner, p2 := square(1, 3, innerCCW)
+	for i := range p2.Segments {
+		p2.Segments[i].V2 += 4
+	}
+	p2.StartV += 4
+	return &Slice{Vertices: append(outer, inner...), Polygons: []Polygon{p1, p2}}
+}
+
+func TestRasterizer_RasterizeSlice(t *testing.T) {
+	vertices, p := square(1, 3, true)
+	sq := &Slice{Vertices: vertices, Polygons: []Polygon{p}}
+	open := &Slice{Vertices: vertices, Polygons: []Polygon{{StartV: 0, Segments: []Segment{{V2: 1}, {V2: 2}, {V2: 3}}}}}
+	half := &Slice{Vertices: []go3mf.Point2D{{0, 0}, {1, 0}, {1, 4}, {0, 4}}, Polygons: []Polygon{p}}
+	tests := []struct {
+		name      string
+		r         *Rasterizer
+		s         *Slice
+		transform go3mf.Matrix
+		want      []uint8
+	}{
+		{"empty", NewRasterizer(4, 4, 1), new(Slice), go3mf.Matrix{}, make([]uint8, 16)},
+		{"square", NewRasterizer(4, 4, 1), sq, go3mf.Matrix{}, []uint8{
+			0, 0, 0, 0,
+			0, 255, 255, 0,
+			0, 255, 255, 0,
+			0, 0, 0, 0,
+		}},
+		{"open", NewRasterizer(4, 4, 1), open, go3mf.Matrix{}, []uint8{
+			0, 0, 0, 0,
+			0, 255, 255, 0,
+			0, 255, 255, 0,
+			0, 0, 0, 0,
+		}},
+		{"transform", NewRasterizer(4, 4, 1), sq, go3mf.Identity().Translate(1, -1, 0), []uint8{
+			0, 0, 0, 0,
+			0, 0, 0, 0,
+			0, 0, 255, 255,
+			0, 0, 255, 255,
+		}},
+		{"origin", &Rasterizer{Width: 2, Height: 2, PixelSize: 2, Origin: go3mf.Point2D{-1, 1}}, sq, go3mf.Matrix{}, []uint8{
+			0, 0,
+			0, 255,
+		}},
+		{"evenodd", NewRasterizer(4, 4, 1), nested(true), go3mf.Matrix{}, []uint8{
+			255, 255, 255, 255,
+			255, 0, 0, 255,
+			255, 0, 0, 255,
+			255, 255, 255, 255,
+		}},
+		{"nonzero", &Rasterizer{Width: 4, Height: 4, PixelSize: 1, FillRule: FillNonZero}, nested(true), go3mf.Matrix{}, []uint8{
+			255, 255, 255, 255,
+			255, 255, 255, 255,
+			255, 255, 255, 255,
+			255, 255, 255, 255,
+		}},
+		{"nonzero-hole", &Rasterizer{Width: 4, Height: 4, PixelSize: 1, FillRule: FillNonZero}, nested(false), go3mf.Matrix{}, []uint8{
+			255, 255, 255, 255,
+			255, 0, 0, 255,
+			255, 0, 0, 255,
+			255, 255, 255, 255,
+		}},
+		{"antialiasing", &Rasterizer{Width: 2, Height: 1, PixelSize: 4, Samples: 4}, half, go3mf.Matrix{}, []uint8{
+			64, 0,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.r.RasterizeSlice(tt.s, tt.transform)
+			if diff := deep.Equal(got.Pix, tt.want); diff != nil {
+				t.Errorf("Rasterizer.RasterizeSlice() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestRasterizer_RasterizeItem(t *testing.T) {
+	vertices, p := square(0, 1, true)
+	s := &Slice{TopZ: 2, Vertices: vertices, Polygons: []Polygon{p}}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{
+				&SliceStack{ID: 1, Slices: []*Slice{{TopZ: 1, Vertices: vertices, Polygons: []Polygon{p}}}},
+				&SliceStack{ID: 2, Refs: []SliceRef{{SliceStackID: 1}, {SliceStackID: 3, Path: "/3D/other.model"}}},
+				&SliceStack{ID: 6, Refs: []SliceRef{{SliceStackID: 10}}},
+			},
+			Objects: []*go3mf.Object{
+				{ID: 3, AnyAttr: go3mf.AttrMarshalers{&SliceStackInfo{SliceStackID: 2}}},
+				{ID: 4},
+				{ID: 5, AnyAttr: go3mf.AttrMarshalers{&SliceStackInfo{SliceStackID: 10}}},
+				{ID: 7, AnyAttr: go3mf.AttrMarshalers{&SliceStackInfo{SliceStackID: 6}}},
+			},
+		},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/other.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&SliceStack{ID: 3, Slices: []*Slice{s}},
+			}}},
+		},
+	}
+	r := NewRasterizer(2, 2, 1)
+	got, err := r.RasterizeItem(m, &go3mf.Item{ObjectID: 3, Transform: go3mf.Identity().Translate(1, 0, 0)})
+	if err != nil {
+		t.Fatalf("Rasterizer.RasterizeItem() error = %v", err)
+	}
+	want := []uint8{0, 0, 0, 255}
+	if len(got) != 2 {
+		t.Fatalf("Rasterizer.RasterizeItem() layers = %d, want 2", len(got))
+	}
+	for i, topZ := range []float32{1, 2} {
+		if got[i].TopZ != topZ {
+			t.Errorf("Rasterizer.RasterizeItem() layer %d TopZ = %v, want %v", i, got[i].TopZ, topZ)
+		}
+		if diff := deep.Equal(got[i].Image.Pix, want); diff != nil {
+			t.Errorf("Rasterizer.RasterizeItem() layer %d = %v", i, diff)
+		}
+	}
+	if got, err := r.RasterizeItem(m, &go3mf.Item{ObjectID: 4}); err != nil || got != nil {
+		t.Errorf("Rasterizer.RasterizeItem() object 4 = %v, %v, want no layers", got, err)
+	}
+	for _, id := range []uint32{5, 7, 100} {
+		if _, err := r.RasterizeItem(m, &go3mf.Item{ObjectID: id}); !errors.Is(err, specerr.ErrMissingResource) {
+			t.Errorf("Rasterizer.RasterizeItem() object %d error = %v, want %v", id, err, specerr.ErrMissingResource)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package slices
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/qmuntal/go3mf"
+	specerr "github.com/qmuntal/go3mf/errors"
+)
+
+// ErrLayerHeight is returned when resampling with a non positive layer height.
+var ErrLayerHeight = errors.New("slices: layer height must be positive")
+
+// ResampleStrategy defines how the resampled slices are computed from the original ones.
+type ResampleStrategy uint8
+
+// Supported resample strategies.
+const (
+	// ResampleNearest copies the original slice whose layer contains
+	// the middle of the new layer.
+	ResampleNearest ResampleStrategy = iota
+	// ResampleInterpolate blends the contours of the original slices
+	// whose layer middles are around the middle of the new layer.
+	// It falls back to ResampleNearest when the contours of both slices
+	// cannot be matched one to one.
+	ResampleInterpolate
+)
+
+// A Resampler slices a slice stack again with a different layer height.
+type Resampler struct {
+	LayerHeight float32
+	Strategy    ResampleStrategy
+}
+
+// NewResampler returns a new Resampler.
+func NewResampler(layerHeight float32, strategy ResampleStrategy) *Resampler {
+	return &Resampler{
+		LayerHeight: layerHeight,
+		Strategy:    strategy,
+	}
+}
+
+// Resample returns a stack with the ID and BottomZ of st and a slice every LayerHeight,
+// the last one being the first that reaches the top of st.
+// st is defined in the model part path and its slice references are followed
+// into the child model parts, so the result contains all the slices and no references.
+//
+// Each original slice is considered to span from the TopZ of the previous one,
+// or the BottomZ of st for the first one, to its own TopZ.
+// The slices copied with ResampleNearest keep their segment properties,
+// while the interpolated ones have compact vertices and no properties.
+func (r *Resampler) Resample(m *go3mf.Model, path string, st *SliceStack) (*SliceStack, error) {
+	if r.LayerHeight <= 0 {
+		return nil, ErrLayerHeight
+	}
+	src, err := stackSlices(m, path, st)
+	if err != nil {
+		return nil, err
+	}
+	result := &SliceStack{ID: st.ID, BottomZ: st.BottomZ}
+	if len(src) == 0 {
+		return result, nil
+	}
+	bottom, height := float64(st.BottomZ), float64(r.LayerHeight)
+	centers := make([]float64, len(src))
+	prev := bottom
+	for i, s := range src {
+		centers[i] = (prev + float64(s.TopZ)) / 2
+		prev = float64(s.TopZ)
+	}
+	n := int(math.Ceil((prev-bottom)/height - 1e-4))
+	for i := 1; i <= n; i++ {
+		topZ := float32(bottom + float64(i)*height)
+		mid := bottom + (float64(i)-0.5)*height
+		var s *Slice
+		if r.Strategy == ResampleInterpolate {
+			s = interpolateAt(src, centers, mid, topZ)
+		}
+		if s == nil {
+			s = copySlice(src[nearestSlice(src, mid)], topZ)
+		}
+		result.Slices = append(result.Slices, s)
+	}
+	return result, nil
+}
+
+// stackSlices returns the slices of st and of the stacks it references,
+// checking that their TopZ is increasing.
+func stackSlices(m *go3mf.Model, path string, st *SliceStack) ([]*Slice, error) {
+	src, err := st.ResolveSlices(m, path)
+	if err != nil {
+		return nil, err
+	}
+	for j, s := range src {
+		if s.TopZ < st.BottomZ {
+			return nil, specerr.WrapIndex(specerr.ErrSliceSmallTopZ, s, j)
+		}
+		if j > 0 && s.TopZ <= src[j-1].TopZ {
+			return nil, specerr.WrapIndex(specerr.ErrSliceNoMonotonic, s, j)
+		}
+	}
+	return src, nil
+}
+
+// nearestSlice returns the index of the slice whose layer contains z.
+func nearestSlice(src []*Slice, z float64) int {
+	i := sort.Search(len(src), func(i int) bool { return float64(src[i].TopZ) >= z })
+	if i == len(src) {
+		i--
+	}
+	return i
+}
+
+func copySlice(s *Slice, topZ float32) *Slice {
+	c := &Slice{
+		TopZ:     topZ,
+		Vertices: append([]go3mf.Point2D(nil), s.Vertices...),
+		Polygons: make([]Polygon, len(s.Polygons)),
+	}
+	for i, p := range s.Polygons {
+		c.Polygons[i] = Polygon{StartV: p.StartV, Segments: append([]Segment(nil), p.Segments...)}
+	}
+	return c
+}
+
+// interpolateAt returns the blend of the slices whose layer middles surround z,
+// or nil if z is out of them or the contours do not match.
+func interpolateAt(src []*Slice, centers []float64, z float64, topZ float32) *Slice {
+	k := sort.SearchFloat64s(centers, z)
+	if k == 0 || k == len(centers) || centers[k] == z {
+		return nil
+	}
+	t := (z - centers[k-1]) / (centers[k] - centers[k-1])
+	return interpolate(src[k-1], src[k], t, topZ)
+}
+
+// A profile is a polygon prepared to be interpolated.
+type profile struct {
+	points []go3mf.Point2D
+	closed bool
+	area   float64
+	center [2]float64
+}
+
+func profiles(s *Slice) []profile {
+	var result []profile
+	for _, p := range s.Polygons {
+		points, ok := s.PolygonPoints(p)
+		if !ok {
+			continue
+		}
+		pr := profile{points: points}
+		if len(points) > 2 && points[0] == points[len(points)-1] {
+			pr.closed = true
+			pr.points = points[:len(points)-1]
+		}
+		if len(pr.points) < 2 {
+			continue
+		}
+		for i, v1 := range pr.points {
+			v2 := pr.points[(i+1)%len(pr.points)]
+			pr.area += float64(v1.X())*float64(v2.Y()) - float64(v2.X())*float64(v1.Y())
+			pr.center[0] += float64(v1.X()) / float64(len(pr.points))
+			pr.center[1] += float64(v1.Y()) / float64(len(pr.points))
+		}
+		result = append(result, pr)
+	}
+	return result
+}
+
+// interpolate returns the slice at t between a and b,
+// matching each polygon of a with the closest polygon of b
+// that has the same closedness and orientation.
+func interpolate(a, b *Slice, t float64, topZ float32) *Slice {
+	pa, pb := profiles(a), profiles(b)
+	if len(pa) != len(pb) {
+		return nil
+	}
+	used := make([]bool, len(pb))
+	builder := newSliceBuilder(topZ)
+	for _, p1 := range pa {
+		best, bestDist := -1, math.Inf(1)
+		for j, p2 := range pb {
+			if used[j] || p1.closed != p2.closed || (p1.closed && (p1.area > 0) != (p2.area > 0)) {
+				continue
+			}
+			dx, dy := p2.center[0]-p1.center[0], p2.center[1]-p1.center[1]
+			if d := dx*dx + dy*dy; d < bestDist {
+				best, bestDist = j, d
+			}
+		}
+		if best < 0 {
+			return nil
+		}
+		used[best] = true
+		if points := blend(p1, pb[best], t); len(points) > 1 {
+			builder.addPolygon(points)
+		}
+	}
+	return builder.s
+}
+
+// blend returns the points at t between p1 and p2, which are parametrized by their
+// normalized arc length so that the vertices of both contours are kept.
+// The start of closed contours is aligned to the closest vertices and open ones
+// are reversed if their ends are closer that way.
+func blend(p1, p2 profile, t float64) []go3mf.Point2D {
+	points2 := append([]go3mf.Point2D(nil), p2.points...)
+	if p1.closed {
+		start, best := 0, math.Inf(1)
+		for i, v := range points2 {
+			if d := distance2(p1.points[0], v); d < best {
+				start, best = i, d
+			}
+		}
+		points2 = append(points2[start:], points2[:start]...)
+		points2 = append(points2, points2[0])
+		p1.points = append(append([]go3mf.Point2D(nil), p1.points...), p1.points[0])
+	} else if distance2(p1.points[0], points2[0]) > distance2(p1.points[0], points2[len(points2)-1]) {
+		for i, j := 0, len(points2)-1; i < j; i, j = i+1, j-1 {
+			points2[i], points2[j] = points2[j], points2[i]
+		}
+	}
+	params1, params2 := arcParams(p1.points), arcParams(points2)
+	params := append(append([]float64(nil), params1...), params2...)
+	sort.Float64s(params)
+	var result []go3mf.Point2D
+	for i, s := range params {
+		if i > 0 && s-params[i-1] < 1e-9 {
+			continue
+		}
+		x1, y1 := pointAt(p1.points, params1, s)
+		x2, y2 := pointAt(points2, params2, s)
+		v := go3mf.Point2D{float32(x1 + (x2-x1)*t), float32(y1 + (y2-y1)*t)}
+		if len(result) == 0 || result[len(result)-1] != v {
+			result = append(result, v)
+		}
+	}
+	if p1.closed {
+		if len(result) > 1 && result[0] == result[len(result)-1] {
+			result = result[:len(result)-1]
+		}
+		if result = removeCollinear(result); len(result) < 3 {
+			return nil
+		}
+		result = append(result, result[0])
+	}
+	return result
+}
+
+// arcParams returns the normalized arc length at each point.
+func arcParams(points []go3mf.Point2D) []float64 {
+	params := make([]float64, len(points))
+	for i := 1; i < len(points); i++ {
+		params[i] = params[i-1] + math.Sqrt(distance2(points[i-1], points[i]))
+	}
+	if total := params[len(params)-1]; total > 0 {
+		for i := range params {
+			params[i] /= total
+		}
+	}
+	return params
+}
+
+// pointAt returns the point at the normalized arc length s.
+func pointAt(points []go3mf.Point2D, params []float64, s float64) (float64, float64) {
+	i := sort.SearchFloat64s(params, s)
+	if i == 0 {
+		return float64(points[0].X()), float64(points[0].Y())
+	}
+	if i == len(points) {
+		i--
+	}
+	p1, p2 := points[i-1], points[i]
+	var f float64
+	if d := params[i] - params[i-1]; d > 0 {
+		f = math.Min(1, (s-params[i-1])/d)
+	}
+	return float64(p1.X()) + (float64(p2.X())-float64(p1.X()))*f,
+		float64(p1.Y()) + (float64(p2.Y())-float64(p1.Y()))*f
+}
@@ -0,0 +1,137 @@
+package slices
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	specerr "github.com/qmuntal/go3mf/errors"
+)
+
+func centered(topZ, half float32) *Slice {
+	s := sliceOf(rect(-half, -half, half, half))
+	s.TopZ = topZ
+	return s
+}
+
+func TestResampler_Resample(t *testing.T) {
+	islands := func(topZ float32) *Slice {
+		s := sliceOf(rect(-2, -2, 2, 2), rect(3, 3, 4, 4))
+		s.TopZ = topZ
+		return s
+	}
+	withProperty := centered(1, 1)
+	withProperty.Polygons[0].Segments[0].PID = 2
+	flat := &SliceStack{ID: 1, Slices: []*Slice{withProperty, centered(2, 2)}}
+	split := &go3mf.Model{
+		Resources: go3mf.Resources{Assets: []go3mf.Asset{
+			&SliceStack{ID: 1, Refs: []SliceRef{{SliceStackID: 3, Path: "/3D/a.model"}, {SliceStackID: 4, Path: "/3D/b.model"}}},
+		}},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/a.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{&SliceStack{ID: 3, Slices: []*Slice{withProperty}}}}},
+			"/3D/b.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{&SliceStack{ID: 4, Slices: []*Slice{centered(2, 2)}}}}},
+		},
+	}
+	nearest := &SliceStack{ID: 1, Slices: []*Slice{
+		copySlice(withProperty, 0.5), copySlice(withProperty, 1), centered(1.5, 2), centered(2, 2),
+	}}
+	interpolated := &SliceStack{ID: 1, Slices: []*Slice{
+		copySlice(withProperty, 0.5), centered(1, 1.25), centered(1.5, 1.75), centered(2, 2),
+	}}
+	tests := []struct {
+		name     string
+		m        *go3mf.Model
+		st       *SliceStack
+		strategy ResampleStrategy
+		want     *SliceStack
+	}{
+		{"empty", nil, &SliceStack{ID: 1, BottomZ: 1}, ResampleNearest, &SliceStack{ID: 1, BottomZ: 1}},
+		{"nearest", nil, flat, ResampleNearest, nearest},
+		{"nearestRefs", split, split.Resources.Assets[0].(*SliceStack), ResampleNearest, nearest},
+		{"interpolate", nil, flat, ResampleInterpolate, interpolated},
+		{"interpolateRefs", split, split.Resources.Assets[0].(*SliceStack), ResampleInterpolate, interpolated},
+		{"fallback", nil, &SliceStack{ID: 1, Slices: []*Slice{centered(1, 1), islands(2)}}, ResampleInterpolate, &SliceStack{ID: 1, Slices: []*Slice{
+			centered(0.5, 1), centered(1, 1), islands(1.5), islands(2),
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewResampler(0.5, tt.strategy).Resample(tt.m, "", tt.st)
+			if err != nil {
+				t.Fatalf("Resampler.Resample() error = %v", err)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("Resampler.Resample() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestResampler_Resample_layers(t *testing.T) {
+	st := &SliceStack{BottomZ: 0.2, Slices: []*Slice{centered(0.5, 1), centered(0.8, 1), centered(1.1, 1)}}
+	got, err := NewResampler(0.4, ResampleNearest).Resample(nil, "", st)
+	if err != nil {
+		t.Fatalf("Resampler.Resample() error = %v", err)
+	}
+	want := []float32{0.6, 1, 1.4}
+	if len(got.Slices) != len(want) {
+		t.Fatalf("Resampler.Resample() slices = %d, want %d", len(got.Slices), len(want))
+	}
+	for i, s := range got.Slices {
+		if s.TopZ != want[i] {
+			t.Errorf("Resampler.Resample() slice %d TopZ = %v, want %v", i, s.TopZ, want[i])
+		}
+	}
+}
+
+func TestResampler_Resample_interpolateShapes(t *testing.T) {
+	triangle := &Slice{TopZ: 2, Vertices: []go3mf.Point2D{{-2, -2}, {2, -2}, {0, 2}}, Polygons: []Polygon{
+		{StartV: 0, Segments: []Segment{{V2: 1}, {V2: 2}, {V2: 0}}},
+	}}
+	line1 := &Slice{TopZ: 1, Vertices: []go3mf.Point2D{{0, 0}, {2, 0}}, Polygons: []Polygon{{StartV: 0, Segments: []Segment{{V2: 1}}}}}
+	line2 := &Slice{TopZ: 2, Vertices: []go3mf.Point2D{{2, 2}, {0, 2}}, Polygons: []Polygon{{StartV: 0, Segments: []Segment{{V2: 1}}}}}
+	got, err := NewResampler(1, ResampleInterpolate).Resample(nil, "", &SliceStack{Slices: []*Slice{centered(1, 1), triangle, centered(3, 1)}})
+	if err != nil {
+		t.Fatalf("Resampler.Resample() error = %v", err)
+	}
+	for i, s := range got.Slices {
+		if err := s.ValidateGeometry(); err != nil {
+			t.Errorf("Resampler.Resample() slice %d ValidateGeometry() = %v", i, err)
+		}
+	}
+	got, err = NewResampler(0.5, ResampleInterpolate).Resample(nil, "", &SliceStack{Slices: []*Slice{line1, line2}})
+	if err != nil {
+		t.Fatalf("Resampler.Resample() error = %v", err)
+	}
+	want := &Slice{TopZ: 1, Vertices: []go3mf.Point2D{{0, 0.5}, {2, 0.5}}, Polygons: []Polygon{{StartV: 0, Segments: []Segment{{V2: 1}}}}}
+	if diff := deep.Equal(got.Slices[1], want); diff != nil {
+		t.Errorf("Resampler.Resample() = %v", diff)
+	}
+}
+
+func TestResampler_Resample_error(t *testing.T) {
+	m := &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+		&SliceStack{ID: 1, Refs: []SliceRef{{SliceStackID: 2}}},
+		&SliceStack{ID: 3, Refs: []SliceRef{{SliceStackID: 5}}},
+		&SliceStack{ID: 4, Slices: []*Slice{centered(1, 1)}},
+	}}}
+	tests := []struct {
+		name        string
+		layerHeight float32
+		st          *SliceStack
+		want        error
+	}{
+		{"layerHeight", 0, &SliceStack{}, ErrLayerHeight},
+		{"missingRef", 1, m.Resources.Assets[0].(*SliceStack), specerr.ErrMissingResource},
+		{"notMonotonic", 1, &SliceStack{Slices: []*Slice{centered(2, 1), centered(1, 1)}}, specerr.ErrSliceNoMonotonic},
+		{"smallTopZ", 1, &SliceStack{BottomZ: 2, Slices: []*Slice{centered(1, 1)}}, specerr.ErrSliceSmallTopZ},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewResampler(tt.layerHeight, ResampleNearest).Resample(m, "", tt.st); !errors.Is(err, tt.want) {
+				t.Errorf("Resampler.Resample() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
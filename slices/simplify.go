@@ -0,0 +1,89 @@
+package slices
+
+import (
+	"math"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// Simplify returns a copy of s whose polygons are simplified using the
+// Douglas–Peucker algorithm, removing the vertices that are closer than
+// tolerance to the simplified contour.
+//
+// Closed polygons with less than three remaining vertices and open polygons
+// with less than two are removed. The result has compact vertices
+// and segment properties are not preserved.
+// Simplification can make a valid slice self-intersect when the tolerance
+// is of the order of the distance between contours.
+func Simplify(s *Slice, tolerance float32) *Slice {
+	b := newSliceBuilder(s.TopZ)
+	for _, p := range s.Polygons {
+		points, ok := s.PolygonPoints(p)
+		if !ok {
+			continue
+		}
+		closed := len(points) > 2 && points[0] == points[len(points)-1]
+		if closed {
+			points = simplifyClosed(points[:len(points)-1], float64(tolerance))
+			if len(points) < 3 {
+				continue
+			}
+			points = append(points, points[0])
+		} else {
+			points = douglasPeucker(points, float64(tolerance))
+			if len(points) < 2 {
+				continue
+			}
+		}
+		b.addPolygon(points)
+	}
+	return b.s
+}
+
+// simplifyClosed simplifies a loop by splitting it at the vertex
+// that is farthest from the first one.
+func simplifyClosed(loop []go3mf.Point2D, tolerance float64) []go3mf.Point2D {
+	far, best := 0, -1.0
+	for i, v := range loop {
+		if d := distance2(loop[0], v); d > best {
+			far, best = i, d
+		}
+	}
+	if far == 0 {
+		return loop[:1]
+	}
+	first := douglasPeucker(loop[:far+1], tolerance)
+	second := douglasPeucker(append(append([]go3mf.Point2D(nil), loop[far:]...), loop[0]), tolerance)
+	return append(first, second[1:len(second)-1]...)
+}
+
+func douglasPeucker(points []go3mf.Point2D, tolerance float64) []go3mf.Point2D {
+	if len(points) < 3 {
+		return append([]go3mf.Point2D(nil), points...)
+	}
+	last := len(points) - 1
+	index, dmax := 0, -1.0
+	for i := 1; i < last; i++ {
+		if d := segmentDistance(points[i], points[0], points[last]); d > dmax {
+			index, dmax = i, d
+		}
+	}
+	if dmax <= tolerance {
+		return []go3mf.Point2D{points[0], points[last]}
+	}
+	first := douglasPeucker(points[:index+1], tolerance)
+	second := douglasPeucker(points[index:], tolerance)
+	return append(first[:len(first)-1], second...)
+}
+
+// segmentDistance returns the distance from p to the segment a-b.
+func segmentDistance(p, a, b go3mf.Point2D) float64 {
+	ax, ay := float64(a.X()), float64(a.Y())
+	dx, dy := float64(b.X())-ax, float64(b.Y())-ay
+	px, py := float64(p.X())-ax, float64(p.Y())-ay
+	if l2 := dx*dx + dy*dy; l2 > 0 {
+		t := math.Max(0, math.Min(1, (px*dx+py*dy)/l2))
+		px, py = px-t*dx, py-t*dy
+	}
+	return math.Hypot(px, py)
+}
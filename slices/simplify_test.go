@@ -0,0 +1,36 @@
+package slices
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+func TestSimplify(t *testing.T) {
+	s := &Slice{TopZ: 1, Vertices: []go3mf.Point2D{
+		{0, 0}, {1, 0.01}, {2, 0}, {2, 1}, {1.99, 2}, {0, 2}, {0, 2}, {0, 1},
+		{5, 5}, {6, 5.02}, {7, 5}, {8, 5.5},
+		{10, 10}, {10.01, 10}, {10, 10.01},
+		{20, 20}, {20.01, 20},
+	}, Polygons: []Polygon{
+		{StartV: 0, Segments: []Segment{{V2: 1, PID: 1}, {V2: 2}, {V2: 3}, {V2: 4}, {V2: 5}, {V2: 6}, {V2: 7}, {V2: 0}}},
+		{StartV: 8, Segments: []Segment{{V2: 9}, {V2: 10}, {V2: 11}}},
+		{StartV: 12, Segments: []Segment{{V2: 13}, {V2: 14}, {V2: 12}}},
+		{StartV: 15, Segments: []Segment{{V2: 16}}},
+		{StartV: 20, Segments: []Segment{{V2: 0}}},
+	}}
+	want := &Slice{TopZ: 1, Vertices: []go3mf.Point2D{
+		{0, 0}, {2, 0}, {1.99, 2}, {0, 2},
+		{5, 5}, {7, 5}, {8, 5.5},
+		{20, 20}, {20.01, 20},
+	}, Polygons: []Polygon{
+		{StartV: 0, Segments: []Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}},
+		{StartV: 4, Segments: []Segment{{V2: 5}, {V2: 6}}},
+		{StartV: 7, Segments: []Segment{{V2: 8}}},
+	}}
+	got := Simplify(s, 0.1)
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Simplify() = %v", diff)
+	}
+}
@@ -2,6 +2,7 @@ package slices
 
 import (
 	"github.com/qmuntal/go3mf"
+	specerr "github.com/qmuntal/go3mf/errors"
 )
 
 // Namespace is the canonical name of this extension.
@@ -49,6 +50,25 @@ type Slice struct {
 	Polygons []Polygon
 }
 
+// PolygonPoints returns the points of p without consecutive duplicates.
+// Closed polygons repeat the first point at the end.
+// It returns false if p references missing vertices.
+func (s *Slice) PolygonPoints(p Polygon) ([]go3mf.Point2D, bool) {
+	if int(p.StartV) >= len(s.Vertices) {
+		return nil, false
+	}
+	points := []go3mf.Point2D{s.Vertices[p.StartV]}
+	for _, seg := range p.Segments {
+		if int(seg.V2) >= len(s.Vertices) {
+			return nil, false
+		}
+		if v := s.Vertices[seg.V2]; v != points[len(points)-1] {
+			points = append(points, v)
+		}
+	}
+	return points, true
+}
+
 // MeshResolution defines the resolutions for a slice.
 type MeshResolution uint8
 
@@ -93,6 +113,67 @@ func (s *SliceStack) Identify() uint32 {
 	return s.ID
 }
 
+// ResolveRefs returns the slice stacks referenced by st, which is defined in the model part path,
+// and the model part path where each of them is defined.
+// m can be nil if st does not contain references.
+func (s *SliceStack) ResolveRefs(m *go3mf.Model, path string) ([]*SliceStack, []string, error) {
+	stacks := make([]*SliceStack, 0, len(s.Refs))
+	paths := make([]string, 0, len(s.Refs))
+	for i, ref := range s.Refs {
+		refPath := ref.Path
+		if refPath == "" {
+			refPath = path
+		}
+		if m == nil {
+			return nil, nil, specerr.WrapIndex(specerr.ErrMissingResource, ref, i)
+		}
+		a, ok := m.FindAsset(refPath, ref.SliceStackID)
+		if !ok {
+			return nil, nil, specerr.WrapIndex(specerr.ErrMissingResource, ref, i)
+		}
+		refStack, ok := a.(*SliceStack)
+		if !ok {
+			return nil, nil, specerr.WrapIndex(specerr.ErrNonSliceStack, ref, i)
+		}
+		stacks = append(stacks, refStack)
+		paths = append(paths, refPath)
+	}
+	return stacks, paths, nil
+}
+
+// ResolveSlices returns the slices of st, which is defined in the model part path,
+// followed by the slices of the stacks it references.
+// m can be nil if st does not contain references.
+func (s *SliceStack) ResolveSlices(m *go3mf.Model, path string) ([]*Slice, error) {
+	stacks, _, err := s.ResolveRefs(m, path)
+	if err != nil {
+		return nil, err
+	}
+	src := append([]*Slice(nil), s.Slices...)
+	for _, refStack := range stacks {
+		src = append(src, refStack.Slices...)
+	}
+	return src, nil
+}
+
+// ObjectSliceStack returns the slice stack referenced by obj, which is defined in the model part path,
+// or nil if obj does not reference a slice stack.
+func ObjectSliceStack(m *go3mf.Model, path string, obj *go3mf.Object) (*SliceStack, error) {
+	var sti *SliceStackInfo
+	if !obj.AnyAttr.Get(&sti) {
+		return nil, nil
+	}
+	a, ok := m.FindAsset(path, sti.SliceStackID)
+	if !ok {
+		return nil, specerr.Wrap(specerr.ErrMissingResource, obj)
+	}
+	st, ok := a.(*SliceStack)
+	if !ok {
+		return nil, specerr.Wrap(specerr.ErrNonSliceStack, obj)
+	}
+	return st, nil
+}
+
 // SliceStackInfo defines the attributes added to Object.
 type SliceStackInfo struct {
 	SliceStackID   uint32
@@ -1,10 +1,12 @@
 package slices
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
 	"github.com/qmuntal/go3mf"
+	specerr "github.com/qmuntal/go3mf/errors"
 )
 
 var _ go3mf.SpecDecoder = new(Spec)
@@ -69,3 +71,70 @@ func Test_newMeshResolution(t *testing.T) {
 		})
 	}
 }
+
+func TestSliceStack_ResolveRefs(t *testing.T) {
+	s1, s2 := &Slice{TopZ: 1}, &Slice{TopZ: 2}
+	child := &SliceStack{ID: 3, Slices: []*Slice{s2}}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{Assets: []go3mf.Asset{
+			&SliceStack{ID: 1, Slices: []*Slice{s1}},
+			&go3mf.BaseMaterials{ID: 2},
+		}},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/other.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{child}}},
+		},
+	}
+	st := &SliceStack{Refs: []SliceRef{{SliceStackID: 3, Path: "/3D/other.model"}}}
+	stacks, paths, err := st.ResolveRefs(m, "")
+	if err != nil {
+		t.Fatalf("SliceStack.ResolveRefs() error = %v", err)
+	}
+	if !reflect.DeepEqual(stacks, []*SliceStack{child}) || !reflect.DeepEqual(paths, []string{"/3D/other.model"}) {
+		t.Errorf("SliceStack.ResolveRefs() = %v, %v", stacks, paths)
+	}
+	got, err := (&SliceStack{Slices: []*Slice{s1}, Refs: st.Refs}).ResolveSlices(m, "")
+	if err != nil || !reflect.DeepEqual(got, []*Slice{s1, s2}) {
+		t.Errorf("SliceStack.ResolveSlices() = %v, %v", got, err)
+	}
+	tests := []struct {
+		name string
+		m    *go3mf.Model
+		ref  SliceRef
+		want error
+	}{
+		{"nilModel", nil, SliceRef{SliceStackID: 1}, specerr.ErrMissingResource},
+		{"missing", m, SliceRef{SliceStackID: 3}, specerr.ErrMissingResource},
+		{"nonSliceStack", m, SliceRef{SliceStackID: 2}, specerr.ErrNonSliceStack},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &SliceStack{Refs: []SliceRef{tt.ref}}
+			if _, _, err := st.ResolveRefs(tt.m, ""); !errors.Is(err, tt.want) {
+				t.Errorf("SliceStack.ResolveRefs() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlice_PolygonPoints(t *testing.T) {
+	s := &Slice{Vertices: []go3mf.Point2D{{0, 0}, {1, 0}, {1, 1}, {1, 0}}}
+	tests := []struct {
+		name string
+		p    Polygon
+		want []go3mf.Point2D
+		ok   bool
+	}{
+		{"closed", Polygon{StartV: 0, Segments: []Segment{{V2: 1}, {V2: 2}, {V2: 0}}}, []go3mf.Point2D{{0, 0}, {1, 0}, {1, 1}, {0, 0}}, true},
+		{"duplicated", Polygon{StartV: 0, Segments: []Segment{{V2: 1}, {V2: 3}, {V2: 2}}}, []go3mf.Point2D{{0, 0}, {1, 0}, {1, 1}}, true},
+		{"missingStart", Polygon{StartV: 4}, nil, false},
+		{"missingVertex", Polygon{StartV: 0, Segments: []Segment{{V2: 4}}}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := s.PolygonPoints(tt.p)
+			if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Slice.PolygonPoints() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package slices
+
+import (
+	"math"
+	"sort"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// LayerStats contains geometric measures of a layer.
+//
+// The slices are interpreted using the even-odd rule and open polygons
+// are implicitly closed, so overlapping polygons are measured once.
+type LayerStats struct {
+	BottomZ   float32
+	TopZ      float32
+	Area      float64
+	Perimeter float64       // Total length of the contours.
+	Islands   int           // Number of outer contours.
+	Min, Max  go3mf.Point2D // Bounding box, zero if the layer is empty.
+}
+
+// Stats contains geometric measures of sliced parts.
+//
+// Volume is an approximation of the material volume that adds up
+// the area of each layer multiplied by its height,
+// MaxArea is the area of the largest cross-section and
+// the layer count is the length of Layers.
+type Stats struct {
+	Layers  []LayerStats
+	Volume  float64
+	MaxArea float64
+}
+
+func (s *Stats) add(l LayerStats) {
+	s.Layers = append(s.Layers, l)
+	s.Volume += l.Area * (float64(l.TopZ) - float64(l.BottomZ))
+	if l.Area > s.MaxArea {
+		s.MaxArea = l.Area
+	}
+}
+
+// AnalyzeStack measures st, which is defined in the model part path,
+// applying transform to the slices. A zero transform is treated as the identity.
+// The slice references are followed into the child model parts.
+// The layers are sorted from bottom to top, even if transform mirrors the Z axis.
+func AnalyzeStack(m *go3mf.Model, path string, st *SliceStack, transform go3mf.Matrix) (*Stats, error) {
+	src, err := stackSlices(m, path, st)
+	if err != nil {
+		return nil, err
+	}
+	if transform == (go3mf.Matrix{}) {
+		transform = go3mf.Identity()
+	}
+	z := func(z float32) float32 {
+		return transform[10]*z + transform[14]
+	}
+	layers := make([]LayerStats, len(src))
+	bottomZ := st.BottomZ
+	for i, s := range src {
+		layers[i] = measure(transformSlice(s, transform))
+		layers[i].BottomZ, layers[i].TopZ = z(bottomZ), z(s.TopZ)
+		bottomZ = s.TopZ
+	}
+	if transform[10] < 0 {
+		// Mirroring the Z axis turns the stack upside down.
+		for i := range layers {
+			layers[i].BottomZ, layers[i].TopZ = layers[i].TopZ, layers[i].BottomZ
+		}
+		for i, j := 0, len(layers)-1; i < j; i, j = i+1, j-1 {
+			layers[i], layers[j] = layers[j], layers[i]
+		}
+	}
+	stats := new(Stats)
+	for _, l := range layers {
+		stats.add(l)
+	}
+	return stats, nil
+}
+
+// AnalyzeBuild measures the sliced objects referenced by the build items,
+// including the ones referenced by components, applying their transforms.
+//
+// The layers of the parts are split at the bottom and top Z of every part layer,
+// so each returned layer is an interval of the build with a constant cross-section
+// that adds up the measures of the part layers that overlap it.
+// The layer count of the build is the number of these intervals,
+// which can be greater than the layer count of each part,
+// and MaxArea is the area of the largest cross-section of the build.
+// Intervals not covered by any part are not returned.
+// Objects that do not reference a slice stack are ignored.
+func AnalyzeBuild(m *go3mf.Model) (*Stats, error) {
+	var layers []LayerStats
+	err := m.WalkBuild(func(path string, obj *go3mf.Object, transform go3mf.Matrix) error {
+		st, err := ObjectSliceStack(m, path, obj)
+		if err != nil || st == nil {
+			return err
+		}
+		stats, err := AnalyzeStack(m, path, st, transform)
+		if err != nil {
+			return err
+		}
+		layers = append(layers, stats.Layers...)
+		return go3mf.SkipComponents
+	})
+	if err != nil {
+		return nil, err
+	}
+	var zs []float32
+	for _, l := range layers {
+		zs = append(zs, l.BottomZ, l.TopZ)
+	}
+	sort.Slice(zs, func(i, j int) bool { return zs[i] < zs[j] })
+	n := 0
+	for i, z := range zs {
+		if i == 0 || z != zs[n-1] {
+			zs[n] = z
+			n++
+		}
+	}
+	zs = zs[:n]
+	intervals := make([]*LayerStats, len(zs))
+	for _, l := range layers {
+		i := sort.Search(len(zs), func(i int) bool { return zs[i] >= l.BottomZ })
+		for ; i < len(zs)-1 && zs[i+1] <= l.TopZ; i++ {
+			if intervals[i] == nil {
+				intervals[i] = &LayerStats{BottomZ: zs[i], TopZ: zs[i+1]}
+			}
+			intervals[i].merge(l)
+		}
+	}
+	stats := new(Stats)
+	for _, l := range intervals {
+		if l != nil {
+			stats.add(*l)
+		}
+	}
+	return stats, nil
+}
+
+// merge adds the measures of other to l, keeping the l Z interval.
+func (l *LayerStats) merge(other LayerStats) {
+	if other.Islands > 0 {
+		if l.Islands == 0 {
+			l.Min, l.Max = other.Min, other.Max
+		}
+		for i := range l.Min {
+			l.Min[i] = float32(math.Min(float64(l.Min[i]), float64(other.Min[i])))
+			l.Max[i] = float32(math.Max(float64(l.Max[i]), float64(other.Max[i])))
+		}
+	}
+	l.Area += other.Area
+	l.Perimeter += other.Perimeter
+	l.Islands += other.Islands
+}
+
+// measure returns the area, perimeter, islands and bounding box of s.
+func measure(s *Slice) LayerStats {
+	var l LayerStats
+	loops := contours(s)
+	for i, loop := range resolve(loops, func(p [2]float64) bool { return evenOdd(loops, p) }) {
+		a := signedArea(loop)
+		l.Area += a
+		if a > 0 {
+			l.Islands++
+		}
+		for j, v := range loop {
+			l.Perimeter += math.Sqrt(distance2(v, loop[(j+1)%len(loop)]))
+			if i == 0 && j == 0 {
+				l.Min, l.Max = v, v
+			}
+			for k := range v {
+				l.Min[k] = float32(math.Min(float64(l.Min[k]), float64(v[k])))
+				l.Max[k] = float32(math.Max(float64(l.Max[k]), float64(v[k])))
+			}
+		}
+	}
+	return l
+}
+
+func transformSlice(s *Slice, transform go3mf.Matrix) *Slice {
+	if transform == go3mf.Identity() {
+		return s
+	}
+	c := *s
+	c.Vertices = make([]go3mf.Point2D, len(s.Vertices))
+	for i, v := range s.Vertices {
+		c.Vertices[i] = transform.Mul2D(v)
+	}
+	return &c
+}
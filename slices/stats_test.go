@@ -0,0 +1,103 @@
+package slices
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+func TestAnalyzeStack(t *testing.T) {
+	layer := func(topZ float32, loops ...[]go3mf.Point2D) *Slice {
+		s := sliceOf(loops...)
+		s.TopZ = topZ
+		return s
+	}
+	st := &SliceStack{BottomZ: 1, Slices: []*Slice{
+		layer(1.5, rect(0, 0, 4, 4), rect(1, 1, 3, 3)),
+		layer(2, rect(0, 0, 2, 2), rect(1, 1, 3, 3), rect(5, 0, 6, 1)),
+		{TopZ: 3},
+	}}
+	tests := []struct {
+		name      string
+		transform go3mf.Matrix
+		want      *Stats
+	}{
+		{"identity", go3mf.Matrix{}, &Stats{Volume: 9.5, MaxArea: 12, Layers: []LayerStats{
+			{BottomZ: 1, TopZ: 1.5, Area: 12, Perimeter: 24, Islands: 1, Min: go3mf.Point2D{0, 0}, Max: go3mf.Point2D{4, 4}},
+			{BottomZ: 1.5, TopZ: 2, Area: 7, Perimeter: 20, Islands: 3, Min: go3mf.Point2D{0, 0}, Max: go3mf.Point2D{6, 3}},
+			{BottomZ: 2, TopZ: 3},
+		}}},
+		{"transform", go3mf.Matrix{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, -1, 1, 10, 1}, &Stats{Volume: 19, MaxArea: 24, Layers: []LayerStats{
+			{BottomZ: 11, TopZ: 11.5, Area: 24, Perimeter: 36, Islands: 1, Min: go3mf.Point2D{-1, 1}, Max: go3mf.Point2D{7, 5}},
+			{BottomZ: 11.5, TopZ: 12, Area: 14, Perimeter: 30, Islands: 3, Min: go3mf.Point2D{-1, 1}, Max: go3mf.Point2D{11, 4}},
+			{BottomZ: 12, TopZ: 13},
+		}}},
+		{"mirror", go3mf.Matrix{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, -1, 0, 0, 0, 0, 1}, &Stats{Volume: 9.5, MaxArea: 12, Layers: []LayerStats{
+			{BottomZ: -3, TopZ: -2},
+			{BottomZ: -2, TopZ: -1.5, Area: 7, Perimeter: 20, Islands: 3, Min: go3mf.Point2D{0, 0}, Max: go3mf.Point2D{6, 3}},
+			{BottomZ: -1.5, TopZ: -1, Area: 12, Perimeter: 24, Islands: 1, Min: go3mf.Point2D{0, 0}, Max: go3mf.Point2D{4, 4}},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AnalyzeStack(nil, "", st, tt.transform)
+			if err != nil {
+				t.Fatalf("AnalyzeStack() error = %v", err)
+			}
+			deep.FloatPrecision = 6
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("AnalyzeStack() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestAnalyzeBuild(t *testing.T) {
+	square := sliceOf(rect(0, 0, 2, 2))
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{
+				&SliceStack{ID: 1, Refs: []SliceRef{{SliceStackID: 2, Path: "/3D/slices.model"}}},
+			},
+			Objects: []*go3mf.Object{
+				{ID: 3, AnyAttr: go3mf.AttrMarshalers{&SliceStackInfo{SliceStackID: 1}}},
+				{ID: 4, Components: []*go3mf.Component{
+					{ObjectID: 3, Transform: go3mf.Identity().Translate(5, 0, 0)},
+					{ObjectID: 3, Transform: go3mf.Identity().Translate(0, 0, 1)},
+					{ObjectID: 3, Transform: go3mf.Identity().Translate(0, 0, 0.5)},
+					{ObjectID: 100},
+				}},
+				{ID: 5},
+			},
+		},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/slices.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&SliceStack{ID: 2, Slices: []*Slice{square}},
+			}}},
+		},
+		Build: go3mf.Build{Items: []*go3mf.Item{
+			{ObjectID: 3},
+			{ObjectID: 4, Transform: go3mf.Identity().Translate(0, 10, 0)},
+			{ObjectID: 5},
+			{ObjectID: 200},
+		}},
+	}
+	got, err := AnalyzeBuild(m)
+	if err != nil {
+		t.Fatalf("AnalyzeBuild() error = %v", err)
+	}
+	want := &Stats{Volume: 16, MaxArea: 12, Layers: []LayerStats{
+		{BottomZ: 0, TopZ: 0.5, Area: 8, Perimeter: 16, Islands: 2, Min: go3mf.Point2D{0, 0}, Max: go3mf.Point2D{7, 12}},
+		{BottomZ: 0.5, TopZ: 1, Area: 12, Perimeter: 24, Islands: 3, Min: go3mf.Point2D{0, 0}, Max: go3mf.Point2D{7, 12}},
+		{BottomZ: 1, TopZ: 1.5, Area: 8, Perimeter: 16, Islands: 2, Min: go3mf.Point2D{0, 10}, Max: go3mf.Point2D{2, 12}},
+		{BottomZ: 1.5, TopZ: 2, Area: 4, Perimeter: 8, Islands: 1, Min: go3mf.Point2D{0, 10}, Max: go3mf.Point2D{2, 12}},
+	}}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("AnalyzeBuild() = %v", diff)
+	}
+	m.Childs = nil
+	if _, err := AnalyzeBuild(m); err == nil {
+		t.Error("AnalyzeBuild() expected error")
+	}
+}
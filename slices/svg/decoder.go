@@ -0,0 +1,261 @@
+package svg
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/slices"
+)
+
+// A Decoder reads layered SVG drawings into slice stacks.
+// The elements that do not follow the package convention are ignored.
+type Decoder struct {
+	r io.Reader
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		r: r,
+	}
+}
+
+// Decode reads the drawing into a slice stack and adds it to the m resources.
+func (d *Decoder) Decode(m *go3mf.Model) error {
+	st, err := d.DecodeStack()
+	if err != nil {
+		return err
+	}
+	st.ID = m.Resources.UnusedID()
+	m.Resources.Assets = append(m.Resources.Assets, st)
+	return nil
+}
+
+// DecodeStack reads the drawing into a slice stack without ID.
+func (d *Decoder) DecodeStack() (*slices.SliceStack, error) {
+	x := xml.NewDecoder(d.r)
+	st := new(slices.SliceStack)
+	var (
+		s       *slices.Slice
+		indices map[go3mf.Point2D]uint32
+	)
+	for {
+		t, err := x.Token()
+		if err == io.EOF {
+			return st, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		se, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch se.Name.Local {
+		case "svg":
+			if v, ok := attr(se, attrBottomZ); ok {
+				z, err := strconv.ParseFloat(v, 32)
+				if err != nil {
+					return nil, err
+				}
+				st.BottomZ = float32(z)
+			}
+		case "g":
+			if v, ok := attr(se, attrTopZ); ok {
+				z, err := strconv.ParseFloat(v, 32)
+				if err != nil {
+					return nil, err
+				}
+				s = &slices.Slice{TopZ: float32(z)}
+				indices = make(map[go3mf.Point2D]uint32)
+				st.Slices = append(st.Slices, s)
+			}
+		case "path":
+			if s == nil {
+				continue
+			}
+			data, _ := attr(se, "d")
+			properties, _ := attr(se, attrProperties)
+			if err := decodePath(s, indices, data, properties); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func attr(se xml.StartElement, name string) (string, bool) {
+	for _, a := range se.Attr {
+		if a.Name.Space == "" && a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// decodePath adds the polygons of the path data to s.
+// It supports the absolute and relative moveto, lineto,
+// horizontal lineto, vertical lineto and closepath commands.
+func decodePath(s *slices.Slice, indices map[go3mf.Point2D]uint32, data, properties string) error {
+	vertex := func(v go3mf.Point2D) uint32 {
+		v[1] = -v[1]
+		if v[1] == 0 {
+			v[1] = 0 // Avoid negative zeros.
+		}
+		if i, ok := indices[v]; ok {
+			return i
+		}
+		i := uint32(len(s.Vertices))
+		indices[v] = i
+		s.Vertices = append(s.Vertices, v)
+		return i
+	}
+	var (
+		polygons []slices.Polygon
+		p        *slices.Polygon
+		cur      go3mf.Point2D
+		start    go3mf.Point2D
+	)
+	addSegment := func(v go3mf.Point2D) error {
+		if p == nil {
+			return ErrInvalidPath
+		}
+		p.Segments = append(p.Segments, slices.Segment{V2: vertex(v)})
+		cur = v
+		return nil
+	}
+	tokens := pathTokens(data)
+	var cmd byte
+	for i := 0; i < len(tokens); {
+		if c := tokens[i]; len(c) == 1 && strings.ContainsAny(c, "MmLlHhVvZz") {
+			cmd = c[0]
+			i++
+		} else if cmd == 'M' {
+			cmd = 'L' // Implicit lineto after a moveto.
+		} else if cmd == 'm' {
+			cmd = 'l'
+		}
+		var args int
+		switch cmd {
+		case 'M', 'm', 'L', 'l':
+			args = 2
+		case 'H', 'h', 'V', 'v':
+			args = 1
+		case 'Z', 'z':
+			if p != nil && cur != start {
+				addSegment(start)
+			}
+			p, cur = nil, start
+			continue
+		default:
+			return ErrInvalidPath
+		}
+		if i+args > len(tokens) {
+			return ErrInvalidPath
+		}
+		var values [2]float32
+		for j := 0; j < args; j++ {
+			f, err := strconv.ParseFloat(tokens[i+j], 32)
+			if err != nil {
+				return ErrInvalidPath
+			}
+			values[j] = float32(f)
+		}
+		i += args
+		var v go3mf.Point2D
+		switch cmd {
+		case 'M', 'L':
+			v = go3mf.Point2D{values[0], values[1]}
+		case 'm', 'l':
+			v = go3mf.Point2D{cur.X() + values[0], cur.Y() + values[1]}
+		case 'H':
+			v = go3mf.Point2D{values[0], cur.Y()}
+		case 'h':
+			v = go3mf.Point2D{cur.X() + values[0], cur.Y()}
+		case 'V':
+			v = go3mf.Point2D{cur.X(), values[0]}
+		case 'v':
+			v = go3mf.Point2D{cur.X(), cur.Y() + values[0]}
+		}
+		if cmd == 'M' || cmd == 'm' {
+			polygons = append(polygons, slices.Polygon{StartV: vertex(v)})
+			p = &polygons[len(polygons)-1]
+			cur, start = v, v
+		} else if err := addSegment(v); err != nil {
+			return err
+		}
+	}
+	setProperties(polygons, properties)
+	for _, p := range polygons {
+		if len(p.Segments) > 0 {
+			s.Polygons = append(s.Polygons, p)
+		}
+	}
+	return nil
+}
+
+// setProperties assigns the pid,p1,p2 values to the segments, in order.
+func setProperties(polygons []slices.Polygon, properties string) {
+	fields := strings.Fields(properties)
+	for i := range polygons {
+		for j := range polygons[i].Segments {
+			if len(fields) == 0 {
+				return
+			}
+			values := strings.Split(fields[0], ",")
+			fields = fields[1:]
+			if len(values) != 3 {
+				continue
+			}
+			seg := &polygons[i].Segments[j]
+			for k, v := range values {
+				n, _ := strconv.ParseUint(v, 10, 32)
+				switch k {
+				case 0:
+					seg.PID = uint32(n)
+				case 1:
+					seg.P1 = uint32(n)
+				case 2:
+					seg.P2 = uint32(n)
+				}
+			}
+		}
+	}
+}
+
+// pathTokens splits the path data into commands and numbers.
+func pathTokens(data string) []string {
+	var tokens []string
+	var sb strings.Builder
+	flush := func() {
+		if sb.Len() > 0 {
+			tokens = append(tokens, sb.String())
+			sb.Reset()
+		}
+	}
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case strings.IndexByte("MmLlHhVvZz", c) >= 0:
+			flush()
+			tokens = append(tokens, string(c))
+		case c == ' ' || c == ',' || c == '\t' || c == '\n' || c == '\r':
+			flush()
+		case c == '-' && sb.Len() > 0 && !strings.HasSuffix(sb.String(), "e") && !strings.HasSuffix(sb.String(), "E"):
+			flush()
+			sb.WriteByte(c)
+		default:
+			if strings.IndexByte("AaCcSsQqTt", c) >= 0 {
+				// Unsupported commands make the path invalid.
+				flush()
+				tokens = append(tokens, string(c))
+				continue
+			}
+			sb.WriteByte(c)
+		}
+	}
+	flush()
+	return tokens
+}
@@ -0,0 +1,79 @@
+package svg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/slices"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	m, st := slicedModel()
+	var buf bytes.Buffer
+	if err := NewEncoder(m).EncodeStack(&buf, "", st); err != nil {
+		t.Fatalf("Encoder.EncodeStack() error = %v", err)
+	}
+	got := &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{{ID: 1}}}}
+	if err := NewDecoder(&buf).Decode(got); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	want := &slices.SliceStack{ID: 2, BottomZ: 0.5, Slices: []*slices.Slice{
+		{TopZ: 1, Vertices: []go3mf.Point2D{{0, 0}, {2, 0}, {2, 2}, {0, 2}}, Polygons: []slices.Polygon{
+			{StartV: 0, Segments: []slices.Segment{{V2: 1, PID: 5, P1: 1, P2: 0}, {V2: 2}, {V2: 3}, {V2: 0}}},
+		}},
+		{TopZ: 1.5, Vertices: []go3mf.Point2D{{0, 0}, {1, -1}}, Polygons: []slices.Polygon{
+			{StartV: 0, Segments: []slices.Segment{{V2: 1}}},
+		}},
+	}}
+	if diff := deep.Equal(got.Resources.Assets, []go3mf.Asset{want}); diff != nil {
+		t.Errorf("Decoder.Decode() = %v", diff)
+	}
+}
+
+func TestDecoder_DecodeStack(t *testing.T) {
+	data := `<?xml version="1.0"?>
+<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 10 10">
+  <path d="M0 0 L1 1"/>
+  <g data-topz="0.1">
+    <rect width="1" height="1"/>
+    <g><path d="m1,-1 h2 v-2 h-2 z M5 -5 6 -5 6-6 L5 -5 Z"/></g>
+  </g>
+</svg>`
+	got, err := NewDecoder(strings.NewReader(data)).DecodeStack()
+	if err != nil {
+		t.Fatalf("Decoder.DecodeStack() error = %v", err)
+	}
+	want := &slices.SliceStack{Slices: []*slices.Slice{
+		{TopZ: 0.1, Vertices: []go3mf.Point2D{{1, 1}, {3, 1}, {3, 3}, {1, 3}, {5, 5}, {6, 5}, {6, 6}}, Polygons: []slices.Polygon{
+			{StartV: 0, Segments: []slices.Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}},
+			{StartV: 4, Segments: []slices.Segment{{V2: 5}, {V2: 6}, {V2: 4}}},
+		}},
+	}}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Decoder.DecodeStack() = %v", diff)
+	}
+}
+
+func TestDecoder_DecodeStack_error(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"xml", `<svg><g data-topz="1"></svg>`},
+		{"topz", `<svg><g data-topz="a"></g></svg>`},
+		{"bottomz", `<svg data-bottomz="a"></svg>`},
+		{"curve", `<svg><g data-topz="1"><path d="M0 0 C1 1 2 2 3 3"/></g></svg>`},
+		{"noMoveto", `<svg><g data-topz="1"><path d="L1 1"/></g></svg>`},
+		{"args", `<svg><g data-topz="1"><path d="M0 0 L1"/></g></svg>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewDecoder(strings.NewReader(tt.data)).DecodeStack(); err == nil {
+				t.Error("Decoder.DecodeStack() expected error")
+			}
+		})
+	}
+}
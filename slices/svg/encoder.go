@@ -0,0 +1,203 @@
+package svg
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/materials"
+	"github.com/qmuntal/go3mf/slices"
+)
+
+// An Encoder writes slices as SVG drawings.
+//
+// The polygons are filled with Fill using FillRule and outlined with Stroke.
+// The segments whose properties can be resolved to a base material
+// or to a color group are drawn on top with the color of their first property.
+// StrokeWidth is measured in screen units, so it does not depend on the drawing scale.
+type Encoder struct {
+	FillRule    slices.FillRule
+	Fill        color.RGBA
+	Stroke      color.RGBA
+	StrokeWidth float32
+	m           *go3mf.Model
+}
+
+// NewEncoder returns a new encoder with grey polygons and black outlines.
+// The model m is used to resolve the segment properties and the slice references,
+// and can be nil.
+func NewEncoder(m *go3mf.Model) *Encoder {
+	return &Encoder{
+		Fill:        color.RGBA{R: 200, G: 200, B: 200, A: 255},
+		Stroke:      color.RGBA{A: 255},
+		StrokeWidth: 1,
+		m:           m,
+	}
+}
+
+// A Layer is a slice and the path of the model part where it is defined.
+type Layer struct {
+	Path  string
+	Slice *slices.Slice
+}
+
+// Layers returns the slices of st, which is defined in the model part path,
+// following the slice references into the child model parts.
+func (e *Encoder) Layers(path string, st *slices.SliceStack) ([]Layer, error) {
+	stacks, paths, err := st.ResolveRefs(e.m, path)
+	if err != nil {
+		return nil, err
+	}
+	var layers []Layer
+	for _, s := range st.Slices {
+		layers = append(layers, Layer{Path: path, Slice: s})
+	}
+	for i, refStack := range stacks {
+		for _, s := range refStack.Slices {
+			layers = append(layers, Layer{Path: paths[i], Slice: s})
+		}
+	}
+	return layers, nil
+}
+
+// EncodeSlice writes s, which is defined in the model part path, as a single layer SVG.
+func (e *Encoder) EncodeSlice(w io.Writer, path string, s *slices.Slice) error {
+	return e.encode(w, 0, []Layer{{Path: path, Slice: s}})
+}
+
+// EncodeStack writes the slices of st, which is defined in the model part path,
+// as a multi-layer SVG.
+func (e *Encoder) EncodeStack(w io.Writer, path string, st *slices.SliceStack) error {
+	layers, err := e.Layers(path, st)
+	if err != nil {
+		return err
+	}
+	return e.encode(w, st.BottomZ, layers)
+}
+
+func (e *Encoder) encode(w io.Writer, bottomZ float32, layers []Layer) error {
+	bw := bufio.NewWriter(w)
+	min, max := bounds(layers)
+	width, height := formatFloat(max.X()-min.X()), formatFloat(max.Y()-min.Y())
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%smm" height="%smm" viewBox="%s %s %s %s" %s="%s">`+"\n",
+		width, height, formatFloat(min.X()), formatFloat(-max.Y()), width, height, attrBottomZ, formatFloat(bottomZ))
+	fillRule := "evenodd"
+	if e.FillRule == slices.FillNonZero {
+		fillRule = "nonzero"
+	}
+	resolvers := make(map[string]*materials.ColorResolver)
+	for i, l := range layers {
+		fmt.Fprintf(bw, `  <g id="layer%d" %s="%s" fill="%s" fill-rule="%s" stroke="%s" stroke-width="%s">`+"\n",
+			i, attrTopZ, formatFloat(l.Slice.TopZ), formatColor(e.Fill), fillRule, formatColor(e.Stroke), formatFloat(e.StrokeWidth))
+		for _, p := range l.Slice.Polygons {
+			e.writePolygon(bw, l.Slice, p)
+		}
+		if e.m != nil {
+			r, ok := resolvers[l.Path]
+			if !ok {
+				r = materials.NewColorResolver(e.m, l.Path)
+				resolvers[l.Path] = r
+			}
+			e.writeColoredSegments(bw, l.Slice, r)
+		}
+		bw.WriteString("  </g>\n")
+	}
+	bw.WriteString("</svg>\n")
+	return bw.Flush()
+}
+
+func (e *Encoder) writePolygon(w *bufio.Writer, s *slices.Slice, p slices.Polygon) {
+	if !validPolygon(s, p) {
+		return
+	}
+	var d strings.Builder
+	d.WriteString("M" + formatPoint(s.Vertices[p.StartV]))
+	var hasProperties bool
+	properties := make([]string, len(p.Segments))
+	for i, seg := range p.Segments {
+		if i == len(p.Segments)-1 && seg.V2 == p.StartV && i > 0 {
+			d.WriteString(" Z")
+		} else {
+			d.WriteString(" L" + formatPoint(s.Vertices[seg.V2]))
+		}
+		if seg.PID != 0 {
+			hasProperties = true
+		}
+		properties[i] = fmt.Sprintf("%d,%d,%d", seg.PID, seg.P1, seg.P2)
+	}
+	fmt.Fprintf(w, `    <path d="%s" vector-effect="non-scaling-stroke"`, d.String())
+	if hasProperties {
+		fmt.Fprintf(w, ` %s="%s"`, attrProperties, strings.Join(properties, " "))
+	}
+	w.WriteString("/>\n")
+}
+
+func (e *Encoder) writeColoredSegments(w *bufio.Writer, s *slices.Slice, r *materials.ColorResolver) {
+	for _, p := range s.Polygons {
+		if !validPolygon(s, p) {
+			continue
+		}
+		v1 := s.Vertices[p.StartV]
+		for _, seg := range p.Segments {
+			v2 := s.Vertices[seg.V2]
+			if c, ok := r.Color(seg.PID, seg.P1); ok {
+				fmt.Fprintf(w, `    <line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" vector-effect="non-scaling-stroke"/>`+"\n",
+					formatFloat(v1.X()), formatFloat(-v1.Y()), formatFloat(v2.X()), formatFloat(-v2.Y()), formatColor(c))
+			}
+			v1 = v2
+		}
+	}
+}
+
+func validPolygon(s *slices.Slice, p slices.Polygon) bool {
+	if len(p.Segments) == 0 || int(p.StartV) >= len(s.Vertices) {
+		return false
+	}
+	for _, seg := range p.Segments {
+		if int(seg.V2) >= len(s.Vertices) {
+			return false
+		}
+	}
+	return true
+}
+
+func bounds(layers []Layer) (go3mf.Point2D, go3mf.Point2D) {
+	min := go3mf.Point2D{math.MaxFloat32, math.MaxFloat32}
+	max := go3mf.Point2D{-math.MaxFloat32, -math.MaxFloat32}
+	for _, l := range layers {
+		for _, v := range l.Slice.Vertices {
+			for i := range min {
+				min[i] = float32(math.Min(float64(min[i]), float64(v[i])))
+				max[i] = float32(math.Max(float64(max[i]), float64(v[i])))
+			}
+		}
+	}
+	if min.X() > max.X() {
+		return go3mf.Point2D{}, go3mf.Point2D{}
+	}
+	return min, max
+}
+
+func formatPoint(v go3mf.Point2D) string {
+	return formatFloat(v.X()) + " " + formatFloat(-v.Y())
+}
+
+func formatFloat(f float32) string {
+	if f == 0 {
+		// Avoid negative zeros.
+		return "0"
+	}
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+func formatColor(c color.RGBA) string {
+	if c.A == 255 {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("rgba(%d,%d,%d,%s)", c.R, c.G, c.B, strconv.FormatFloat(float64(c.A)/255, 'f', 3, 64))
+}
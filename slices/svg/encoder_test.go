@@ -0,0 +1,81 @@
+package svg
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/materials"
+	"github.com/qmuntal/go3mf/slices"
+)
+
+func slicedModel() (*go3mf.Model, *slices.SliceStack) {
+	st := &slices.SliceStack{ID: 1, BottomZ: 0.5, Refs: []slices.SliceRef{{SliceStackID: 3, Path: "/3D/other.model"}}}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{Assets: []go3mf.Asset{
+			&go3mf.BaseMaterials{ID: 5, Materials: []go3mf.Base{{Name: "red", Color: color.RGBA{R: 255, A: 255}}}},
+			st,
+		}},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/other.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&materials.ColorGroup{ID: 5, Colors: []color.RGBA{{G: 255, A: 255}, {B: 255, A: 128}}},
+				&slices.SliceStack{ID: 3, Slices: []*slices.Slice{
+					{TopZ: 1, Vertices: []go3mf.Point2D{{0, 0}, {2, 0}, {2, 2}, {0, 2}}, Polygons: []slices.Polygon{
+						{StartV: 0, Segments: []slices.Segment{{V2: 1, PID: 5, P1: 1, P2: 0}, {V2: 2}, {V2: 3}, {V2: 0}}},
+					}},
+					{TopZ: 1.5, Vertices: []go3mf.Point2D{{0, 0}, {1, -1}}, Polygons: []slices.Polygon{
+						{StartV: 0, Segments: []slices.Segment{{V2: 1}}},
+						{StartV: 0, Segments: []slices.Segment{{V2: 10}}},
+					}},
+				}},
+			}}},
+		},
+	}
+	return m, st
+}
+
+func TestEncoder_EncodeStack(t *testing.T) {
+	m, st := slicedModel()
+	var buf bytes.Buffer
+	e := NewEncoder(m)
+	e.FillRule = slices.FillNonZero
+	if err := e.EncodeStack(&buf, "", st); err != nil {
+		t.Fatalf("Encoder.EncodeStack() error = %v", err)
+	}
+	want := `<svg xmlns="http://www.w3.org/2000/svg" width="2mm" height="3mm" viewBox="0 -2 2 3" data-bottomz="0.5">
+  <g id="layer0" data-topz="1" fill="#c8c8c8" fill-rule="nonzero" stroke="#000000" stroke-width="1">
+    <path d="M0 0 L2 0 L2 -2 L0 -2 Z" vector-effect="non-scaling-stroke" data-properties="5,1,0 0,0,0 0,0,0 0,0,0"/>
+    <line x1="0" y1="0" x2="2" y2="0" stroke="rgba(0,0,255,0.502)" vector-effect="non-scaling-stroke"/>
+  </g>
+  <g id="layer1" data-topz="1.5" fill="#c8c8c8" fill-rule="nonzero" stroke="#000000" stroke-width="1">
+    <path d="M0 0 L1 1" vector-effect="non-scaling-stroke"/>
+  </g>
+</svg>
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Encoder.EncodeStack() = %v, want %v", got, want)
+	}
+}
+
+func TestEncoder_EncodeSlice(t *testing.T) {
+	m, _ := slicedModel()
+	s := &slices.Slice{TopZ: 2, Vertices: []go3mf.Point2D{{1, 1}, {2, 1}, {1, 2}}, Polygons: []slices.Polygon{
+		{StartV: 0, Segments: []slices.Segment{{V2: 1, PID: 5}, {V2: 2, PID: 5}, {V2: 0, PID: 4}}},
+	}}
+	var buf bytes.Buffer
+	if err := NewEncoder(m).EncodeSlice(&buf, "", s); err != nil {
+		t.Fatalf("Encoder.EncodeSlice() error = %v", err)
+	}
+	want := `<svg xmlns="http://www.w3.org/2000/svg" width="1mm" height="1mm" viewBox="1 -2 1 1" data-bottomz="0">
+  <g id="layer0" data-topz="2" fill="#c8c8c8" fill-rule="evenodd" stroke="#000000" stroke-width="1">
+    <path d="M1 -1 L2 -1 L1 -2 Z" vector-effect="non-scaling-stroke" data-properties="5,0,0 5,0,0 4,0,0"/>
+    <line x1="1" y1="-1" x2="2" y2="-1" stroke="#ff0000" vector-effect="non-scaling-stroke"/>
+    <line x1="2" y1="-1" x2="1" y2="-2" stroke="#ff0000" vector-effect="non-scaling-stroke"/>
+  </g>
+</svg>
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Encoder.EncodeSlice() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,25 @@
+// Package svg reads and writes slices as layered SVG drawings.
+//
+// Each slice is drawn in a group element with a data-topz attribute
+// and each polygon is a path element whose d attribute only contains
+// moveto, lineto and closepath commands. Closed polygons end with a closepath.
+// The root svg element stores the stack bottom Z in a data-bottomz attribute.
+//
+// The Y coordinates are negated so the drawings are not mirrored,
+// as the SVG Y axis points down.
+// The segment properties are stored in the data-properties attribute of the path
+// as a space separated list of pid,p1,p2 values, one per segment.
+package svg
+
+import (
+	"errors"
+)
+
+// ErrInvalidPath is returned when the data of a path cannot be parsed.
+var ErrInvalidPath = errors.New("svg: invalid path data")
+
+const (
+	attrTopZ       = "data-topz"
+	attrBottomZ    = "data-bottomz"
+	attrProperties = "data-properties"
+)
@@ -0,0 +1,127 @@
+package slices
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// Trace is the inverse of RasterizeSlice: it returns a slice whose polygons
+// are the contours of the pixels of img that are brighter than half intensity.
+//
+// The contours follow the pixel borders, using the Rasterizer PixelSize and Origin
+// to convert them into model coordinates. Outer contours are counter-clockwise
+// and holes are clockwise, so the slice can be filled with any FillRule.
+// Diagonally adjacent pixels are traced as separated contours.
+func (r *Rasterizer) Trace(img image.Image) *Slice {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	filled := func(x, y int) bool {
+		if x < 0 || y < 0 || x >= w || y >= h {
+			return false
+		}
+		return color.GrayModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.Gray).Y >= 128
+	}
+	// Border edges are stored in a grid with the Y axis pointing up,
+	// with the filled pixel on the left of the edge direction.
+	next := make(map[gridPoint][]gridPoint)
+	addEdge := func(p1, p2 gridPoint) {
+		next[p1] = append(next[p1], p2)
+	}
+	var starts []gridPoint
+	for y := h - 1; y >= 0; y-- {
+		gy := h - y - 1
+		for x := 0; x < w; x++ {
+			if !filled(x, y) {
+				continue
+			}
+			if !filled(x, y+1) {
+				addEdge(gridPoint{x, gy}, gridPoint{x + 1, gy})
+				starts = append(starts, gridPoint{x, gy})
+			}
+			if !filled(x+1, y) {
+				addEdge(gridPoint{x + 1, gy}, gridPoint{x + 1, gy + 1})
+			}
+			if !filled(x, y-1) {
+				addEdge(gridPoint{x + 1, gy + 1}, gridPoint{x, gy + 1})
+			}
+			if !filled(x-1, y) {
+				addEdge(gridPoint{x, gy + 1}, gridPoint{x, gy})
+			}
+		}
+	}
+	s := new(Slice)
+	indices := make(map[gridPoint]uint32)
+	vertex := func(p gridPoint) uint32 {
+		if i, ok := indices[p]; ok {
+			return i
+		}
+		i := uint32(len(s.Vertices))
+		indices[p] = i
+		s.Vertices = append(s.Vertices, go3mf.Point2D{
+			r.Origin.X() + float32(p.x)*r.PixelSize,
+			r.Origin.Y() + float32(p.y)*r.PixelSize,
+		})
+		return i
+	}
+	for _, start := range starts {
+		if len(next[start]) == 0 {
+			continue
+		}
+		contour := traceContour(next, start)
+		p := Polygon{StartV: vertex(contour[0])}
+		for _, v := range contour[1:] {
+			p.Segments = append(p.Segments, Segment{V2: vertex(v)})
+		}
+		p.Segments = append(p.Segments, Segment{V2: p.StartV})
+		s.Polygons = append(s.Polygons, p)
+	}
+	return s
+}
+
+type gridPoint struct {
+	x, y int
+}
+
+// traceContour consumes the edges of the closed contour that contains start
+// and returns its corners. At the pixel corners with two outgoing edges
+// the left turn is taken, which keeps the contour on its own pixel.
+func traceContour(next map[gridPoint][]gridPoint, start gridPoint) []gridPoint {
+	var corners []gridPoint
+	prev, cur := start, start
+	for {
+		out := next[cur]
+		if len(out) == 0 {
+			break
+		}
+		k := 0
+		if len(out) > 1 && cur != prev {
+			dx, dy := cur.x-prev.x, cur.y-prev.y
+			for i, o := range out {
+				// Left turn: (dx, dy) -> (-dy, dx).
+				if o.x-cur.x == -dy && o.y-cur.y == dx {
+					k = i
+					break
+				}
+			}
+		}
+		to := out[k]
+		next[cur] = append(out[:k], out[k+1:]...)
+		if cur == start || (to.x-cur.x != cur.x-prev.x || to.y-cur.y != cur.y-prev.y) {
+			corners = append(corners, cur)
+		}
+		prev, cur = cur, to
+		if cur == start {
+			break
+		}
+	}
+	// The start point is a corner only if the contour turns there.
+	if len(corners) > 2 {
+		last, first := corners[len(corners)-1], corners[1]
+		if (start.x-last.x == 0) == (first.x-start.x == 0) && (start.y-last.y == 0) == (first.y-start.y == 0) {
+			corners = corners[1:]
+		}
+	}
+	return corners
+}
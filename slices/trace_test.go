@@ -0,0 +1,78 @@
+package slices
+
+import (
+	"image"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+func gray(w int, pix ...uint8) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, w, len(pix)/w))
+	copy(img.Pix, pix)
+	return img
+}
+
+func TestRasterizer_Trace(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *Rasterizer
+		img  *image.Gray
+		want *Slice
+	}{
+		{"empty", NewRasterizer(2, 2, 1), gray(2, 0, 0, 0, 0), new(Slice)},
+		{"square", &Rasterizer{PixelSize: 2, Origin: go3mf.Point2D{1, 1}}, gray(3,
+			0, 0, 0,
+			0, 255, 255,
+			0, 255, 200,
+		), &Slice{
+			Vertices: []go3mf.Point2D{{3, 1}, {7, 1}, {7, 5}, {3, 5}},
+			Polygons: []Polygon{{StartV: 0, Segments: []Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}}},
+		}},
+		{"hole", NewRasterizer(3, 3, 1), gray(3,
+			255, 255, 255,
+			255, 0, 255,
+			255, 255, 255,
+		), &Slice{
+			Vertices: []go3mf.Point2D{{0, 0}, {3, 0}, {3, 3}, {0, 3}, {1, 2}, {2, 2}, {2, 1}, {1, 1}},
+			Polygons: []Polygon{
+				{StartV: 0, Segments: []Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}},
+				{StartV: 4, Segments: []Segment{{V2: 5}, {V2: 6}, {V2: 7}, {V2: 4}}},
+			},
+		}},
+		{"diagonal", NewRasterizer(2, 2, 1), gray(2,
+			255, 0,
+			0, 255,
+		), &Slice{
+			Vertices: []go3mf.Point2D{{1, 0}, {2, 0}, {2, 1}, {1, 1}, {0, 1}, {1, 2}, {0, 2}},
+			Polygons: []Polygon{
+				{StartV: 0, Segments: []Segment{{V2: 1}, {V2: 2}, {V2: 3}, {V2: 0}}},
+				{StartV: 4, Segments: []Segment{{V2: 3}, {V2: 5}, {V2: 6}, {V2: 4}}},
+			},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.r.Trace(tt.img)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("Rasterizer.Trace() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestRasterizer_Trace_roundTrip(t *testing.T) {
+	for _, rule := range []FillRule{FillEvenOdd, FillNonZero} {
+		r := &Rasterizer{Width: 4, Height: 3, PixelSize: 0.5, Origin: go3mf.Point2D{-1, 2}, FillRule: rule}
+		img := gray(4,
+			255, 255, 0, 255,
+			255, 0, 255, 0,
+			255, 255, 255, 255,
+		)
+		got := r.RasterizeSlice(r.Trace(img), go3mf.Matrix{})
+		if diff := deep.Equal(got.Pix, img.Pix); diff != nil {
+			t.Errorf("Rasterizer.Trace() %v = %v", rule, diff)
+		}
+	}
+}
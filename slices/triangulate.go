@@ -0,0 +1,182 @@
+package slices
+
+import (
+	"sort"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// triangulate returns the counter-clockwise triangles that fill the region
+// bounded by loops, which must not cross each other and have the region on their left,
+// as the loops returned by resolve.
+//
+// Each hole is bridged to the smallest outer contour that contains it
+// and the resulting polygons are triangulated by ear clipping.
+func triangulate(loops [][]go3mf.Point2D) [][3]go3mf.Point2D {
+	type group struct {
+		outer []go3mf.Point2D
+		area  float64
+		holes [][]go3mf.Point2D
+	}
+	var groups []*group
+	var holes [][]go3mf.Point2D
+	for _, loop := range loops {
+		if a := signedArea(loop); a > 0 {
+			groups = append(groups, &group{outer: loop, area: a})
+		} else if a < 0 {
+			holes = append(holes, loop)
+		}
+	}
+	for _, hole := range holes {
+		p, _ := sides(hole[0], hole[1])
+		var owner *group
+		for _, g := range groups {
+			if (owner == nil || g.area < owner.area) && evenOdd([][]go3mf.Point2D{g.outer}, p) {
+				owner = g
+			}
+		}
+		if owner != nil {
+			owner.holes = append(owner.holes, hole)
+		}
+	}
+	var tris [][3]go3mf.Point2D
+	for _, g := range groups {
+		sort.Slice(g.holes, func(i, j int) bool {
+			return g.holes[i][rightmost(g.holes[i])].X() > g.holes[j][rightmost(g.holes[j])].X()
+		})
+		region := append([][]go3mf.Point2D{g.outer}, g.holes...)
+		poly := g.outer
+		for i, hole := range g.holes {
+			poly = bridge(poly, hole, region, g.holes[i+1:])
+		}
+		tris = earClip(poly, tris)
+	}
+	return tris
+}
+
+func signedArea(loop []go3mf.Point2D) float64 {
+	var a float64
+	for i, v1 := range loop {
+		v2 := loop[(i+1)%len(loop)]
+		a += float64(v1.X())*float64(v2.Y()) - float64(v2.X())*float64(v1.Y())
+	}
+	return a / 2
+}
+
+func rightmost(loop []go3mf.Point2D) int {
+	var index int
+	for i, v := range loop {
+		if v.X() > loop[index].X() {
+			index = i
+		}
+	}
+	return index
+}
+
+// bridge merges hole into poly joining the rightmost hole vertex with the closest
+// poly vertex that can be reached from it without leaving the region
+// nor crossing poly, the hole or the pending holes.
+// The hole is dropped if there is no such vertex.
+func bridge(poly, hole []go3mf.Point2D, region, pending [][]go3mf.Point2D) []go3mf.Point2D {
+	hm := rightmost(hole)
+	m := hole[hm]
+	candidates := make([]int, len(poly))
+	for i := range candidates {
+		candidates[i] = i
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return distance2(m, poly[candidates[i]]) < distance2(m, poly[candidates[j]])
+	})
+	obstacles := append([][]go3mf.Point2D{poly, hole}, pending...)
+	for _, i := range candidates {
+		p := poly[i]
+		if p != m {
+			mid := [2]float64{(float64(p.X()) + float64(m.X())) / 2, (float64(p.Y()) + float64(m.Y())) / 2}
+			if !evenOdd(region, mid) || crossesAny(p, m, obstacles) {
+				continue
+			}
+		}
+		merged := make([]go3mf.Point2D, 0, len(poly)+len(hole)+2)
+		merged = append(merged, poly[:i+1]...)
+		merged = append(merged, hole[hm:]...)
+		merged = append(merged, hole[:hm+1]...)
+		merged = append(merged, poly[i:]...)
+		result := merged[:1]
+		for _, v := range merged[1:] {
+			if v != result[len(result)-1] {
+				result = append(result, v)
+			}
+		}
+		if len(result) > 1 && result[0] == result[len(result)-1] {
+			result = result[:len(result)-1]
+		}
+		return result
+	}
+	return poly
+}
+
+// crossesAny reports whether the segment p1-p2 properly crosses any loop edge.
+func crossesAny(p1, p2 go3mf.Point2D, loops [][]go3mf.Point2D) bool {
+	for _, loop := range loops {
+		for i, v1 := range loop {
+			v2 := loop[(i+1)%len(loop)]
+			if orientation(p1, p2, v1)*orientation(p1, p2, v2) < 0 &&
+				orientation(v1, v2, p1)*orientation(v1, v2, p2) < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// earClip appends to tris the triangles of the counter-clockwise polygon poly.
+// Vertices that are repeated because of the hole bridges are allowed.
+func earClip(poly []go3mf.Point2D, tris [][3]go3mf.Point2D) [][3]go3mf.Point2D {
+	n := len(poly)
+	if n < 3 {
+		return tris
+	}
+	prev, next := make([]int, n), make([]int, n)
+	for i := range poly {
+		prev[i], next[i] = (i+n-1)%n, (i+1)%n
+	}
+	ear := 0
+	for fails := 0; n > 3; {
+		if !isEar(poly, prev, next, ear) {
+			if fails++; fails >= n {
+				// No ear left because of degenerated geometry.
+				return tris
+			}
+			ear = next[ear]
+			continue
+		}
+		tris = append(tris, [3]go3mf.Point2D{poly[prev[ear]], poly[ear], poly[next[ear]]})
+		next[prev[ear]], prev[next[ear]] = next[ear], prev[ear]
+		ear = next[ear]
+		n--
+		fails = 0
+	}
+	if a, b, c := poly[prev[ear]], poly[ear], poly[next[ear]]; orientation(a, b, c) > 0 {
+		tris = append(tris, [3]go3mf.Point2D{a, b, c})
+	}
+	return tris
+}
+
+// isEar reports whether the triangle formed by ear and its neighbours
+// is counter-clockwise and does not contain any other polygon vertex.
+func isEar(poly []go3mf.Point2D, prev, next []int, ear int) bool {
+	a, b, c := poly[prev[ear]], poly[ear], poly[next[ear]]
+	if orientation(a, b, c) <= 0 {
+		return false
+	}
+	for i := next[next[ear]]; i != prev[ear]; i = next[i] {
+		p := poly[i]
+		if p == a || p == b || p == c {
+			continue
+		}
+		if orientation(a, b, p) >= 0 && orientation(b, c, p) >= 0 && orientation(c, a, p) >= 0 {
+			return false
+		}
+	}
+	return true
+}
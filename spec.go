@@ -32,6 +32,29 @@ func (u *UnknownSpec) Required() bool     { return u.IsRequired }
 func (u *UnknownSpec) SetLocal(l string)  { u.LocalName = l }
 func (u *UnknownSpec) SetRequired(r bool) { u.IsRequired = r }
 
+// UnknownTokens contains the raw XML tokens of an element
+// that does not belong to any registered extension.
+// It is only filled when decoding with PreserveUnknown.
+type UnknownTokens []xml.Token
+
+// Marshal3MF encodes the tokens verbatim.
+func (u *UnknownTokens) Marshal3MF(x *XMLEncoder) error {
+	for _, t := range *u {
+		x.EncodeToken(t)
+	}
+	return nil
+}
+
+// UnknownAttrs contains the raw XML attributes
+// that do not belong to any registered extension.
+// It is only filled when decoding with PreserveUnknown.
+type UnknownAttrs []xml.Attr
+
+// Marshal3MFAttr encodes the attributes verbatim.
+func (u *UnknownAttrs) Marshal3MFAttr(_ *XMLEncoder) ([]xml.Attr, error) {
+	return *u, nil
+}
+
 type SpecDecoder interface {
 	Spec
 	OnDecoded(m *Model) error
@@ -123,7 +146,7 @@ func (e Marshalers) Get(target interface{}) bool {
 
 func (e Marshalers) encode(x *XMLEncoder) error {
 	for _, ext := range e {
-		if err := ext.Marshal3MF(x); err == nil {
+		if err := ext.Marshal3MF(x); err != nil {
 			return err
 		}
 	}
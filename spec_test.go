@@ -1,6 +1,8 @@
 package go3mf
 
 import (
+	"bytes"
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -110,3 +112,43 @@ func TestAttrMarshalers_Get_Panic(t *testing.T) {
 		})
 	}
 }
+
+type countMarshaler struct {
+	calls int
+	err   error
+}
+
+func (c *countMarshaler) Marshal3MF(_ *XMLEncoder) error {
+	c.calls++
+	return c.err
+}
+
+func TestMarshalers_encode(t *testing.T) {
+	errEncode := errors.New("encode error")
+	tests := []struct {
+		name      string
+		e         []*countMarshaler
+		wantErr   error
+		wantCalls []int
+	}{
+		{"empty", nil, nil, nil},
+		{"all", []*countMarshaler{{}, {}}, nil, []int{1, 1}},
+		{"error", []*countMarshaler{{err: errEncode}, {}}, errEncode, []int{1, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e Marshalers
+			for _, m := range tt.e {
+				e = append(e, m)
+			}
+			if err := e.encode(newXMLEncoder(new(bytes.Buffer), 6)); err != tt.wantErr {
+				t.Errorf("Marshalers.encode() error = %v, want %v", err, tt.wantErr)
+			}
+			for i, m := range tt.e {
+				if m.calls != tt.wantCalls[i] {
+					t.Errorf("Marshalers.encode() calls[%d] = %d, want %d", i, m.calls, tt.wantCalls[i])
+				}
+			}
+		})
+	}
+}
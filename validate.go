@@ -37,7 +37,7 @@ func (m *Model) sortedChilds() []string {
 // Validate checks that the model is conformant with the 3MF specs.
 func (m *Model) Validate() error {
 	var errs error
-	errs = errors.Append(errs, validateRelationship(m, m.RootRelationships, ""))
+	errs = errors.Append(errs, validateRelationship(m, m.RootRelationships, "", nil))
 	errs = errors.Append(errs, m.validateNamespaces())
 	rootPath := m.PathOrDefault()
 	sortedChilds := m.sortedChilds()
@@ -46,11 +46,14 @@ func (m *Model) Validate() error {
 		if path == rootPath {
 			errs = errors.Append(errs, errors.ErrOPCDuplicatedModelName)
 		} else {
-			errs = errors.Append(errs, validateRelationship(m, c.Relationships, path))
+			errs = errors.Append(errs, validateRelationship(m, c.Relationships, path, c.PrintTicket))
 		}
 	}
 
-	errs = errors.Append(errs, validateRelationship(m, m.Relationships, rootPath))
+	errs = errors.Append(errs, validateRelationship(m, m.Relationships, rootPath, m.PrintTicket))
+	for _, a := range m.Attachments {
+		errs = errors.Append(errs, validateRelationship(m, a.Relationships, a.Path, nil))
+	}
 	errs = errors.Append(errs, checkMetadadata(m, m.Metadata))
 
 	sortedSpecs := m.sortedSpecs()
@@ -202,6 +205,18 @@ func (res *Resources) validate(m *Model, path string) error {
 	return errs
 }
 
+// hasExtensionShape reports whether the object geometry is defined
+// by an element of a registered extension, such as a boolean shape.
+// Elements of unknown extensions do not define the geometry.
+func (r *Object) hasExtensionShape() bool {
+	for _, a := range r.Any {
+		if _, ok := a.(*UnknownTokens); !ok {
+			return true
+		}
+	}
+	return false
+}
+
 // Validate validates that the object is compliant with 3MF specs,
 // except for the mesh coherency.
 func (r *Object) Validate(m *Model, path string) error {
@@ -213,7 +228,7 @@ func (r *Object) Validate(m *Model, path string) error {
 	if r.PIndex != 0 && r.PID == 0 {
 		errs = errors.Append(errs, errors.NewMissingFieldError(attrPID))
 	}
-	if (r.Mesh != nil && len(r.Components) > 0) || (r.Mesh == nil && len(r.Components) == 0) {
+	if (r.Mesh != nil && len(r.Components) > 0) || (r.Mesh == nil && len(r.Components) == 0 && !r.hasExtensionShape()) {
 		errs = errors.Append(errs, errors.ErrInvalidObject)
 	}
 	if r.Mesh != nil {
@@ -291,6 +306,30 @@ func (r *Object) validateMesh(m *Model, path string) error {
 			}
 		}
 	}
+	errs = errors.Append(errs, r.Mesh.validateTriangleSets())
+	return errs
+}
+
+func (m *Mesh) validateTriangleSets() error {
+	var errs error
+	identifiers := make(map[string]struct{}, len(m.TriangleSets))
+	for i, set := range m.TriangleSets {
+		if set.Name == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrName), set, i))
+		}
+		if set.Identifier == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrIdentifier), set, i))
+		} else if _, ok := identifiers[set.Identifier]; ok {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrTriangleSetIdentifier, set, i))
+		}
+		identifiers[set.Identifier] = struct{}{}
+		for _, idx := range set.Indices {
+			if int(idx) >= len(m.Triangles) {
+				errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, set, i))
+				break
+			}
+		}
+	}
 	return errs
 }
 
@@ -323,11 +362,11 @@ func (m *Model) validateNamespaces() error {
 	return nil
 }
 
-func validateRelationship(m *Model, rels []Relationship, path string) error {
+func validateRelationship(m *Model, rels []Relationship, path string, ticket *PrintTicket) error {
 	var errs error
 	type partrel struct{ path, rel string }
 	visitedParts := make(map[partrel]struct{})
-	var hasPrintTicket bool
+	hasPrintTicket := ticket != nil
 	for i, r := range rels {
 		if r.Path == "" || r.Path[0] != '/' || strings.Contains(r.Path, "/.") {
 			errs = errors.Append(errs, errors.WrapIndex(errors.ErrOPCPartName, r, i))
@@ -32,6 +32,19 @@ func TestValidate(t *testing.T) {
 			fmt.Errorf("/3D/3dmodel.model@Relationship#7: %v", errors.ErrOPCContentType),
 			fmt.Errorf("/3D/3dmodel.model@Relationship#7: %v", errors.ErrOPCDuplicatedTicket),
 		}},
+		{"printTicket", &Model{PrintTicket: new(PrintTicket), Attachments: []Attachment{
+			{Path: "/a.xml", ContentType: ContentTypePrintTicket},
+		}, Relationships: []Relationship{{Path: "/a.xml", Type: RelTypePrintTicket}}, Childs: map[string]*ChildModel{"/b.model": {
+			PrintTicket: new(PrintTicket), Relationships: []Relationship{{Path: "/a.xml", Type: RelTypePrintTicket}},
+		}}}, []error{
+			fmt.Errorf("/b.model@Relationship#0: %v", errors.ErrOPCDuplicatedTicket),
+			fmt.Errorf("/3D/3dmodel.model@Relationship#0: %v", errors.ErrOPCDuplicatedTicket),
+		}},
+		{"attachmentRels", &Model{Attachments: []Attachment{{Path: "/a.bin", Relationships: []Relationship{
+			{Path: "/b.bin"},
+		}}}}, []error{
+			fmt.Errorf("/a.bin@Relationship#0: %v", errors.ErrOPCRelTarget),
+		}},
 		{"namespaces", &Model{Specs: map[string]Spec{"fake": &UnknownSpec{IsRequired: true}}}, []error{
 			errors.ErrRequiredExt,
 		}},
@@ -86,6 +99,10 @@ func TestValidate(t *testing.T) {
 			{ID: 1, PIndex: 1, Mesh: &Mesh{}, Components: []*Component{{ObjectID: 1}}},
 			{ID: 2, Mesh: &Mesh{Vertices: []Point3D{{}, {}, {}, {}}, Triangles: []Triangle{
 				NewTriangle(0, 1, 2), NewTriangle(0, 3, 1), NewTriangle(0, 2, 3), NewTriangle(1, 3, 2),
+			}, TriangleSets: []TriangleSet{
+				{Name: "a", Identifier: "a", Indices: []uint32{0, 1}},
+				{Identifier: "a", Indices: []uint32{2, 4}},
+				{Name: "c"},
 			}}},
 			{ID: 3, PID: 5, Components: []*Component{
 				{ObjectID: 3}, {ObjectID: 2}, {}, {ObjectID: 5}, {ObjectID: 100},
@@ -98,6 +115,7 @@ func TestValidate(t *testing.T) {
 					NewTrianglePID(0, 2, 3, 5, 1, 1, 0),
 					NewTrianglePID(1, 2, 3, 100, 0, 0, 0),
 				}}},
+			{ID: 7, Any: Marshalers{&UnknownTokens{xml.StartElement{Name: xml.Name{Space: "http://vendor.com/ext", Local: "shape"}}}}},
 		}}}, []error{
 			fmt.Errorf("Resources@Object#0: %v", errors.ErrMissingID),
 			fmt.Errorf("Resources@Object#0: %v", errors.ErrInvalidObject),
@@ -107,6 +125,10 @@ func TestValidate(t *testing.T) {
 			fmt.Errorf("Resources@Object#1@Mesh: %v", errors.ErrInsufficientVertices),
 			fmt.Errorf("Resources@Object#1@Mesh: %v", errors.ErrInsufficientTriangles),
 			fmt.Errorf("Resources@Object#1@Component#0: %v", errors.ErrRecursion),
+			fmt.Errorf("Resources@Object#2@Mesh@TriangleSet#1: %v", &errors.MissingFieldError{Name: attrName}),
+			fmt.Errorf("Resources@Object#2@Mesh@TriangleSet#1: %v", errors.ErrTriangleSetIdentifier),
+			fmt.Errorf("Resources@Object#2@Mesh@TriangleSet#1: %v", errors.ErrIndexOutOfBounds),
+			fmt.Errorf("Resources@Object#2@Mesh@TriangleSet#2: %v", &errors.MissingFieldError{Name: attrIdentifier}),
 			fmt.Errorf("Resources@Object#3: %v", errors.ErrComponentsPID),
 			fmt.Errorf("Resources@Object#3@Component#0: %v", errors.ErrRecursion),
 			fmt.Errorf("Resources@Object#3@Component#2: %v", &errors.MissingFieldError{Name: attrObjectID}),
@@ -122,6 +144,7 @@ func TestValidate(t *testing.T) {
 			fmt.Errorf("Resources@Object#5@Mesh@Triangle#0: %v", errors.ErrIndexOutOfBounds),
 			fmt.Errorf("Resources@Object#5@Mesh@Triangle#1: %v", errors.ErrIndexOutOfBounds),
 			fmt.Errorf("Resources@Object#5@Mesh@Triangle#3: %v", errors.ErrMissingResource),
+			fmt.Errorf("Resources@Object#6: %v", errors.ErrInvalidObject),
 		}},
 	}
 	for _, tt := range tests {